@@ -1,66 +1,49 @@
 package main
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
-	"go.uber.org/zap"
-
-	"mPR/db/migrations"
-	"mPR/internal/api/handlers"
-	"mPR/internal/api/routers"
 	"mPR/internal/config"
 	"mPR/internal/logger"
-	"mPR/internal/service"
-	"mPR/internal/storage/postgres"
-	"mPR/internal/storage/repository"
 )
 
+const usage = `Usage: server [command]
+
+Commands:
+  serve                 start the HTTP API (default)
+  migrate up            apply all pending migrations
+  migrate down N        roll back the last N migrations
+  migrate version       print the current schema version
+  migrate force V       set the schema version to V and clear the dirty flag
+
+Migrations target Postgres, or the SQLITE_PATH file when STORAGE=sqlite.`
+
 func main() {
 	cfg := config.Load()
 
 	log := logger.New(*cfg)
 	defer func() { _ = log.Sync() }()
 
-	migrations.Run(cfg.Postgres, log)
-
-	db := postgres.New(cfg.Postgres, log)
-
-	repos := repository.New(db)
-	services := service.New(repos, cfg.App.MaxReviewers)
-	api := handlers.New(log, services)
-	router := routers.Init(api, cfg.App.AdminToken)
-
-	addr := fmt.Sprintf(":%s", cfg.App.Port)
-	srv := &http.Server{
-		Addr:              addr,
-		Handler:           router,
-		ReadHeaderTimeout: 3 * time.Second,
-		ReadTimeout:       5 * time.Second,
-		WriteTimeout:      10 * time.Second,
-		IdleTimeout:       60 * time.Second,
+	args := os.Args[1:]
+	if len(args) == 0 {
+		serve(cfg, log)
+		return
 	}
 
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatal("Service down", zap.Error(err))
+	switch args[0] {
+	case "serve":
+		serve(cfg, log)
+	case "migrate":
+		if err := migrateCommand(args[1:], cfg, log); err != nil {
+			_ = log.Sync()
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			os.Exit(1)
 		}
-	}()
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Error shootdown service", zap.Error(err))
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s\n", args[0], usage)
+		os.Exit(2)
 	}
 }
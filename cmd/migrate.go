@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"mPR/db/migrations"
+	"mPR/internal/config"
+)
+
+func migrateCommand(args []string, cfg *config.Config, log *zap.Logger) error {
+	if len(args) == 0 {
+		return errors.New("missing subcommand\n\n" + usage)
+	}
+
+	migrator, err := newMigrator(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = migrator.Close() }()
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+		return printVersion(migrator)
+
+	case "down":
+		if len(args) != 2 {
+			return errors.New("usage: migrate down N")
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid number of steps %q", args[1])
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+		return printVersion(migrator)
+
+	case "version":
+		return printVersion(migrator)
+
+	case "force":
+		if len(args) != 2 {
+			return errors.New("usage: migrate force V")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+		return printVersion(migrator)
+
+	default:
+		return fmt.Errorf("unknown subcommand %q\n\n%s", args[0], usage)
+	}
+}
+
+// newMigrator picks the migration set of the configured storage; memory
+// storage has no schema, so it falls through to Postgres like before.
+func newMigrator(cfg *config.Config, log *zap.Logger) (*migrations.Migrator, error) {
+	if cfg.App.Storage == "sqlite" {
+		return migrations.NewSQLite(cfg.SQLite, log)
+	}
+
+	return migrations.New(cfg.Postgres, log)
+}
+
+func printVersion(migrator *migrations.Migrator) error {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d, dirty: %t, latest: %d\n", version, dirty, latest)
+	return nil
+}
@@ -0,0 +1,738 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type app struct {
+	client  *client
+	printer *printer
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var rosterContentTypes = map[string]string{
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+	".json": "application/json",
+	".csv":  "text/csv",
+}
+
+var commands = map[string]map[string]command{
+	"team": {
+		"add":    {"team add NAME --member ID:USERNAME[:inactive]...", teamAdd},
+		"get":    {"team get NAME", teamGet},
+		"import": {"team import FILE.(yaml|json|csv) [--dry-run] [--prune]", teamImport},
+		"list":   {"team list [--limit N] [--cursor C | --all]", teamList},
+	},
+	"user": {
+		"activate":   {"user activate USER_ID", userSetActive(true)},
+		"deactivate": {"user deactivate USER_ID", userSetActive(false)},
+		"slack":      {"user slack USER_ID [SLACK_MEMBER_ID]", userSetSlack},
+		"email":      {"user email USER_ID [ADDRESS] [--opt-out]", userSetEmail},
+		"list": {
+			"user list [--team NAME] [--active true|false] [--prefix USERNAME] [--limit N] [--cursor C | --all]",
+			userList,
+		},
+	},
+	"pr": {
+		"create":   {"pr create PR_ID --name NAME --author USER_ID", prCreate},
+		"merge":    {"pr merge PR_ID", prMerge},
+		"reassign": {"pr reassign PR_ID --old USER_ID", prReassign},
+		"review":   {"pr review PR_ID --reviewer USER_ID --decision approve|request-changes|comment", prReview},
+		"get":      {"pr get PR_ID", prGet},
+		"list": {
+			"pr list [--status OPEN|MERGED] [--author ID] [--reviewer ID] [--team NAME] [--search TEXT]\n" +
+				"        [--created-from T] [--created-to T] [--merged-from T] [--merged-to T]\n" +
+				"        [--sort created_at|name|id] [--order asc|desc] [--limit N] [--cursor C | --all]",
+			prList,
+		},
+	},
+	"reviews": {
+		"for": {"reviews for USER_ID [--status OPEN|MERGED|ALL] [--limit N] [--cursor C | --all]", reviewsFor},
+	},
+	"snapshot": {
+		"export": {"snapshot export [--file FILE.json]", snapshotExport},
+		"import": {"snapshot import FILE.json", snapshotImport},
+	},
+}
+
+type memberFlags []user
+
+func (m *memberFlags) String() string {
+	return fmt.Sprint(*m)
+}
+
+func (m *memberFlags) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return errors.New("member must be ID:USERNAME or ID:USERNAME:inactive")
+	}
+
+	member := user{UserID: parts[0], Username: parts[1], IsActive: true}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "active":
+		case "inactive":
+			member.IsActive = false
+		default:
+			return fmt.Errorf("unknown member state %q", parts[2])
+		}
+	}
+
+	*m = append(*m, member)
+	return nil
+}
+
+func teamAdd(ctx context.Context, a *app, args []string) error {
+	var members memberFlags
+	fs := flag.NewFlagSet("team add", flag.ContinueOnError)
+	fs.Var(&members, "member", "team member as ID:USERNAME[:inactive], repeatable")
+
+	name, err := parseOne(fs, args, "NAME")
+	if err != nil {
+		return err
+	}
+
+	if members == nil {
+		members = memberFlags{}
+	}
+
+	var resp struct {
+		Team team `json:"team"`
+	}
+	body := map[string]any{"team_name": name, "members": members}
+	if err := a.client.post(ctx, "/team/add", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printTeam(resp.Team)
+}
+
+func teamGet(ctx context.Context, a *app, args []string) error {
+	name, err := parseOne(flag.NewFlagSet("team get", flag.ContinueOnError), args, "NAME")
+	if err != nil {
+		return err
+	}
+
+	var t team
+	if err := a.client.get(ctx, "/team/get", url.Values{"team_name": {name}}, &t); err != nil {
+		return err
+	}
+
+	return a.printTeam(t)
+}
+
+func teamImport(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("team import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only show the changes")
+	prune := fs.Bool("prune", false, "deactivate users missing from the roster")
+
+	path, err := parseOne(fs, args, "FILE")
+	if err != nil {
+		return err
+	}
+
+	contentType, ok := rosterContentTypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return fmt.Errorf("unsupported roster file %q, use .yaml, .json or .csv", path)
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read roster: %w", err)
+	}
+
+	query := url.Values{
+		"dry_run": {strconv.FormatBool(*dryRun)},
+		"prune":   {strconv.FormatBool(*prune)},
+	}
+
+	var plan rosterPlan
+	if err := a.client.upload(ctx, "/team/import", query, contentType, data, &plan); err != nil {
+		return err
+	}
+
+	return a.printer.print(plan, func() table {
+		t := table{headers: []string{"ACTION", "TEAM", "USER_ID", "FROM_TEAM", "FIELDS"}}
+		for _, c := range plan.Changes {
+			t.rows = append(t.rows, []string{c.Action, c.TeamName, c.UserID, c.FromTeam, strings.Join(c.Fields, ",")})
+		}
+		return t
+	})
+}
+
+func teamList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("team list", flag.ContinueOnError)
+	query, all, err := parsePageFlags(fs, args, nil)
+	if err != nil {
+		return err
+	}
+
+	teams, next, err := listPages[teamSummary](ctx, a, "/team/list", "teams", query, all)
+	if err != nil {
+		return err
+	}
+
+	err = a.printer.print(teamPage{Teams: teams, NextCursor: next}, func() table {
+		t := table{headers: []string{"TEAM", "MEMBERS", "ACTIVE"}}
+		for _, team := range teams {
+			t.rows = append(t.rows, []string{
+				team.TeamName,
+				strconv.FormatInt(team.Members, 10),
+				strconv.FormatInt(team.ActiveMembers, 10),
+			})
+		}
+		return t
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.printNextCursor(next)
+}
+
+func userList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("user list", flag.ContinueOnError)
+	query, all, err := parsePageFlags(fs, args, map[string]*string{
+		"team_name":       fs.String("team", "", "team name"),
+		"is_active":       fs.String("active", "", "true or false"),
+		"username_prefix": fs.String("prefix", "", "username prefix"),
+	})
+	if err != nil {
+		return err
+	}
+
+	users, next, err := listPages[userSummary](ctx, a, "/users/list", "users", query, all)
+	if err != nil {
+		return err
+	}
+
+	err = a.printer.print(userPage{Users: users, NextCursor: next}, func() table {
+		plain := make([]user, 0, len(users))
+		for _, u := range users {
+			plain = append(plain, u.user)
+		}
+
+		t := usersTable(plain)
+		t.headers = append(t.headers, "OPEN_REVIEWS")
+		for i, u := range users {
+			t.rows[i] = append(t.rows[i], strconv.FormatInt(u.OpenReviews, 10))
+		}
+		return t
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.printNextCursor(next)
+}
+
+func userSetActive(active bool) func(ctx context.Context, a *app, args []string) error {
+	return func(ctx context.Context, a *app, args []string) error {
+		userID, err := parseOne(flag.NewFlagSet("user", flag.ContinueOnError), args, "USER_ID")
+		if err != nil {
+			return err
+		}
+
+		var resp struct {
+			User user `json:"user"`
+		}
+		body := map[string]any{"user_id": userID, "is_active": active}
+		if err := a.client.post(ctx, "/users/setIsActive", body, &resp); err != nil {
+			return err
+		}
+
+		return a.printer.print(resp.User, func() table {
+			return usersTable([]user{resp.User})
+		})
+	}
+}
+
+// userSetSlack links the user to a Slack member ID, or unlinks them when the
+// ID is omitted.
+func userSetSlack(ctx context.Context, a *app, args []string) error {
+	positional, err := parseInterspersed(flag.NewFlagSet("user slack", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return errors.New("expected USER_ID and an optional SLACK_MEMBER_ID")
+	}
+
+	slackID := ""
+	if len(positional) == 2 {
+		slackID = positional[1]
+	}
+
+	var resp struct {
+		User user `json:"user"`
+	}
+	body := map[string]any{"user_id": positional[0], "slack_id": slackID}
+	if err := a.client.post(ctx, "/users/setSlackID", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printer.print(resp.User, func() table {
+		return usersTable([]user{resp.User})
+	})
+}
+
+// userSetEmail sets the user's address and email opt-out, or removes the
+// address when it is omitted.
+func userSetEmail(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("user email", flag.ContinueOnError)
+	optOut := fs.Bool("opt-out", false, "keep the address but send no email")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return errors.New("expected USER_ID and an optional ADDRESS")
+	}
+
+	address := ""
+	if len(positional) == 2 {
+		address = positional[1]
+	}
+
+	var resp struct {
+		User user `json:"user"`
+	}
+	body := map[string]any{"user_id": positional[0], "email": address, "email_opt_out": *optOut}
+	if err := a.client.post(ctx, "/users/setEmail", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printer.print(resp.User, func() table {
+		return usersTable([]user{resp.User})
+	})
+}
+
+func prCreate(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	name := fs.String("name", "", "pull request title")
+	author := fs.String("author", "", "author user ID")
+
+	prID, err := parseOne(fs, args, "PR_ID")
+	if err != nil {
+		return err
+	}
+	if *author == "" {
+		return errors.New("--author is required")
+	}
+
+	var resp struct {
+		PR pullRequest `json:"pr"`
+	}
+	body := map[string]any{"pull_request_id": prID, "pull_request_name": *name, "author_id": *author}
+	if err := a.client.post(ctx, "/pullRequest/create", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printPRs(resp.PR, []pullRequest{resp.PR})
+}
+
+func prMerge(ctx context.Context, a *app, args []string) error {
+	prID, err := parseOne(flag.NewFlagSet("pr merge", flag.ContinueOnError), args, "PR_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		PR pullRequest `json:"pr"`
+	}
+	if err := a.client.post(ctx, "/pullRequest/merge", map[string]any{"pull_request_id": prID}, &resp); err != nil {
+		return err
+	}
+
+	return a.printPRs(resp.PR, []pullRequest{resp.PR})
+}
+
+func prReassign(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("pr reassign", flag.ContinueOnError)
+	oldUser := fs.String("old", "", "reviewer to replace")
+
+	prID, err := parseOne(fs, args, "PR_ID")
+	if err != nil {
+		return err
+	}
+	if *oldUser == "" {
+		return errors.New("--old is required")
+	}
+
+	var resp reassignResult
+	body := map[string]any{"pull_request_id": prID, "old_user_id": *oldUser}
+	if err := a.client.post(ctx, "/pullRequest/reassign", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printer.print(resp, func() table {
+		t := prsTable([]pullRequest{resp.PR})
+		t.headers = append(t.headers, "REPLACED_BY")
+		t.rows[0] = append(t.rows[0], resp.ReplacedBy)
+		return t
+	})
+}
+
+var reviewDecisions = map[string]string{
+	"approve":         "APPROVED",
+	"request-changes": "CHANGES_REQUESTED",
+	"comment":         "COMMENTED",
+}
+
+func prReview(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("pr review", flag.ContinueOnError)
+	reviewer := fs.String("reviewer", "", "reviewer user ID")
+	decision := fs.String("decision", "", "approve, request-changes or comment")
+
+	prID, err := parseOne(fs, args, "PR_ID")
+	if err != nil {
+		return err
+	}
+	if *reviewer == "" {
+		return errors.New("--reviewer is required")
+	}
+
+	value, ok := reviewDecisions[*decision]
+	if !ok {
+		return fmt.Errorf("--decision must be approve, request-changes or comment, got %q", *decision)
+	}
+
+	var resp struct {
+		Review review `json:"review"`
+	}
+	body := map[string]any{"pull_request_id": prID, "reviewer_id": *reviewer, "decision": value}
+	if err := a.client.post(ctx, "/pullRequest/review", body, &resp); err != nil {
+		return err
+	}
+
+	return a.printer.print(resp.Review, func() table {
+		return table{
+			headers: []string{"PR_ID", "REVIEWER", "DECISION", "SUBMITTED"},
+			rows: [][]string{{
+				resp.Review.PullRequestID,
+				resp.Review.ReviewerID,
+				resp.Review.Decision,
+				formatTime(resp.Review.SubmittedAt),
+			}},
+		}
+	})
+}
+
+func prGet(ctx context.Context, a *app, args []string) error {
+	prID, err := parseOne(flag.NewFlagSet("pr get", flag.ContinueOnError), args, "PR_ID")
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		PR pullRequest `json:"pr"`
+	}
+	if err := a.client.get(ctx, "/pullRequest/get", url.Values{"pull_request_id": {prID}}, &resp); err != nil {
+		return err
+	}
+
+	return a.printPRs(resp.PR, []pullRequest{resp.PR})
+}
+
+func prList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("pr list", flag.ContinueOnError)
+	query, all, err := parsePageFlags(fs, args, map[string]*string{
+		"status":       fs.String("status", "", "OPEN or MERGED"),
+		"author_id":    fs.String("author", "", "author user ID"),
+		"reviewer_id":  fs.String("reviewer", "", "assigned reviewer user ID"),
+		"team_name":    fs.String("team", "", "author's team"),
+		"q":            fs.String("search", "", "text to find in the PR name"),
+		"created_from": fs.String("created-from", "", "created at or after, RFC 3339"),
+		"created_to":   fs.String("created-to", "", "created before, RFC 3339"),
+		"merged_from":  fs.String("merged-from", "", "merged at or after, RFC 3339"),
+		"merged_to":    fs.String("merged-to", "", "merged before, RFC 3339"),
+		"sort":         fs.String("sort", "", "created_at, name or id"),
+		"order":        fs.String("order", "", "asc or desc"),
+	})
+	if err != nil {
+		return err
+	}
+
+	prs, next, err := listPages[pullRequest](ctx, a, "/pullRequest/list", "pull_requests", query, all)
+	if err != nil {
+		return err
+	}
+
+	if err := a.printPRs(prPage{PullRequests: prs, NextCursor: next}, prs); err != nil {
+		return err
+	}
+
+	return a.printNextCursor(next)
+}
+
+func reviewsFor(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("reviews for", flag.ContinueOnError)
+	positional, query, all, err := parsePageArgs(fs, args, map[string]*string{
+		"status": fs.String("status", "", "OPEN (default), MERGED or ALL"),
+	})
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("expected exactly one USER_ID argument")
+	}
+	query.Set("user_id", positional[0])
+
+	prs, next, err := listPages[pullRequest](ctx, a, "/users/getReview", "pull_requests", query, all)
+	if err != nil {
+		return err
+	}
+
+	resp := userReviews{UserID: positional[0], PullRequests: prs, NextCursor: next}
+	if err := a.printPRs(resp, prs); err != nil {
+		return err
+	}
+
+	return a.printNextCursor(next)
+}
+
+func snapshotExport(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("snapshot export", flag.ContinueOnError)
+	file := fs.String("file", "", "write the snapshot to FILE instead of stdout")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected argument %q", positional[0])
+	}
+
+	var doc json.RawMessage
+	if err := a.client.get(ctx, "/admin/snapshot", nil, &doc); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, doc, "", "  "); err != nil {
+		return fmt.Errorf("format snapshot: %w", err)
+	}
+	out.WriteByte('\n')
+
+	if *file == "" {
+		_, err := a.printer.w.Write(out.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Clean(*file), out.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func snapshotImport(ctx context.Context, a *app, args []string) error {
+	path, err := parseOne(flag.NewFlagSet("snapshot import", flag.ContinueOnError), args, "FILE")
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read snapshot: %w", err)
+	}
+
+	var result snapshotResult
+	if err := a.client.upload(ctx, "/admin/snapshot", nil, "application/json", data, &result); err != nil {
+		return err
+	}
+
+	return a.printer.print(result, func() table {
+		t := table{
+			headers: []string{"TEAMS", "USERS", "PULL_REQUESTS", "REVIEWERS", "REASSIGNMENTS", "REVIEWS"},
+			rows: [][]string{{
+				strconv.Itoa(result.Teams),
+				strconv.Itoa(result.Users),
+				strconv.Itoa(result.PullRequests),
+				strconv.Itoa(result.Reviewers),
+				strconv.Itoa(result.Reassignments),
+				strconv.Itoa(result.Reviews),
+			}},
+		}
+		for _, w := range result.Warnings {
+			t.rows = append(t.rows, []string{"warning: " + w})
+		}
+		return t
+	})
+}
+
+func (a *app) printTeam(t team) error {
+	return a.printer.print(t, func() table {
+		return usersTable(t.Members)
+	})
+}
+
+func (a *app) printPRs(value any, prs []pullRequest) error {
+	return a.printer.print(value, func() table {
+		return prsTable(prs)
+	})
+}
+
+func usersTable(users []user) table {
+	t := table{headers: []string{"USER_ID", "USERNAME", "TEAM", "ACTIVE"}}
+	for _, u := range users {
+		teamName := ""
+		if u.TeamName != nil {
+			teamName = *u.TeamName
+		}
+		t.rows = append(t.rows, []string{u.UserID, u.Username, teamName, strconv.FormatBool(u.IsActive)})
+	}
+	return t
+}
+
+func prsTable(prs []pullRequest) table {
+	t := table{headers: []string{"PR_ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"}}
+	for _, pr := range prs {
+		t.rows = append(t.rows, []string{
+			pr.ID,
+			pr.Name,
+			pr.AuthorID,
+			pr.Status,
+			strings.Join(pr.AssignedReviewers, ","),
+			formatTime(pr.CreatedAt),
+			formatTime(pr.MergedAt),
+		})
+	}
+	return t
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+// parsePageFlags adds --limit, --cursor and --all to fs, parses args and
+// turns every non-empty filter into a query parameter.
+func parsePageFlags(fs *flag.FlagSet, args []string, filters map[string]*string) (url.Values, bool, error) {
+	positional, query, all, err := parsePageArgs(fs, args, filters)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(positional) > 0 {
+		return nil, false, fmt.Errorf("unexpected argument %q", positional[0])
+	}
+
+	return query, all, nil
+}
+
+// parsePageArgs is parsePageFlags for commands that also take positional
+// arguments.
+func parsePageArgs(fs *flag.FlagSet, args []string, filters map[string]*string) ([]string, url.Values, bool, error) {
+	limit := fs.Int("limit", 0, "page size")
+	cursor := fs.String("cursor", "", "cursor returned by the previous page")
+	all := fs.Bool("all", false, "follow cursors and fetch every page")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	query := url.Values{}
+	for name, value := range filters {
+		if *value != "" {
+			query.Set(name, *value)
+		}
+	}
+	if *limit > 0 {
+		query.Set("limit", strconv.Itoa(*limit))
+	}
+	if *cursor != "" {
+		query.Set("cursor", *cursor)
+	}
+
+	return positional, query, *all, nil
+}
+
+// listPages fetches one page of a list endpoint, or every page when all is
+// set, and returns the items stored under key with the last next_cursor.
+func listPages[T any](ctx context.Context, a *app, path, key string, query url.Values, all bool) ([]T, string, error) {
+	items := []T{}
+	for {
+		var page map[string]json.RawMessage
+		if err := a.client.get(ctx, path, query, &page); err != nil {
+			return nil, "", err
+		}
+
+		var batch []T
+		if err := json.Unmarshal(page[key], &batch); err != nil {
+			return nil, "", fmt.Errorf("decode %s: %w", key, err)
+		}
+		items = append(items, batch...)
+
+		var next string
+		if raw, ok := page["next_cursor"]; ok {
+			if err := json.Unmarshal(raw, &next); err != nil {
+				return nil, "", fmt.Errorf("decode next_cursor: %w", err)
+			}
+		}
+
+		if !all || next == "" {
+			return items, next, nil
+		}
+		query.Set("cursor", next)
+	}
+}
+
+func (a *app) printNextCursor(next string) error {
+	if a.printer.format != formatTable || next == "" {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(a.printer.w, "\nMore results: --cursor %s\n", next)
+	return err
+}
+
+// parseOne parses flags placed before or after the single positional argument.
+func parseOne(fs *flag.FlagSet, args []string, name string) (string, error) {
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return "", err
+	}
+
+	if len(positional) != 1 {
+		return "", fmt.Errorf("expected exactly one %s argument", name)
+	}
+
+	return positional[0], nil
+}
+
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
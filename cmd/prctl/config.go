@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultURL = "http://localhost:8080"
+
+type settings struct {
+	URL    string `yaml:"url"`
+	Token  string `yaml:"token"`
+	Output string `yaml:"output"`
+}
+
+// loadSettings merges the config file with the environment; environment
+// variables win so a one-off PRCTL_TOKEN=... overrides the saved token.
+func loadSettings(path string) (settings, error) {
+	s := settings{URL: defaultURL, Output: formatTable}
+
+	if path == "" {
+		path = os.Getenv("PRCTL_CONFIG")
+	}
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "prctl", "config.yaml")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(filepath.Clean(path))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return s, fmt.Errorf("read config %s: %w", path, err)
+		default:
+			if err := yaml.Unmarshal(data, &s); err != nil {
+				return s, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		}
+	}
+
+	if v := os.Getenv("PRCTL_URL"); v != "" {
+		s.URL = v
+	}
+	if v := os.Getenv("PRCTL_TOKEN"); v != "" {
+		s.Token = v
+	}
+	if v := os.Getenv("PRCTL_OUTPUT"); v != "" {
+		s.Output = v
+	}
+
+	return s, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	globals, rest, err := extractGlobals(args)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) < 2 {
+		fmt.Fprint(stdout, usage())
+		if len(rest) == 0 || rest[0] == "help" {
+			return nil
+		}
+		return errors.New("missing command")
+	}
+
+	group, ok := commands[rest[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", rest[0], usage())
+	}
+
+	cmd, ok := group[rest[1]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", rest[0]+" "+rest[1], usage())
+	}
+
+	s, err := loadSettings(globals["config"])
+	if err != nil {
+		return err
+	}
+	if v, ok := globals["url"]; ok {
+		s.URL = v
+	}
+	if v, ok := globals["token"]; ok {
+		s.Token = v
+	}
+	if v, ok := globals["output"]; ok {
+		s.Output = v
+	}
+
+	a := &app{
+		client:  newClient(s.URL, s.Token),
+		printer: &printer{w: stdout, format: s.Output},
+	}
+
+	if err := cmd.run(ctx, a, rest[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w\nusage: prctl %s", err, cmd.usage)
+	}
+
+	return nil
+}
+
+var globalFlags = map[string]string{
+	"url":    "url",
+	"token":  "token",
+	"output": "output",
+	"o":      "output",
+	"config": "config",
+}
+
+// extractGlobals pulls connection and output flags from anywhere in args so
+// they may be given before or after the subcommand.
+func extractGlobals(args []string) (map[string]string, []string, error) {
+	globals := make(map[string]string)
+	rest := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		key, ok := globalFlags[name]
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("flag %s needs a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+		globals[key] = value
+	}
+
+	return globals, rest, nil
+}
+
+func usage() string {
+	var b strings.Builder
+	b.WriteString("Usage: prctl [--url URL] [--token TOKEN] [-o table|json|yaml] [--config FILE] COMMAND\n\nCommands:\n")
+
+	lines := make([]string, 0)
+	for _, group := range commands {
+		for _, cmd := range group {
+			lines = append(lines, "  "+cmd.usage)
+		}
+	}
+	sort.Strings(lines)
+	b.WriteString(strings.Join(lines, "\n"))
+
+	b.WriteString("\n\nEnvironment: PRCTL_URL, PRCTL_TOKEN, PRCTL_OUTPUT, PRCTL_CONFIG\n")
+	b.WriteString("Config file (YAML, default $XDG_CONFIG_HOME/prctl/config.yaml): url, token, output\n")
+	return b.String()
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStubAPI(t *testing.T) *httptest.Server {
+	t.Setenv("PRCTL_CONFIG", t.TempDir()+"/missing.yaml")
+	t.Setenv("PRCTL_URL", "")
+	t.Setenv("PRCTL_TOKEN", "")
+	t.Setenv("PRCTL_OUTPUT", "")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /team/get", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "backend", r.URL.Query().Get("team_name"))
+		_, _ = w.Write([]byte(`{"team_name":"backend","members":[
+			{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true},
+			{"user_id":"u2","username":"Bob","team_name":"backend","is_active":false}]}`))
+	})
+	mux.HandleFunc("POST /users/setIsActive", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"invalid admin token","request_id":"req-1"}}`))
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"user": map[string]any{
+			"user_id": body["user_id"], "username": "Bob", "is_active": body["is_active"],
+		}})
+	})
+
+	mux.HandleFunc("POST /team/import", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "true", r.URL.Query().Get("dry_run"))
+		_, _ = w.Write([]byte(`{"dry_run":true,"prune":false,"summary":{"move_user":1},
+			"changes":[{"action":"move_user","team_name":"backend","user_id":"u3","from_team":"frontend"}]}`))
+	})
+
+	mux.HandleFunc("GET /pullRequest/list", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "OPEN", r.URL.Query().Get("status"))
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"pull_requests":[{"pull_request_id":"pr-2","pull_request_name":"Two",
+				"author_id":"u1","status":"OPEN","assigned_reviewers":["u2"]}],"next_cursor":"c1"}`))
+			return
+		}
+		assert.Equal(t, "c1", r.URL.Query().Get("cursor"))
+		_, _ = w.Write([]byte(`{"pull_requests":[{"pull_request_id":"pr-1","pull_request_name":"One",
+			"author_id":"u1","status":"OPEN","assigned_reviewers":[]}]}`))
+	})
+	mux.HandleFunc("GET /users/getReview", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "u2", r.URL.Query().Get("user_id"))
+		assert.Equal(t, "ALL", r.URL.Query().Get("status"))
+		_, _ = w.Write([]byte(`{"user_id":"u2","pull_requests":[{"pull_request_id":"pr-3","pull_request_name":"Three",
+			"author_id":"u1","status":"MERGED","assigned_reviewers":["u2"]}],"next_cursor":"c2"}`))
+	})
+	mux.HandleFunc("GET /team/list", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"teams":[{"team_name":"backend","members":3,"active_members":2}]}`))
+	})
+	mux.HandleFunc("GET /users/list", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "false", r.URL.Query().Get("is_active"))
+		_, _ = w.Write([]byte(`{"users":[{"user_id":"u2","username":"Bob","team_name":"backend",
+			"is_active":false,"open_reviews":4}]}`))
+	})
+	mux.HandleFunc("GET /admin/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"format":"pr-manager-snapshot","version":1,"teams":[{"team_name":"backend"}]}`))
+	})
+	mux.HandleFunc("POST /admin/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "pr-manager-snapshot", body["format"])
+		_, _ = w.Write([]byte(`{"teams":1,"users":0,"pull_requests":0,"reviewers":0}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRun_TeamGetTable(t *testing.T) {
+	srv := newStubAPI(t)
+	var out bytes.Buffer
+
+	err := run(context.Background(), []string{"--url", srv.URL, "team", "get", "backend"}, &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, "USER_ID  USERNAME  TEAM     ACTIVE\n"+
+		"u1       Alice     backend  true\n"+
+		"u2       Bob       backend  false\n", out.String())
+}
+
+func TestRun_TeamGetJSONAndYAML(t *testing.T) {
+	srv := newStubAPI(t)
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"team", "get", "backend", "-o", "json", "--url=" + srv.URL}, &jsonOut))
+	assert.Contains(t, jsonOut.String(), `"team_name": "backend"`)
+
+	var yamlOut bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"-o=yaml", "--url", srv.URL, "team", "get", "backend"}, &yamlOut))
+	assert.Contains(t, yamlOut.String(), "team_name: backend")
+	assert.Contains(t, yamlOut.String(), "- user_id: u1")
+}
+
+func TestRun_TokenFromEnv(t *testing.T) {
+	srv := newStubAPI(t)
+	t.Setenv("PRCTL_URL", srv.URL)
+	t.Setenv("PRCTL_TOKEN", "secret")
+
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"user", "deactivate", "u2", "-o", "json"}, &out)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"is_active": false`)
+}
+
+func TestRun_APIError(t *testing.T) {
+	srv := newStubAPI(t)
+
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"--url", srv.URL, "--token", "wrong", "user", "activate", "u2"}, &out)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401 UNAUTHORIZED: invalid admin token (request_id req-1)")
+}
+
+func TestRun_ValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":"VALIDATION_FAILED","message":"request does not match the API specification",
+			"fields":[{"in":"query","field":"limit","message":"number must be at most 200"}]}}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("PRCTL_CONFIG", t.TempDir()+"/missing.yaml")
+
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"--url", srv.URL, "team", "list", "--limit", "500"}, &out)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400 VALIDATION_FAILED: request does not match the API specification; limit: number must be at most 200")
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	err := run(context.Background(), []string{"team", "delete", "backend"}, &bytes.Buffer{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown command "team delete"`)
+}
+
+func TestRun_TeamImport(t *testing.T) {
+	srv := newStubAPI(t)
+
+	path := filepath.Join(t.TempDir(), "roster.csv")
+	require.NoError(t, os.WriteFile(path, []byte("team_name,user_id,username\nbackend,u3,Charlie\n"), 0o600))
+
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"--url", srv.URL, "team", "import", path, "--dry-run"}, &out)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "move_user")
+	assert.Contains(t, out.String(), "frontend")
+}
+
+func TestRun_SnapshotExportImport(t *testing.T) {
+	srv := newStubAPI(t)
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	err := run(context.Background(), []string{"--url", srv.URL, "snapshot", "export", "--file", path}, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"team_name": "backend"`)
+
+	var out bytes.Buffer
+	err = run(context.Background(), []string{"--url", srv.URL, "-o", "json", "snapshot", "import", path}, &out)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"teams": 1`)
+}
+
+func TestRun_PRList(t *testing.T) {
+	srv := newStubAPI(t)
+
+	var page bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "pr", "list", "--status", "OPEN"}, &page))
+	assert.Contains(t, page.String(), "pr-2")
+	assert.NotContains(t, page.String(), "pr-1")
+	assert.Contains(t, page.String(), "--cursor c1")
+
+	var all bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "pr", "list", "--status=OPEN", "--all"}, &all))
+	assert.Contains(t, all.String(), "pr-2")
+	assert.Contains(t, all.String(), "pr-1")
+	assert.NotContains(t, all.String(), "--cursor")
+}
+
+func TestRun_ReviewsFor(t *testing.T) {
+	srv := newStubAPI(t)
+
+	var out bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "reviews", "for", "u2", "--status", "ALL", "-o", "json"}, &out))
+	assert.Contains(t, out.String(), `"user_id": "u2"`)
+	assert.Contains(t, out.String(), `"pull_request_id": "pr-3"`)
+	assert.Contains(t, out.String(), `"next_cursor": "c2"`)
+
+	err := run(context.Background(), []string{"--url", srv.URL, "reviews", "for", "--status", "ALL"}, &out)
+	assert.ErrorContains(t, err, "expected exactly one USER_ID argument")
+}
+
+func TestRun_TeamAndUserList(t *testing.T) {
+	srv := newStubAPI(t)
+
+	var teams bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "team", "list"}, &teams))
+	assert.Equal(t, "TEAM     MEMBERS  ACTIVE\n"+
+		"backend  3        2\n", teams.String())
+
+	var users bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "user", "list", "--active", "false"}, &users))
+	assert.Equal(t, "USER_ID  USERNAME  TEAM     ACTIVE  OPEN_REVIEWS\n"+
+		"u2       Bob       backend  false   4\n", users.String())
+
+	var yamlOut bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"--url", srv.URL, "-o", "yaml", "user", "list", "--active=false"}, &yamlOut))
+	assert.Contains(t, yamlOut.String(), "- user_id: u2")
+	assert.Contains(t, yamlOut.String(), "open_reviews: 4")
+}
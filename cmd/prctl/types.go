@@ -0,0 +1,97 @@
+package main
+
+import "time"
+
+type user struct {
+	UserID   string  `json:"user_id" yaml:"user_id"`
+	Username string  `json:"username" yaml:"username"`
+	TeamName *string `json:"team_name,omitempty" yaml:"team_name,omitempty"`
+	IsActive bool    `json:"is_active" yaml:"is_active"`
+	SlackID  *string `json:"slack_id,omitempty" yaml:"slack_id,omitempty"`
+	Email    *string `json:"email,omitempty" yaml:"email,omitempty"`
+	OptOut   bool    `json:"email_opt_out,omitempty" yaml:"email_opt_out,omitempty"`
+}
+
+type team struct {
+	TeamName string `json:"team_name" yaml:"team_name"`
+	Members  []user `json:"members" yaml:"members"`
+}
+
+type pullRequest struct {
+	ID                string     `json:"pull_request_id" yaml:"pull_request_id"`
+	Name              string     `json:"pull_request_name" yaml:"pull_request_name"`
+	AuthorID          string     `json:"author_id" yaml:"author_id"`
+	Status            string     `json:"status" yaml:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers" yaml:"assigned_reviewers"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty" yaml:"created_at,omitempty"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty" yaml:"merged_at,omitempty"`
+}
+
+type prPage struct {
+	PullRequests []pullRequest `json:"pull_requests" yaml:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty" yaml:"next_cursor,omitempty"`
+}
+
+type teamSummary struct {
+	TeamName      string `json:"team_name" yaml:"team_name"`
+	Members       int64  `json:"members" yaml:"members"`
+	ActiveMembers int64  `json:"active_members" yaml:"active_members"`
+}
+
+type teamPage struct {
+	Teams      []teamSummary `json:"teams" yaml:"teams"`
+	NextCursor string        `json:"next_cursor,omitempty" yaml:"next_cursor,omitempty"`
+}
+
+type userSummary struct {
+	user        `yaml:",inline"`
+	OpenReviews int64 `json:"open_reviews" yaml:"open_reviews"`
+}
+
+type userPage struct {
+	Users      []userSummary `json:"users" yaml:"users"`
+	NextCursor string        `json:"next_cursor,omitempty" yaml:"next_cursor,omitempty"`
+}
+
+type review struct {
+	PullRequestID string     `json:"pull_request_id" yaml:"pull_request_id"`
+	ReviewerID    string     `json:"reviewer_id" yaml:"reviewer_id"`
+	Decision      string     `json:"decision" yaml:"decision"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty" yaml:"submitted_at,omitempty"`
+}
+
+type reassignResult struct {
+	PR         pullRequest `json:"pr" yaml:"pr"`
+	ReplacedBy string      `json:"replaced_by" yaml:"replaced_by"`
+}
+
+type userReviews struct {
+	UserID       string        `json:"user_id" yaml:"user_id"`
+	PullRequests []pullRequest `json:"pull_requests" yaml:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty" yaml:"next_cursor,omitempty"`
+}
+
+type rosterChange struct {
+	Action   string   `json:"action" yaml:"action"`
+	TeamName string   `json:"team_name,omitempty" yaml:"team_name,omitempty"`
+	UserID   string   `json:"user_id,omitempty" yaml:"user_id,omitempty"`
+	FromTeam string   `json:"from_team,omitempty" yaml:"from_team,omitempty"`
+	Fields   []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+type rosterPlan struct {
+	DryRun  bool           `json:"dry_run" yaml:"dry_run"`
+	Prune   bool           `json:"prune" yaml:"prune"`
+	Changes []rosterChange `json:"changes" yaml:"changes"`
+	Summary map[string]int `json:"summary" yaml:"summary"`
+}
+
+type snapshotResult struct {
+	Teams         int      `json:"teams" yaml:"teams"`
+	Users         int      `json:"users" yaml:"users"`
+	PullRequests  int      `json:"pull_requests" yaml:"pull_requests"`
+	Reviewers     int      `json:"reviewers" yaml:"reviewers"`
+	Reassignments int      `json:"reassignments" yaml:"reassignments"`
+	Reviews       int      `json:"reviews" yaml:"reviews"`
+	Warnings      []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
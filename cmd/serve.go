@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+
+	"mPR/db/migrations"
+	"mPR/internal/api/handlers"
+	"mPR/internal/api/openapi"
+	"mPR/internal/api/routers"
+	"mPR/internal/config"
+	"mPR/internal/email"
+	"mPR/internal/events"
+	"mPR/internal/grpcapi"
+	"mPR/internal/idempotency"
+	"mPR/internal/metrics"
+	"mPR/internal/ratelimit"
+	"mPR/internal/service"
+	"mPR/internal/service/users"
+	"mPR/internal/slack"
+	"mPR/internal/storage/postgres"
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/memory"
+	"mPR/internal/storage/sqlite"
+	"mPR/internal/tracing"
+)
+
+func serve(cfg *config.Config, log *zap.Logger) {
+	shutdownTracing, err := tracing.New(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatal("Error init tracing", zap.Error(err))
+	}
+
+	m := metrics.New()
+
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		log.Fatal("Error read embedded migrations", zap.Error(err))
+	}
+
+	var repos *repository.All
+	switch cfg.App.Storage {
+	case "postgres":
+		repos = openPostgres(cfg, m, log)
+	case "sqlite":
+		repos = openSQLite(cfg, m, log)
+	case "memory":
+		log.Warn("Using in-memory storage, all data is lost on restart")
+		repos = memory.New(schemaVersion)
+	default:
+		log.Fatal("Unknown storage", zap.String("storage", cfg.App.Storage))
+	}
+	m.Register(metrics.NewWorkloadCollector(repos.PullRequests, repos.Reviewers))
+
+	broker := events.NewBroker(64)
+	services := service.New(repos, broker, cfg.App.MaxReviewers, schemaVersion)
+	api := handlers.New(log, services)
+
+	var limiter ratelimit.Store = ratelimit.NewMemory()
+	if cfg.Limits.Backend == "postgres" {
+		limiter = repos.RateLimits
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatal("Error load OpenAPI specification", zap.Error(err))
+	}
+
+	router := routers.Init(api, cfg, spec, limiter, repos.IdempotencyKeys, m, log)
+
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	runBackground := func(run func(ctx context.Context)) {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			run(dispatchCtx)
+		}()
+	}
+
+	var notifiers []events.Sink
+	if len(cfg.Slack.Webhooks) > 0 {
+		// Half the claim lease leaves the other sinks room before the event is
+		// handed to another dispatcher.
+		budget := cfg.Events.ClaimLease / 2
+		client := slack.NewClient(&http.Client{Timeout: cfg.Slack.Timeout}, cfg.Slack.Retries, time.Second, budget)
+		notifier := slack.NewNotifier(client, cfg.Slack.Webhooks, repos.Users, repos.PullRequests, repos.JobRuns, log)
+		notifiers = append(notifiers, notifier)
+
+		if cfg.Slack.ReminderInterval > 0 {
+			runBackground(func(ctx context.Context) { notifier.RunReminders(ctx, cfg.Slack.ReminderInterval) })
+		}
+	}
+	if cfg.Email.SMTPHost != "" {
+		notifier := newEmailNotifier(cfg.Email, repos.Users, services.Users, repos.JobRuns, log)
+		notifiers = append(notifiers, notifier)
+
+		if cfg.Email.DigestAt != "" {
+			at, err := time.Parse("15:04", cfg.Email.DigestAt)
+			if err != nil {
+				log.Fatal("Invalid EMAIL_DIGEST_AT, want HH:MM", zap.Error(err))
+			}
+			runBackground(func(ctx context.Context) { notifier.RunDigests(ctx, at.Hour(), at.Minute()) })
+		}
+	}
+
+	runBackground(func(ctx context.Context) {
+		idempotency.RunSweeper(ctx, repos.IdempotencyKeys, cfg.App.IdempotencySweepInterval, log)
+	})
+	if cfg.Limits.Backend == "postgres" {
+		runBackground(func(ctx context.Context) { ratelimit.RunSweeper(ctx, limiter, log) })
+	}
+	runBackground(newDispatcher(cfg.Events, repos.Outbox, broker, notifiers, log).Run)
+
+	addr := fmt.Sprintf(":%s", cfg.App.Port)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           router,
+		ReadHeaderTimeout: 3 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+	srv.RegisterOnShutdown(services.Feed.Close)
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Service down", zap.Error(err))
+		}
+	}()
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.App.GRPCPort))
+	if err != nil {
+		log.Fatal("Error listen gRPC port", zap.Error(err))
+	}
+	grpcServer := grpcapi.New(services, cfg, limiter, m, log)
+
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("gRPC service down", zap.Error(err))
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatal("Error shootdown service", zap.Error(err))
+	}
+	grpcServer.Shutdown(ctx)
+
+	stopDispatch()
+	background.Wait()
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("Error flush traces", zap.Error(err))
+	}
+}
+
+func newDispatcher(
+	cfg config.Events,
+	outbox repository.Outbox,
+	broker *events.Broker,
+	notifiers []events.Sink,
+	log *zap.Logger,
+) *events.Dispatcher {
+	sinks := []events.Sink{broker}
+	if cfg.Log {
+		sinks = append(sinks, events.NewLogSink(log))
+	}
+	if cfg.WebhookURL != "" {
+		client := &http.Client{Timeout: cfg.WebhookTimeout}
+		sinks = append(sinks, events.NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret, client, cfg.WebhookRetries, time.Second))
+	}
+	sinks = append(sinks, notifiers...)
+
+	return events.NewDispatcher(outbox, cfg.DispatchInterval, cfg.BatchSize, cfg.ClaimLease, log, sinks...)
+}
+
+func newEmailNotifier(
+	cfg config.Email,
+	userRepo repository.Users,
+	userService *users.Service,
+	jobRuns repository.JobRuns,
+	log *zap.Logger,
+) *email.Notifier {
+	sender, err := email.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From, cfg.Timeout)
+	if err != nil {
+		log.Fatal("Invalid email settings", zap.Error(err))
+	}
+
+	templates, err := email.LoadTemplates(cfg.TemplatesDir)
+	if err != nil {
+		log.Fatal("Error load email templates", zap.Error(err))
+	}
+
+	return email.NewNotifier(sender, templates, userRepo, userService, jobRuns, log)
+}
+
+func openPostgres(cfg *config.Config, m *metrics.Metrics, log *zap.Logger) *repository.All {
+	if err := prepareSchema(cfg, log); err != nil {
+		log.Fatal("Database schema is not ready", zap.Error(err))
+	}
+
+	db := postgres.New(cfg.Postgres, log)
+	instrument(db, m, log)
+
+	return repository.New(db)
+}
+
+func openSQLite(cfg *config.Config, m *metrics.Metrics, log *zap.Logger) *repository.All {
+	if err := prepareSchema(cfg, log); err != nil {
+		log.Fatal("Database schema is not ready", zap.Error(err))
+	}
+
+	db := sqlite.New(cfg.SQLite, log)
+	instrument(db, m, log)
+
+	return repository.New(db)
+}
+
+func instrument(db *gorm.DB, m *metrics.Metrics, log *zap.Logger) {
+	if err := db.Use(m.GormPlugin()); err != nil {
+		log.Fatal("Error register metrics plugin", zap.Error(err))
+	}
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics())); err != nil {
+		log.Fatal("Error register tracing plugin", zap.Error(err))
+	}
+}
+
+func prepareSchema(cfg *config.Config, log *zap.Logger) error {
+	migrator, err := newMigrator(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = migrator.Close() }()
+
+	if cfg.App.MigrateOnStart {
+		return migrator.Up()
+	}
+
+	return migrator.CheckClean()
+}
@@ -3,30 +3,194 @@ package migrations
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"go.uber.org/zap"
 
+	"mPR/db/scripts"
+	sqlitescripts "mPR/db/scripts/sqlite"
 	"mPR/internal/config"
+	"mPR/internal/storage/sqlite"
 )
 
-func Run(cfg config.Database, log *zap.Logger) {
+type Migrator struct {
+	migrate *migrate.Migrate
+}
+
+func New(cfg config.Database, log *zap.Logger) (*Migrator, error) {
 	dsn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.Mode,
 	)
 
-	migrations, err := migrate.New("file://db/scripts", dsn)
+	src, err := iofs.New(scripts.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open embedded migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect migrations to database: %w", err)
+	}
+	m.Log = &migrateLogger{log: log}
+
+	return &Migrator{migrate: m}, nil
+}
+
+// NewSQLite migrates the database file named in cfg with the SQLite migration
+// set, which mirrors the Postgres one version for version.
+func NewSQLite(cfg config.SQLite, log *zap.Logger) (*Migrator, error) {
+	src, err := iofs.New(sqlitescripts.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open embedded migrations: %w", err)
+	}
+
+	db, err := sqlite3.WithInstance(sqlite.Open(cfg.Path), &sqlite3.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("connect migrations to database: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite3", db)
+	if err != nil {
+		return nil, fmt.Errorf("connect migrations to database: %w", err)
+	}
+	m.Log = &migrateLogger{log: log}
+
+	return &Migrator{migrate: m}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.migrate.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func (m *Migrator) Up() error {
+	if err := m.checkDirty(); err != nil {
+		return err
+	}
+
+	if err := m.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrap("apply migrations", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("number of steps must be positive, got %d", steps)
+	}
+
+	if err := m.checkDirty(); err != nil {
+		return err
+	}
+
+	if err := m.migrate.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return m.wrap("roll back migrations", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+func (m *Migrator) Force(version int) error {
+	if err := m.migrate.Force(version); err != nil {
+		return fmt.Errorf("force schema version %d: %w", version, err)
+	}
+
+	return nil
+}
+
+// CheckClean fails when the last migration did not finish, so the service is
+// never started against a half-applied schema.
+func (m *Migrator) CheckClean() error {
+	return m.checkDirty()
+}
+
+func (m *Migrator) checkDirty() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return dirtyError(version)
+	}
+
+	return nil
+}
+
+func (m *Migrator) wrap(action string, err error) error {
+	var dirty migrate.ErrDirty
+	if errors.As(err, &dirty) {
+		return dirtyError(uint(dirty.Version))
+	}
+
+	version, isDirty, verErr := m.Version()
+	if verErr == nil && isDirty {
+		return fmt.Errorf("%s: %w; %w", action, err, dirtyError(version))
+	}
+
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+func dirtyError(version uint) error {
+	return fmt.Errorf(
+		"schema version %d is dirty: a migration failed halfway, repair the database and run `migrate force %d`",
+		version, version,
+	)
+}
+
+// LatestVersion returns the newest migration shipped with the binary, which is
+// the schema version a healthy database is expected to be at.
+func LatestVersion() (uint, error) {
+	src, err := iofs.New(scripts.FS, ".")
 	if err != nil {
-		log.Panic("Error run migrations", zap.Error(err))
-		return
+		return 0, fmt.Errorf("open embedded migrations: %w", err)
 	}
-	defer func() { _, _ = migrations.Close() }()
+	defer func() { _ = src.Close() }()
 
-	if err := migrations.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		log.Info("Error application migrations", zap.Error(err))
-		return
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("read first migration: %w", err)
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read next migration: %w", err)
+		}
+		version = next
 	}
 }
+
+type migrateLogger struct {
+	log *zap.Logger
+}
+
+func (l *migrateLogger) Printf(format string, v ...any) {
+	l.log.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+func (l *migrateLogger) Verbose() bool {
+	return false
+}
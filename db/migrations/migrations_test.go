@@ -0,0 +1,111 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/db/migrations"
+	"mPR/db/scripts"
+	sqlitescripts "mPR/db/scripts/sqlite"
+	"mPR/internal/config"
+	"mPR/internal/storage/sqlite"
+)
+
+func TestLatestVersion_MatchesEmbeddedScripts(t *testing.T) {
+	files, err := fs.Glob(scripts.FS, "*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	version, err := migrations.LatestVersion()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(len(files)), version)
+}
+
+func TestEmbeddedScripts_HaveDownMigrations(t *testing.T) {
+	for name, fsys := range map[string]fs.FS{"postgres": scripts.FS, "sqlite": sqlitescripts.FS} {
+		files, err := fs.Glob(fsys, "*.up.sql")
+		require.NoError(t, err)
+
+		for _, up := range files {
+			down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+			_, err := fs.Stat(fsys, down)
+			assert.NoError(t, err, "missing %s/%s", name, down)
+		}
+	}
+}
+
+// The service reports LatestVersion as the expected schema for either
+// backend, so both migration sets must ship the same versions.
+func TestSQLiteScripts_MirrorPostgresVersions(t *testing.T) {
+	postgres, err := fs.Glob(scripts.FS, "*.up.sql")
+	require.NoError(t, err)
+	sqlite, err := fs.Glob(sqlitescripts.FS, "*.up.sql")
+	require.NoError(t, err)
+
+	assert.Equal(t, postgres, sqlite)
+}
+
+func TestSQLite_UpAndDown(t *testing.T) {
+	migrator, err := migrations.NewSQLite(config.SQLite{Path: filepath.Join(t.TempDir(), "test.db")}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { _ = migrator.Close() }()
+
+	latest, err := migrations.LatestVersion()
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up())
+	version, dirty, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+	assert.False(t, dirty)
+
+	require.NoError(t, migrator.Down(int(latest)))
+	version, _, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Zero(t, version)
+
+	require.NoError(t, migrator.Up())
+}
+
+func TestSQLite_PullRequestCreatedAtIsRequired(t *testing.T) {
+	cfg := config.SQLite{Path: filepath.Join(t.TempDir(), "test.db")}
+	migrator, err := migrations.NewSQLite(cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { _ = migrator.Close() }()
+
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Down(1))
+
+	db := sqlite.Open(cfg.Path)
+	defer func() { _ = db.Close() }()
+
+	_, err = db.Exec(`INSERT INTO teams (team_name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name) VALUES ('u1', 'Alice', 'backend');
+		INSERT INTO pull_requests (pr_id, pr_name, author_id, created_at, merged_at)
+		VALUES ('pr1', 'Open', 'u1', NULL, NULL),
+		       ('pr2', 'Merged', 'u1', NULL, '2025-05-01 10:00:00.000+00:00')`)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up())
+
+	var merged time.Time
+	require.NoError(t, db.QueryRow(`SELECT created_at FROM pull_requests WHERE pr_id = 'pr2'`).Scan(&merged))
+	assert.True(t, time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC).Equal(merged), merged)
+
+	var missing int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM pull_requests WHERE created_at IS NULL`).Scan(&missing))
+	assert.Zero(t, missing)
+
+	_, err = db.Exec(`INSERT INTO pull_requests (pr_id, pr_name, author_id, created_at) VALUES ('pr3', 'New', 'u1', NULL)`)
+	assert.ErrorContains(t, err, "pull_requests.created_at")
+	_, err = db.Exec(`UPDATE pull_requests SET created_at = NULL WHERE pr_id = 'pr1'`)
+	assert.ErrorContains(t, err, "pull_requests.created_at")
+}
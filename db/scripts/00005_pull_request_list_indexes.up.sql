@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_pr_created_at ON pull_requests(created_at, pr_id);
+CREATE INDEX IF NOT EXISTS idx_pr_status_created_at ON pull_requests(status, created_at, pr_id);
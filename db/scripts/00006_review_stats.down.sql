@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reviewer_reassignments;
+DROP INDEX IF EXISTS idx_reviewers_assigned_at;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS assigned_at;
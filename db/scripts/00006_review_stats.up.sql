@@ -0,0 +1,18 @@
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE reviewers r
+SET assigned_at = pr.created_at
+FROM pull_requests pr
+WHERE pr.pr_id = r.pr_id AND pr.created_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reviewers_assigned_at ON reviewers(assigned_at);
+
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pr_id) ON DELETE CASCADE,
+    old_reviewer_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    new_reviewer_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reassigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reassignments_reassigned_at ON reviewer_reassignments(reassigned_at);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS slack_id VARCHAR(32);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_outbox_team;
+CREATE INDEX IF NOT EXISTS idx_outbox_team ON outbox(team_name, id);
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(id) WHERE dispatched_at IS NULL;
+
+DROP INDEX IF EXISTS idx_outbox_unsequenced;
+DROP INDEX IF EXISTS idx_outbox_seq;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS seq;
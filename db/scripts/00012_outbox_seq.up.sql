@@ -0,0 +1,12 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+UPDATE outbox SET seq = id WHERE seq IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_seq ON outbox(seq);
+CREATE INDEX IF NOT EXISTS idx_outbox_unsequenced ON outbox(id) WHERE seq IS NULL;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(seq) WHERE dispatched_at IS NULL;
+
+DROP INDEX IF EXISTS idx_outbox_team;
+CREATE INDEX IF NOT EXISTS idx_outbox_team ON outbox(team_name, seq);
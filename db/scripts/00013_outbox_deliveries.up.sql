@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS outbox_deliveries (
+    event_id BIGINT NOT NULL REFERENCES outbox(id) ON DELETE CASCADE,
+    sink VARCHAR(50) NOT NULL,
+    delivered_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, sink)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    job VARCHAR(50) NOT NULL,
+    slot TIMESTAMPTZ NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (job, slot)
+);
@@ -0,0 +1,17 @@
+ALTER TABLE reviewer_reassignments ADD COLUMN IF NOT EXISTS old_assigned_at TIMESTAMPTZ;
+
+-- The old reviewer got the PR either from an earlier reassignment or when the
+-- PR was created, as 00006 assumed for assigned_at.
+UPDATE reviewer_reassignments rr
+SET old_assigned_at = COALESCE(
+    (SELECT MAX(prev.reassigned_at)
+     FROM reviewer_reassignments prev
+     WHERE prev.pr_id = rr.pr_id AND prev.new_reviewer_id = rr.old_reviewer_id AND prev.id < rr.id),
+    (SELECT pr.created_at FROM pull_requests pr WHERE pr.pr_id = rr.pr_id),
+    rr.reassigned_at
+)
+WHERE rr.old_assigned_at IS NULL;
+
+ALTER TABLE reviewer_reassignments ALTER COLUMN old_assigned_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reassignments_old_assigned_at ON reviewer_reassignments(old_assigned_at);
@@ -0,0 +1,6 @@
+-- full_at is when a bucket refills completely; from then on it is the same as a
+-- fresh one and can be swept. Existing rows get the migration time, so a bucket
+-- drained right before it may refill early once.
+ALTER TABLE rate_limits ADD COLUMN IF NOT EXISTS full_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_rate_limits_full_at ON rate_limits(full_at);
@@ -0,0 +1 @@
+ALTER TABLE pull_requests ALTER COLUMN created_at DROP NOT NULL;
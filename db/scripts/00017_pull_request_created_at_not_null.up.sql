@@ -0,0 +1,5 @@
+-- PR lists page by (created_at, pr_id); a NULL created_at would drop the row
+-- out of every cursor. Rows that never got one take their merge time, if any.
+UPDATE pull_requests SET created_at = COALESCE(merged_at, NOW()) WHERE created_at IS NULL;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
@@ -0,0 +1,6 @@
+package scripts
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reviewers;
+DROP TABLE IF EXISTS pull_requests;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
\ No newline at end of file
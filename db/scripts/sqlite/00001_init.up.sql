@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS teams (
+    team_name VARCHAR(100) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    user_id VARCHAR(100) PRIMARY KEY,
+    username VARCHAR(100) NOT NULL,
+    team_name VARCHAR(100) REFERENCES teams(team_name) ON DELETE SET NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS pull_requests (
+    pr_id VARCHAR(100) PRIMARY KEY,
+    pr_name VARCHAR(100) NOT NULL,
+    author_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'OPEN',
+    created_at DATETIME DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    merged_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS reviewers (
+    pr_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pr_id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (pr_id, reviewer_id)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_team_name;
+DROP INDEX IF EXISTS idx_pr_author;
+DROP INDEX IF EXISTS idx_reviewer_user;
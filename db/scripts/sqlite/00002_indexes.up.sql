@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests(author_id);
+CREATE INDEX IF NOT EXISTS idx_reviewer_user ON reviewers(reviewer_id);
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- ALTER TABLE only accepts a constant default; existing rows are backfilled
+-- from their PR and new rows always get assigned_at from the application.
+ALTER TABLE reviewers ADD COLUMN assigned_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00+00:00';
+
+UPDATE reviewers
+SET assigned_at = (SELECT pr.created_at FROM pull_requests pr WHERE pr.pr_id = reviewers.pr_id)
+WHERE EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pr_id = reviewers.pr_id AND pr.created_at IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS idx_reviewers_assigned_at ON reviewers(assigned_at);
+
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pr_id VARCHAR(100) NOT NULL REFERENCES pull_requests(pr_id) ON DELETE CASCADE,
+    old_reviewer_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    new_reviewer_id VARCHAR(100) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reassigned_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reassignments_reassigned_at ON reviewer_reassignments(reassigned_at);
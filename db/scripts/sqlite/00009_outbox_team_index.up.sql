@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_outbox_team ON outbox(team_name, id);
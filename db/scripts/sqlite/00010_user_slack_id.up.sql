@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN slack_id VARCHAR(32);
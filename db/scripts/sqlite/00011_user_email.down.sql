@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN email_opt_out;
+ALTER TABLE users DROP COLUMN email;
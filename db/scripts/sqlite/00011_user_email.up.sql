@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN email VARCHAR(254);
+ALTER TABLE users ADD COLUMN email_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS job_runs (
+    job VARCHAR(50) NOT NULL,
+    slot DATETIME NOT NULL,
+    claimed_at DATETIME NOT NULL,
+    PRIMARY KEY (job, slot)
+);
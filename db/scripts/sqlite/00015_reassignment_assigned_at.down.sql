@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reassignments_old_assigned_at;
+ALTER TABLE reviewer_reassignments DROP COLUMN old_assigned_at;
@@ -0,0 +1,17 @@
+-- ALTER TABLE only accepts a constant default; existing rows are backfilled
+-- the way the Postgres migration does it and new rows always get
+-- old_assigned_at from the application.
+ALTER TABLE reviewer_reassignments ADD COLUMN old_assigned_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00+00:00';
+
+UPDATE reviewer_reassignments
+SET old_assigned_at = COALESCE(
+    (SELECT MAX(prev.reassigned_at)
+     FROM reviewer_reassignments prev
+     WHERE prev.pr_id = reviewer_reassignments.pr_id
+       AND prev.new_reviewer_id = reviewer_reassignments.old_reviewer_id
+       AND prev.id < reviewer_reassignments.id),
+    (SELECT pr.created_at FROM pull_requests pr WHERE pr.pr_id = reviewer_reassignments.pr_id),
+    reassigned_at
+);
+
+CREATE INDEX IF NOT EXISTS idx_reassignments_old_assigned_at ON reviewer_reassignments(old_assigned_at);
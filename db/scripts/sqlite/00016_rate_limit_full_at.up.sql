@@ -0,0 +1,6 @@
+-- ALTER TABLE only accepts a constant default, so existing rows count as full
+-- and are swept first; a bucket drained right before the migration may refill
+-- early once.
+ALTER TABLE rate_limits ADD COLUMN full_at DATETIME NOT NULL DEFAULT '1970-01-01 00:00:00+00:00';
+
+CREATE INDEX IF NOT EXISTS idx_rate_limits_full_at ON rate_limits(full_at);
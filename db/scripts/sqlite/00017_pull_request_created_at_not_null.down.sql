@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS pull_requests_created_at_update;
+DROP TRIGGER IF EXISTS pull_requests_created_at_insert;
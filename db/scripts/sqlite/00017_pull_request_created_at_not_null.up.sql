@@ -0,0 +1,19 @@
+-- SQLite cannot add NOT NULL to an existing column, and rebuilding the table
+-- would cascade deletes to everything referencing it, so triggers enforce it.
+UPDATE pull_requests
+SET created_at = COALESCE(merged_at, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+WHERE created_at IS NULL;
+
+CREATE TRIGGER IF NOT EXISTS pull_requests_created_at_insert
+BEFORE INSERT ON pull_requests
+WHEN NEW.created_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: pull_requests.created_at');
+END;
+
+CREATE TRIGGER IF NOT EXISTS pull_requests_created_at_update
+BEFORE UPDATE OF created_at ON pull_requests
+WHEN NEW.created_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'NOT NULL constraint failed: pull_requests.created_at');
+END;
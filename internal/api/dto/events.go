@@ -0,0 +1,7 @@
+package dto
+
+type StreamEvents struct {
+	TeamName    string `form:"team_name"`
+	UserID      string `form:"user_id"`
+	LastEventID string `form:"last_event_id"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type CreatePR struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
@@ -14,3 +16,25 @@ type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
 }
+
+type Review struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Decision      string `json:"decision"`
+}
+
+type ListPRs struct {
+	Status      string     `form:"status"`
+	AuthorID    string     `form:"author_id"`
+	ReviewerID  string     `form:"reviewer_id"`
+	TeamName    string     `form:"team_name"`
+	Query       string     `form:"q"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedFrom  *time.Time `form:"merged_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedTo    *time.Time `form:"merged_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort        string     `form:"sort"`
+	Order       string     `form:"order"`
+	Limit       int        `form:"limit"`
+	Cursor      string     `form:"cursor"`
+}
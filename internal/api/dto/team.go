@@ -6,5 +6,12 @@ type Team struct {
 		UserID   string `json:"user_id"`
 		Username string `json:"username"`
 		IsActive bool   `json:"is_active"`
+		SlackID  string `json:"slack_id"`
+		Email    string `json:"email"`
 	} `json:"members"`
 }
+
+type ListTeams struct {
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
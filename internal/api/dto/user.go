@@ -4,3 +4,29 @@ type SetIsActive struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
+
+type SetSlackID struct {
+	UserID  string `json:"user_id"`
+	SlackID string `json:"slack_id"`
+}
+
+type SetEmail struct {
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	EmailOptOut bool   `json:"email_opt_out"`
+}
+
+type GetReview struct {
+	UserID string `form:"user_id"`
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
+
+type ListUsers struct {
+	TeamName       string `form:"team_name"`
+	IsActive       *bool  `form:"is_active"`
+	UsernamePrefix string `form:"username_prefix"`
+	Limit          int    `form:"limit"`
+	Cursor         string `form:"cursor"`
+}
@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/db/migrations"
+	"mPR/internal/api/handlers"
+	"mPR/internal/config"
+	"mPR/internal/events"
+	"mPR/internal/service"
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/memory"
+	"mPR/internal/storage/sqlite"
+)
+
+// TestConcurrentCreates fires the same create from many clients at once
+// against real storage: exactly one must win and the rest must get 409.
+func TestConcurrentCreates(t *testing.T) {
+	backends := map[string]func(t *testing.T) *repository.All{
+		"memory": func(*testing.T) *repository.All { return memory.New(0) },
+		"sqlite": openSQLite,
+	}
+
+	for name, open := range backends {
+		t.Run(name, func(t *testing.T) {
+			api := handlers.New(zap.NewNop(), service.New(open(t), events.NewBroker(1), 2, 0))
+
+			router := gin.New()
+			router.POST("/team/add", api.AddTeam)
+			router.POST("/pullRequest/create", api.Create)
+
+			team := `{"team_name": "backend", "members": [
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true}
+			]}`
+			assertOneCreated(t, router, "/team/add", team)
+
+			pr := `{"pull_request_id": "pr-1001", "pull_request_name": "Add feature", "author_id": "u1"}`
+			assertOneCreated(t, router, "/pullRequest/create", pr)
+		})
+	}
+}
+
+func assertOneCreated(t *testing.T, router *gin.Engine, path, body string) {
+	t.Helper()
+
+	const clients = 20
+	codes := make([]int, clients)
+
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			codes[i] = w.Code
+		}()
+	}
+	wg.Wait()
+
+	counts := make(map[int]int)
+	for _, code := range codes {
+		counts[code]++
+	}
+	assert.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: clients - 1}, counts, path)
+}
+
+func openSQLite(t *testing.T) *repository.All {
+	cfg := config.SQLite{Path: filepath.Join(t.TempDir(), "handlers.db")}
+	log := zap.NewNop()
+
+	migrator, err := migrations.NewSQLite(cfg, log)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Close())
+
+	db := sqlite.New(cfg, log)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		require.NoError(t, sqlDB.Close())
+	})
+
+	return repository.New(db)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/dto"
+	"mPR/internal/api/responses"
+	"mPR/internal/service/feed"
+)
+
+// streamHeartbeat keeps idle streams alive through proxies that drop silent
+// connections.
+const streamHeartbeat = 15 * time.Second
+
+func (api *API) StreamEvents(c *gin.Context) {
+	var input dto.StreamEvents
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for StreamEvents", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid query parameters"))
+		return
+	}
+
+	// Browsers resend the ID of the last event in the header on reconnect;
+	// the query parameter serves clients that cannot set headers.
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = input.LastEventID
+	}
+
+	var after *int64
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			c.JSON(http.StatusBadRequest, responses.Error(c, "", "Last-Event-ID must be a non-negative integer"))
+			return
+		}
+		after = &id
+	}
+
+	filter := feed.Filter{TeamName: input.TeamName, UserID: input.UserID}
+	sub, err := api.services.Feed.Subscribe(c, filter, after)
+	if err != nil {
+		api.log(c).Error("Error subscribe to events", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+	defer sub.Close()
+
+	// The server write timeout is meant for ordinary requests and would cut
+	// the stream off.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		api.log(c).Warn("Error clear write deadline for event stream", zap.Error(err))
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		list, err := sub.Next(ctx, streamHeartbeat)
+		if err != nil {
+			if !errors.Is(err, feed.ErrClosed) && ctx.Err() == nil {
+				api.log(c).Error("Error read events", zap.Error(err))
+			}
+			return false
+		}
+
+		if len(list) == 0 {
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+
+		for _, event := range list {
+			err := sse.Encode(w, sse.Event{
+				Id:    strconv.FormatInt(event.ID, 10),
+				Event: string(event.Type),
+				Data:  event,
+			})
+			if err != nil {
+				return false
+			}
+		}
+
+		return true
+	})
+}
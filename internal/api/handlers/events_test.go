@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/handlers"
+	"mPR/internal/events"
+	"mPR/internal/service"
+	"mPR/internal/storage/repository/memory"
+)
+
+type streamed struct {
+	id    string
+	event string
+}
+
+func streamRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	repos := memory.New(0)
+	broker := events.NewBroker(8)
+	api := handlers.New(zap.NewNop(), service.New(repos, broker, 2, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher := events.NewDispatcher(repos.Outbox, 10*time.Millisecond, 100, time.Minute, zap.NewNop(), broker)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dispatcher.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	router := gin.New()
+	router.POST("/team/add", api.AddTeam)
+	router.POST("/pullRequest/create", api.Create)
+	router.GET("/events/stream", api.StreamEvents)
+
+	return router
+}
+
+func post(t *testing.T, router *gin.Engine, path, body string) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func seedTeams(t *testing.T, router *gin.Engine) {
+	t.Helper()
+
+	post(t, router, "/team/add", `{"team_name": "backend", "members": [
+		{"user_id": "u1", "username": "Alice", "is_active": true},
+		{"user_id": "u2", "username": "Bob", "is_active": true},
+		{"user_id": "u3", "username": "Carol", "is_active": true}
+	]}`)
+	post(t, router, "/team/add", `{"team_name": "frontend", "members": [
+		{"user_id": "u4", "username": "Dave", "is_active": true},
+		{"user_id": "u5", "username": "Eve", "is_active": true}
+	]}`)
+}
+
+// openStream connects to the stream and returns a function reading the next
+// n events from it.
+func openStream(t *testing.T, url string, header http.Header) func(n int) []streamed {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header = header
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	return func(n int) []streamed {
+		var out []streamed
+		var cur streamed
+		for len(out) < n && scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id:"):
+				cur.id = line[len("id:"):]
+			case strings.HasPrefix(line, "event:"):
+				cur.event = line[len("event:"):]
+			case line == "" && cur.event != "":
+				out = append(out, cur)
+				cur = streamed{}
+			}
+		}
+		require.Len(t, out, n, "stream ended early: %v", scanner.Err())
+		return out
+	}
+}
+
+func TestStreamEvents_ResumesFromLastEventID(t *testing.T) {
+	router := streamRouter(t)
+	seedTeams(t, router)
+	post(t, router, "/pullRequest/create", `{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"}`)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	// Events 1 and 2 are the teams; the backend filter drops frontend's.
+	next := openStream(t, srv.URL+"/events/stream?team_name=backend", http.Header{"Last-Event-ID": {"1"}})
+	assert.Equal(t, []streamed{
+		{id: "3", event: "PR_CREATED"},
+		{id: "4", event: "REVIEWER_ASSIGNED"},
+		{id: "5", event: "REVIEWER_ASSIGNED"},
+	}, next(3))
+}
+
+func TestStreamEvents_PushesNewEventsForUser(t *testing.T) {
+	router := streamRouter(t)
+	seedTeams(t, router)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	next := openStream(t, srv.URL+"/events/stream?user_id=u5", http.Header{})
+
+	post(t, router, "/pullRequest/create", `{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"}`)
+	post(t, router, "/pullRequest/create", `{"pull_request_id": "pr-2", "pull_request_name": "Fix layout", "author_id": "u4"}`)
+
+	assert.Equal(t, []streamed{
+		{id: "6", event: "PR_CREATED"},
+		{id: "7", event: "REVIEWER_ASSIGNED"},
+	}, next(2))
+}
+
+func TestStreamEvents_InvalidLastEventID(t *testing.T) {
+	router := streamRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/events/stream?last_event_id=abc", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
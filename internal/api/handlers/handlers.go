@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"mPR/internal/api/responses"
+	"mPR/internal/custom"
+	"mPR/internal/logger"
+	"mPR/internal/pagination"
 	"mPR/internal/service"
 )
 
@@ -19,6 +28,52 @@ func New(logger *zap.Logger, service *service.Manager) *API {
 	}
 }
 
+func (api *API) log(c *gin.Context) *zap.Logger {
+	return logger.WithContext(c, api.logger)
+}
+
+// validLimit rejects page sizes outside 0..pagination.MaxLimit, where 0 means the default.
+func (api *API) validLimit(c *gin.Context, limit int) bool {
+	if limit < 0 || limit > pagination.MaxLimit {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "limit must be between 1 and 200"))
+		return false
+	}
+
+	return true
+}
+
+func (api *API) listFailed(c *gin.Context, err error, msg string) {
+	if errors.Is(err, custom.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest,
+			responses.Error(c, "INVALID_CURSOR", "cursor is malformed or was issued for another query"),
+		)
+		return
+	}
+
+	api.log(c).Error(msg, zap.Error(err))
+	c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+}
+
+const readinessTimeout = 2 * time.Second
+
 func (api *API) Health(c *gin.Context) {
-	c.JSON(200, gin.H{"status": "ok"})
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (api *API) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (api *API) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, readinessTimeout)
+	defer cancel()
+
+	report := api.services.Health.Check(ctx)
+	if !report.Ready() {
+		api.log(c).Warn("Service not ready", zap.Any("checks", report.Checks))
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
 }
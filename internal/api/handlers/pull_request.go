@@ -17,20 +17,20 @@ func (api *API) Create(c *gin.Context) {
 	var input dto.CreatePR
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		api.logger.Warn("Wrong json for Create", zap.Error(err))
-		c.JSON(http.StatusBadRequest, responses.Error("", "invalid JSON"))
+		api.log(c).Warn("Wrong json for Create", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
 		return
 	}
 
 	if input.PullRequestID == "" {
-		api.logger.Warn("Empty pull_request_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "pull_request_id is required"))
+		api.log(c).Warn("Empty pull_request_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "pull_request_id is required"))
 		return
 	}
 
 	if input.AuthorID == "" {
-		api.logger.Warn("Empty author_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "author_id is required"))
+		api.log(c).Warn("Empty author_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "author_id is required"))
 		return
 	}
 
@@ -45,21 +45,21 @@ func (api *API) Create(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, custom.ErrPRExists) {
 			c.JSON(http.StatusConflict,
-				responses.Error("PR_EXISTS", "PR id already exists"),
+				responses.Error(c, "PR_EXISTS", "PR id already exists"),
 			)
 			return
 		}
 
 		if errors.Is(err, custom.ErrNotFound) {
 			c.JSON(http.StatusNotFound,
-				responses.Error("NOT_FOUND", "author or team not found"),
+				responses.Error(c, "NOT_FOUND", "author or team not found"),
 			)
 			return
 		}
 
-		api.logger.Error("Error create PR", zap.Error(err))
+		api.log(c).Error("Error create PR", zap.Error(err))
 		c.JSON(http.StatusInternalServerError,
-			responses.Error("", "internal server error"),
+			responses.Error(c, "", "internal server error"),
 		)
 		return
 	}
@@ -71,14 +71,14 @@ func (api *API) Merge(c *gin.Context) {
 	var input dto.Merge
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		api.logger.Warn("Wrong json for Merge", zap.Error(err))
-		c.JSON(http.StatusBadRequest, responses.Error("", "invalid JSON"))
+		api.log(c).Warn("Wrong json for Merge", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
 		return
 	}
 
 	if input.PRID == "" {
-		api.logger.Warn("Empty pull_request_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "pull_request_id is required"))
+		api.log(c).Warn("Empty pull_request_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "pull_request_id is required"))
 		return
 	}
 
@@ -86,14 +86,14 @@ func (api *API) Merge(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, custom.ErrNotFound) {
 			c.JSON(http.StatusNotFound,
-				responses.Error("NOT_FOUND", "resource not found"),
+				responses.Error(c, "NOT_FOUND", "resource not found"),
 			)
 			return
 		}
 
-		api.logger.Error("Error merge PR", zap.Error(err))
+		api.log(c).Error("Error merge PR", zap.Error(err))
 		c.JSON(http.StatusInternalServerError,
-			responses.Error("", "internal server error"),
+			responses.Error(c, "", "internal server error"),
 		)
 		return
 	}
@@ -105,20 +105,20 @@ func (api *API) Reassign(c *gin.Context) {
 	var input dto.ReassignRequest
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		api.logger.Warn("Wrong json for Reassign", zap.Error(err))
-		c.JSON(http.StatusBadRequest, responses.Error("", "invalid JSON"))
+		api.log(c).Warn("Wrong json for Reassign", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
 		return
 	}
 
 	if input.PullRequestID == "" {
-		api.logger.Warn("Empty pull_request_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "pull_request_id is required"))
+		api.log(c).Warn("Empty pull_request_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "pull_request_id is required"))
 		return
 	}
 
 	if input.OldUserID == "" {
-		api.logger.Warn("Empty old_user_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "old_user_id is required"))
+		api.log(c).Warn("Empty old_user_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "old_user_id is required"))
 		return
 	}
 
@@ -126,35 +126,36 @@ func (api *API) Reassign(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, custom.ErrNotFound) {
 			c.JSON(http.StatusNotFound,
-				responses.Error("NOT_FOUND", "PR or user not found"),
+				responses.Error(c, "NOT_FOUND", "PR or user not found"),
 			)
 			return
 		}
 
 		if errors.Is(err, custom.ErrPRMerged) {
 			c.JSON(http.StatusConflict,
-				responses.Error("PR_MERGED", "cannot reassign on merged PR"),
+				responses.Error(c, "PR_MERGED", "cannot reassign on merged PR"),
 			)
 			return
 		}
 
 		if errors.Is(err, custom.ErrNotAssigned) {
 			c.JSON(http.StatusConflict,
-				responses.Error("NOT_ASSIGNED", "reviewer is not assigned to this PR"),
+				responses.Error(c, "NOT_ASSIGNED", "reviewer is not assigned to this PR"),
 			)
 			return
 		}
 
 		if errors.Is(err, custom.ErrNoCandidate) {
+			_ = c.Error(err)
 			c.JSON(http.StatusConflict,
-				responses.Error("NO_CANDIDATE", "no active replacement candidate in team"),
+				responses.Error(c, "NO_CANDIDATE", "no active replacement candidate in team"),
 			)
 			return
 		}
 
-		api.logger.Error("Error reassign reviewer", zap.Error(err))
+		api.log(c).Error("Error reassign reviewer", zap.Error(err))
 		c.JSON(http.StatusInternalServerError,
-			responses.Error("", "internal server error"),
+			responses.Error(c, "", "internal server error"),
 		)
 		return
 	}
@@ -164,3 +165,141 @@ func (api *API) Reassign(c *gin.Context) {
 		"replaced_by": newReviewerID,
 	})
 }
+
+func (api *API) Review(c *gin.Context) {
+	var input dto.Review
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		api.log(c).Warn("Wrong json for Review", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
+		return
+	}
+
+	if input.PullRequestID == "" || input.ReviewerID == "" {
+		api.log(c).Warn("Empty pull_request_id or reviewer_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "pull_request_id and reviewer_id are required"))
+		return
+	}
+
+	switch input.Decision {
+	case custom.DecisionApproved, custom.DecisionChangesRequested, custom.DecisionCommented:
+	default:
+		c.JSON(http.StatusBadRequest,
+			responses.Error(c, "", "decision must be APPROVED, CHANGES_REQUESTED or COMMENTED"),
+		)
+		return
+	}
+
+	review, err := api.services.PullRequests.Review(c, input.PullRequestID, input.ReviewerID, input.Decision)
+	if err != nil {
+		if errors.Is(err, custom.ErrNotFound) {
+			c.JSON(http.StatusNotFound,
+				responses.Error(c, "NOT_FOUND", "resource not found"),
+			)
+			return
+		}
+
+		if errors.Is(err, custom.ErrPRMerged) {
+			c.JSON(http.StatusConflict,
+				responses.Error(c, "PR_MERGED", "cannot review merged PR"),
+			)
+			return
+		}
+
+		if errors.Is(err, custom.ErrNotAssigned) {
+			c.JSON(http.StatusConflict,
+				responses.Error(c, "NOT_ASSIGNED", "reviewer is not assigned to this PR"),
+			)
+			return
+		}
+
+		api.log(c).Error("Error review PR", zap.Error(err))
+		c.JSON(http.StatusInternalServerError,
+			responses.Error(c, "", "internal server error"),
+		)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"review": review})
+}
+
+func (api *API) GetPR(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		api.log(c).Warn("Empty pull_request_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "pull_request_id is required"))
+		return
+	}
+
+	pr, err := api.services.PullRequests.Get(c, prID)
+	if err != nil {
+		if errors.Is(err, custom.ErrNotFound) {
+			c.JSON(http.StatusNotFound,
+				responses.Error(c, "NOT_FOUND", "resource not found"),
+			)
+			return
+		}
+
+		api.log(c).Error("Error get PR", zap.Error(err))
+		c.JSON(http.StatusInternalServerError,
+			responses.Error(c, "", "internal server error"),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+func (api *API) ListPRs(c *gin.Context) {
+	var input dto.ListPRs
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for ListPRs", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid query parameters"))
+		return
+	}
+
+	if input.Status != "" && input.Status != custom.StatusOpen && input.Status != custom.StatusMerged {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "status must be OPEN or MERGED"))
+		return
+	}
+
+	switch input.Sort {
+	case "", models.SortCreatedAt, models.SortName, models.SortID:
+	default:
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "sort must be created_at, name or id"))
+		return
+	}
+
+	if input.Order != "" && input.Order != "asc" && input.Order != "desc" {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "order must be asc or desc"))
+		return
+	}
+
+	if !api.validLimit(c, input.Limit) {
+		return
+	}
+
+	filter := models.PullRequestFilter{
+		Status:      input.Status,
+		AuthorID:    input.AuthorID,
+		ReviewerID:  input.ReviewerID,
+		TeamName:    input.TeamName,
+		Search:      input.Query,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		MergedFrom:  input.MergedFrom,
+		MergedTo:    input.MergedTo,
+		Sort:        input.Sort,
+		Desc:        input.Order != "asc",
+		Limit:       input.Limit,
+	}
+
+	page, err := api.services.PullRequests.List(c, filter, input.Cursor)
+	if err != nil {
+		api.listFailed(c, err, "Error list PRs")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +13,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
 	"mPR/internal/api/handlers"
 	"mPR/internal/custom"
@@ -44,13 +44,12 @@ func TestCreatePR_Success(t *testing.T) {
 		{ID: "u3", Username: "Charlie", IsActive: true},
 	}
 
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(nil, gorm.ErrRecordNotFound)
 	mockUsers.EXPECT().GetByID(mock.Anything, "u1").Return(author, nil)
 	mockUsers.EXPECT().GetActiveByTeam(mock.Anything, "backend").Return(teamMembers, nil)
 	mockPR.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.PullRequests")).Return(nil)
 	mockReviewers.EXPECT().Add(mock.Anything, mock.AnythingOfType("[]models.Reviewers")).Return(nil)
 
-	prService := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	prService := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "PR_CREATED", "REVIEWER_ASSIGNED", "REVIEWER_ASSIGNED"), 2)
 	services := &service.Manager{PullRequests: prService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -91,10 +90,12 @@ func TestCreatePR_PRExists(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	existingPR := &models.PullRequests{ID: "pr-1001"}
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(existingPR, nil)
+	author := &models.Users{ID: "u1", Username: "Alice", TeamName: stringPtr("backend"), IsActive: true}
+	mockUsers.EXPECT().GetByID(mock.Anything, "u1").Return(author, nil)
+	mockUsers.EXPECT().GetActiveByTeam(mock.Anything, "backend").Return([]models.Users{*author}, nil)
+	mockPR.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.PullRequests")).Return(custom.ErrDuplicateKey)
 
-	prService := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	prService := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 	services := &service.Manager{PullRequests: prService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -126,12 +127,12 @@ func TestMergePR_Success(t *testing.T) {
 		CreatedAt: now.Add(-1 * time.Hour),
 	}
 
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(pr, nil)
+	mockPR.EXPECT().GetByIDForUpdate(mock.Anything, "pr-1001").Return(pr, nil)
 	mockPR.EXPECT().Update(mock.Anything, mock.MatchedBy(func(p *models.PullRequests) bool {
 		return p.Status == custom.StatusMerged && p.MergedAt != nil
 	})).Return(nil)
 
-	prService := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	prService := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "PR_MERGED"), 2)
 	services := &service.Manager{PullRequests: prService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -171,9 +172,10 @@ func TestReassignPR_Success(t *testing.T) {
 		Status:   custom.StatusOpen,
 	}
 
+	assignedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
 	reviewers := []models.Reviewers{
-		{PRID: "pr-1001", ReviewerID: "u2"},
-		{PRID: "pr-1001", ReviewerID: "u3"},
+		{PRID: "pr-1001", ReviewerID: "u2", AssignedAt: assignedAt},
+		{PRID: "pr-1001", ReviewerID: "u3", AssignedAt: assignedAt},
 	}
 
 	oldUser := &models.Users{
@@ -189,14 +191,16 @@ func TestReassignPR_Success(t *testing.T) {
 		{ID: "u4", Username: "Dave", IsActive: true},
 	}
 
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(pr, nil).Times(2)
+	mockPR.EXPECT().GetByIDForUpdate(mock.Anything, "pr-1001").Return(pr, nil)
+	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(pr, nil)
 	mockReviewers.EXPECT().GetByPR(mock.Anything, "pr-1001").Return(reviewers, nil)
 	mockUsers.EXPECT().GetByID(mock.Anything, "u2").Return(oldUser, nil)
 	mockUsers.EXPECT().GetActiveByTeam(mock.Anything, "backend").Return(candidates, nil)
 	mockReviewers.EXPECT().Delete(mock.Anything, "pr-1001", "u2").Return(nil)
 	mockReviewers.EXPECT().AddOne(mock.Anything, "pr-1001", "u4").Return(nil)
+	mockReviewers.EXPECT().LogReassignment(mock.Anything, "pr-1001", "u2", "u4", assignedAt).Return(nil)
 
-	prService := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	prService := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "REVIEWER_REASSIGNED"), 2)
 	services := &service.Manager{PullRequests: prService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -233,9 +237,9 @@ func TestReassignPR_PRMerged(t *testing.T) {
 		Status:   custom.StatusMerged,
 	}
 
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(pr, nil)
+	mockPR.EXPECT().GetByIDForUpdate(mock.Anything, "pr-1001").Return(pr, nil)
 
-	prService := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	prService := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 	services := &service.Manager{PullRequests: prService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -253,6 +257,76 @@ func TestReassignPR_PRMerged(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "PR_MERGED")
 }
 
+// expectEvents expects one outbox write holding events of the given types.
+func expectEvents(t *testing.T, types ...string) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(t)
+	outbox.EXPECT().Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, rows []models.Outbox) error {
+			recorded := make([]string, 0, len(rows))
+			for _, row := range rows {
+				recorded = append(recorded, row.Type)
+			}
+			assert.Equal(t, types, recorded)
+			return nil
+		}).Once()
+
+	return outbox
+}
+
+// passthroughTx runs the transaction body directly, as if it committed.
+func passthroughTx(t *testing.T) *mocks.MockTransactor {
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	return tx
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestListPRs_Filters(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+
+	mockPR.EXPECT().List(mock.Anything, mock.MatchedBy(func(f models.PullRequestFilter) bool {
+		return f.Status == custom.StatusMerged &&
+			f.TeamName == "backend" &&
+			f.Search == "search" &&
+			f.Sort == models.SortName &&
+			!f.Desc &&
+			f.MergedFrom != nil && f.MergedFrom.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	})).Return([]models.PullRequests{{ID: "pr-1", Name: "Add search", Status: custom.StatusMerged}}, nil)
+
+	prService := pull_requests.New(mocks.NewMockTransactor(t), mockPR, mocks.NewMockUsers(t), mocks.NewMockReviewers(t), mocks.NewMockOutbox(t), 2)
+	api := handlers.New(zap.NewNop(), &service.Manager{PullRequests: prService})
+
+	router := gin.New()
+	router.GET("/pullRequest/list", api.ListPRs)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/pullRequest/list?status=MERGED&team_name=backend&q=search&sort=name&order=asc&merged_from=2025-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-1"`)
+	assert.NotContains(t, w.Body.String(), "next_cursor")
+}
+
+func TestListPRs_InvalidParams(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{})
+
+	router := gin.New()
+	router.GET("/pullRequest/list", api.ListPRs)
+
+	for _, query := range []string{"status=CLOSED", "sort=author", "order=up", "limit=1000", "created_from=yesterday"} {
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?"+query, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/responses"
+	"mPR/internal/custom"
+	"mPR/internal/service/roster"
+)
+
+const maxRosterSize = 5 << 20
+
+var rosterFormats = map[string]string{
+	"application/yaml":   roster.FormatYAML,
+	"application/x-yaml": roster.FormatYAML,
+	"text/yaml":          roster.FormatYAML,
+	"application/json":   roster.FormatJSON,
+	"text/csv":           roster.FormatCSV,
+}
+
+func (api *API) ImportRoster(c *gin.Context) {
+	format := c.Query("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+		format = rosterFormats[mediaType]
+	}
+	if format == "" {
+		c.JSON(http.StatusUnsupportedMediaType,
+			responses.Error(c, "", "use Content-Type application/yaml, application/json or text/csv"),
+		)
+		return
+	}
+
+	dryRun, err := parseBoolQuery(c, "dry_run")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "dry_run must be a boolean"))
+		return
+	}
+
+	prune, err := parseBoolQuery(c, "prune")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "prune must be a boolean"))
+		return
+	}
+
+	parsed, err := roster.Parse(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxRosterSize))
+	if err != nil {
+		if !errors.Is(err, custom.ErrInvalidRoster) {
+			api.log(c).Warn("Error read roster", zap.Error(err))
+			c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid request body"))
+			return
+		}
+
+		api.log(c).Warn("Invalid roster", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "INVALID_ROSTER", err.Error()))
+		return
+	}
+
+	plan, err := api.services.Roster.Sync(c, parsed, roster.Options{DryRun: dryRun, Prune: prune})
+	if err != nil {
+		// Another request created a team of the roster while it was applied.
+		if errors.Is(err, custom.ErrTeamExists) {
+			c.JSON(http.StatusConflict,
+				responses.Error(c, "TEAM_EXISTS", "team_name already exists"),
+			)
+			return
+		}
+
+		api.log(c).Error("Error sync roster", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+func parseBoolQuery(c *gin.Context, name string) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
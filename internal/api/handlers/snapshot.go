@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/responses"
+	"mPR/internal/custom"
+	"mPR/internal/service/snapshot"
+)
+
+const maxSnapshotSize = 256 << 20
+
+func (api *API) ExportSnapshot(c *gin.Context) {
+	doc, err := api.services.Snapshot.Export(c)
+	if err != nil {
+		api.log(c).Error("Error export snapshot", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+
+	filename := fmt.Sprintf("snapshot-%s.json", doc.ExportedAt.Format("20060102T150405Z"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.JSON(http.StatusOK, doc)
+}
+
+func (api *API) ImportSnapshot(c *gin.Context) {
+	var doc snapshot.Document
+
+	decoder := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, maxSnapshotSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		api.log(c).Warn("Error read snapshot", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid request body"))
+		return
+	}
+
+	result, err := api.services.Snapshot.Import(c, &doc)
+	if err != nil {
+		switch {
+		case errors.Is(err, custom.ErrInvalidSnapshot):
+			api.log(c).Warn("Invalid snapshot", zap.Error(err))
+			c.JSON(http.StatusBadRequest, responses.Error(c, "INVALID_SNAPSHOT", err.Error()))
+		case errors.Is(err, custom.ErrDatabaseNotEmpty):
+			c.JSON(http.StatusConflict, responses.Error(c, "DATABASE_NOT_EMPTY", "snapshots can only be restored into an empty database"))
+		default:
+			api.log(c).Error("Error import snapshot", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
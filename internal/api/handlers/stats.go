@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/dto"
+	"mPR/internal/api/responses"
+	"mPR/internal/storage/models"
+)
+
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+func (api *API) ReviewerStats(c *gin.Context) {
+	window, ok := api.statsWindow(c)
+	if !ok {
+		return
+	}
+
+	report, err := api.services.Stats.Reviewers(c, window)
+	if err != nil {
+		api.log(c).Error("Error get reviewer stats", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (api *API) TeamStats(c *gin.Context) {
+	window, ok := api.statsWindow(c)
+	if !ok {
+		return
+	}
+
+	report, err := api.services.Stats.Teams(c, window)
+	if err != nil {
+		api.log(c).Error("Error get team stats", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (api *API) CycleTimeStats(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "format must be json or csv"))
+		return
+	}
+
+	window, ok := api.statsWindow(c)
+	if !ok {
+		return
+	}
+
+	report, err := api.services.Stats.CycleTimes(c, window)
+	if err != nil {
+		api.log(c).Error("Error get cycle times", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	filename := fmt.Sprintf("cycle-time-%s-%s.csv", window.From.Format(time.DateOnly), window.To.Format(time.DateOnly))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	if err := report.WriteCSV(c.Writer); err != nil {
+		api.log(c).Error("Error write cycle time CSV", zap.Error(err))
+	}
+}
+
+// statsWindow reads from/to, defaulting to the last 30 days.
+func (api *API) statsWindow(c *gin.Context) (models.StatsWindow, bool) {
+	var input dto.StatsWindow
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for stats", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "from and to must be RFC 3339 timestamps"))
+		return models.StatsWindow{}, false
+	}
+
+	window := models.StatsWindow{To: time.Now().UTC(), TeamName: input.TeamName}
+	if input.To != nil {
+		window.To = *input.To
+	}
+	window.From = window.To.Add(-defaultStatsWindow)
+	if input.From != nil {
+		window.From = *input.From
+	}
+
+	if !window.From.Before(window.To) {
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "from must be before to"))
+		return models.StatsWindow{}, false
+	}
+
+	return window, true
+}
@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/handlers"
+	"mPR/internal/service"
+	"mPR/internal/service/stats"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func TestReviewerStats_Window(t *testing.T) {
+	mockStats := mocks.NewMockStats(t)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mockStats.EXPECT().Reviewers(mock.Anything, mock.MatchedBy(func(w models.StatsWindow) bool {
+		return w.From.Equal(from) && w.To.Equal(to) && w.TeamName == "backend"
+	})).Return([]models.ReviewerStats{{UserID: "u1", Assignments: 3}}, nil)
+
+	api := handlers.New(zap.NewNop(), &service.Manager{Stats: stats.New(mockStats)})
+
+	router := gin.New()
+	router.GET("/stats/reviewers", api.ReviewerStats)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/stats/reviewers?from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z&team_name=backend", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"assignments":3`)
+}
+
+func TestReviewerStats_DefaultWindow(t *testing.T) {
+	mockStats := mocks.NewMockStats(t)
+
+	mockStats.EXPECT().Reviewers(mock.Anything, mock.MatchedBy(func(w models.StatsWindow) bool {
+		return w.To.Sub(w.From) == 30*24*time.Hour && time.Since(w.To) < time.Minute
+	})).Return(nil, nil)
+
+	api := handlers.New(zap.NewNop(), &service.Manager{Stats: stats.New(mockStats)})
+
+	router := gin.New()
+	router.GET("/stats/reviewers", api.ReviewerStats)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/reviewers", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reviewers":[]`)
+}
+
+func TestTeamStats_InvalidWindow(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{})
+
+	router := gin.New()
+	router.GET("/stats/teams", api.TeamStats)
+
+	for _, query := range []string{"from=last-week", "from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/teams?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestCycleTimeStats_CSV(t *testing.T) {
+	mockStats := mocks.NewMockStats(t)
+
+	mockStats.EXPECT().CycleTimes(mock.Anything, mock.Anything).Return([]models.CycleTimeStats{
+		{TeamName: "backend", PullRequests: 1},
+	}, nil)
+
+	api := handlers.New(zap.NewNop(), &service.Manager{Stats: stats.New(mockStats)})
+
+	router := gin.New()
+	router.GET("/stats/cycle-time", api.CycleTimeStats)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/stats/cycle-time?format=csv&from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "cycle-time-2025-01-01-2025-02-01.csv")
+	assert.Contains(t, w.Body.String(), "backend,all,1,")
+}
+
+func TestCycleTimeStats_UnknownFormat(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{})
+
+	router := gin.New()
+	router.GET("/stats/cycle-time", api.CycleTimeStats)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/cycle-time?format=xml", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -10,31 +10,55 @@ import (
 	"mPR/internal/api/dto"
 	"mPR/internal/api/responses"
 	"mPR/internal/custom"
+	userservice "mPR/internal/service/users"
+	"mPR/internal/slack"
 	"mPR/internal/storage/models"
 )
 
 func (api *API) AddTeam(c *gin.Context) {
 	var input dto.Team
 	if err := c.ShouldBindJSON(&input); err != nil {
-		api.logger.Warn("Wrong json for AddTeam", zap.Error(err))
-		c.JSON(http.StatusBadRequest, responses.Error("", "invalid JSON"))
+		api.log(c).Warn("Wrong json for AddTeam", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
 		return
 	}
 
 	users := make([]models.Users, 0, len(input.Members))
 	for _, m := range input.Members {
 		if m.UserID == "" {
-			api.logger.Warn("Empty user_id у member")
-			c.JSON(http.StatusBadRequest, responses.Error("", "user_id is required"))
+			api.log(c).Warn("Empty user_id у member")
+			c.JSON(http.StatusBadRequest, responses.Error(c, "", "user_id is required"))
 			return
 		}
 
-		users = append(users, models.Users{
+		user := models.Users{
 			ID:       m.UserID,
 			Username: m.Username,
 			IsActive: m.IsActive,
 			TeamName: &input.TeamName,
-		})
+		}
+		if m.SlackID != "" {
+			if !slack.ValidMemberID(m.SlackID) {
+				api.log(c).Warn("Invalid slack_id of member", zap.String("slack_id", m.SlackID))
+				c.JSON(http.StatusBadRequest,
+					responses.Error(c, "INVALID_SLACK_ID", "slack_id must be a Slack member ID like U024BE7LH"),
+				)
+				return
+			}
+			user.SlackID = &m.SlackID
+		}
+		if m.Email != "" {
+			if !userservice.ValidEmail(m.Email) {
+				api.log(c).Warn("Invalid email of member", zap.String("user_id", m.UserID))
+				c.JSON(http.StatusBadRequest,
+					responses.Error(c, "INVALID_EMAIL", "email must be a plain address like alice@example.com"),
+				)
+				return
+			}
+			user.Email = &m.Email
+		}
+
+		users = append(users, user)
 	}
 
 	team := models.Teams{Name: input.TeamName}
@@ -42,14 +66,14 @@ func (api *API) AddTeam(c *gin.Context) {
 	err := api.services.Teams.Add(c, &team, users)
 	if err != nil {
 		if errors.Is(err, custom.ErrTeamExists) {
-			c.JSON(http.StatusBadRequest,
-				responses.Error("TEAM_EXISTS", "team_name already exists"),
+			c.JSON(http.StatusConflict,
+				responses.Error(c, "TEAM_EXISTS", "team_name already exists"),
 			)
 			return
 		}
 
-		api.logger.Error("Error add team", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, responses.Error("", "internal server error"))
+		api.log(c).Error("Error add team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
 		return
 	}
 
@@ -60,8 +84,8 @@ func (api *API) AddTeam(c *gin.Context) {
 func (api *API) GetTeam(c *gin.Context) {
 	name := c.Query("team_name")
 	if name == "" {
-		api.logger.Warn("Missing team_name")
-		c.JSON(http.StatusBadRequest, responses.Error("", "team_name is required"))
+		api.log(c).Warn("Missing team_name")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "team_name is required"))
 		return
 	}
 
@@ -69,15 +93,37 @@ func (api *API) GetTeam(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, custom.ErrNotFound) {
 			c.JSON(http.StatusNotFound,
-				responses.Error("NOT_FOUND", "team not found"),
+				responses.Error(c, "NOT_FOUND", "team not found"),
 			)
 			return
 		}
 
-		api.logger.Error("Error get team", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, responses.Error("", "internal server error"))
+		api.log(c).Error("Error get team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
 		return
 	}
 
 	c.JSON(http.StatusOK, team)
 }
+
+func (api *API) ListTeams(c *gin.Context) {
+	var input dto.ListTeams
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for ListTeams", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid query parameters"))
+		return
+	}
+
+	if !api.validLimit(c, input.Limit) {
+		return
+	}
+
+	page, err := api.services.Teams.List(c, input.Limit, input.Cursor)
+	if err != nil {
+		api.listFailed(c, err, "Error list teams")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -11,9 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
 	"mPR/internal/api/handlers"
+	"mPR/internal/custom"
 	"mPR/internal/service"
 	"mPR/internal/service/teams"
 	"mPR/internal/storage/models"
@@ -24,11 +24,10 @@ func TestAddTeam_Success(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	mockTeams.EXPECT().GetByName(mock.Anything, "backend").Return(nil, gorm.ErrRecordNotFound)
 	mockTeams.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.Teams")).Return(nil)
 	mockUsers.EXPECT().CreateOrUpdate(mock.Anything, "backend", mock.AnythingOfType("[]models.Users")).Return(nil)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(passthroughTx(t), mockTeams, mockUsers, expectEvents(t, "TEAM_CREATED"))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -72,10 +71,9 @@ func TestAddTeam_TeamExists(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	existingTeam := &models.Teams{Name: "backend"}
-	mockTeams.EXPECT().GetByName(mock.Anything, "backend").Return(existingTeam, nil)
+	mockTeams.EXPECT().Create(mock.Anything, &models.Teams{Name: "backend"}).Return(custom.ErrDuplicateKey)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(passthroughTx(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -94,7 +92,7 @@ func TestAddTeam_TeamExists(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusConflict, w.Code)
 	assert.Contains(t, w.Body.String(), "TEAM_EXISTS")
 }
 
@@ -102,7 +100,7 @@ func TestAddTeam_InvalidJSON(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -124,7 +122,7 @@ func TestAddTeam_EmptyUserID(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -147,6 +145,29 @@ func TestAddTeam_EmptyUserID(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "user_id is required")
 }
 
+func TestAddTeam_InvalidSlackID(t *testing.T) {
+	teamService := teams.New(mocks.NewMockTransactor(t), mocks.NewMockTeams(t), mocks.NewMockUsers(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Teams: teamService})
+
+	router := gin.New()
+	router.POST("/team/add", api.AddTeam)
+
+	body := `{
+		"team_name": "backend",
+		"members": [
+			{"user_id": "u1", "username": "Alice", "is_active": true, "slack_id": "alice"}
+		]
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_SLACK_ID")
+}
+
 func TestGetTeam_Success(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
@@ -162,7 +183,7 @@ func TestGetTeam_Success(t *testing.T) {
 
 	mockTeams.EXPECT().GetByName(mock.Anything, "backend").Return(team, nil)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -191,7 +212,7 @@ func TestGetTeam_MissingTeamName(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -211,9 +232,9 @@ func TestGetTeam_NotFound(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	mockTeams.EXPECT().GetByName(mock.Anything, "nonexistent").Return(nil, gorm.ErrRecordNotFound)
+	mockTeams.EXPECT().GetByName(mock.Anything, "nonexistent").Return(nil, custom.ErrNotFound)
 
-	teamService := teams.New(mockTeams, mockUsers)
+	teamService := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 	services := &service.Manager{Teams: teamService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -228,3 +249,53 @@ func TestGetTeam_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	assert.Contains(t, w.Body.String(), "NOT_FOUND")
 }
+
+func TestListTeams_Pagination(t *testing.T) {
+	mockTeams := mocks.NewMockTeams(t)
+
+	mockTeams.EXPECT().List(mock.Anything, models.TeamFilter{Limit: 2}).Return([]models.TeamSummary{
+		{Name: "backend", Members: 3, ActiveMembers: 2},
+		{Name: "frontend", Members: 1, ActiveMembers: 1},
+	}, nil)
+	mockTeams.EXPECT().List(mock.Anything, models.TeamFilter{After: "backend", Limit: 2}).Return([]models.TeamSummary{
+		{Name: "frontend", Members: 1, ActiveMembers: 1},
+	}, nil)
+
+	api := handlers.New(zap.NewNop(), &service.Manager{Teams: teams.New(mocks.NewMockTransactor(t), mockTeams, mocks.NewMockUsers(t), mocks.NewMockOutbox(t))})
+
+	router := gin.New()
+	router.GET("/team/list", api.ListTeams)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/list?limit=1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var page struct {
+		Teams      []models.TeamSummary `json:"teams"`
+		NextCursor string               `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Equal(t, []models.TeamSummary{{Name: "backend", Members: 3, ActiveMembers: 2}}, page.Teams)
+	assert.NotEmpty(t, page.NextCursor)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/list?limit=1&cursor="+page.NextCursor, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"team_name":"frontend"`)
+	assert.NotContains(t, w.Body.String(), "next_cursor")
+}
+
+func TestListTeams_InvalidCursor(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{Teams: teams.New(mocks.NewMockTransactor(t), mocks.NewMockTeams(t), mocks.NewMockUsers(t), mocks.NewMockOutbox(t))})
+
+	router := gin.New()
+	router.GET("/team/list", api.ListTeams)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/list?cursor=garbage!", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+}
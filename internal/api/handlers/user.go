@@ -10,59 +10,173 @@ import (
 	"mPR/internal/api/dto"
 	"mPR/internal/api/responses"
 	"mPR/internal/custom"
+	"mPR/internal/storage/models"
 )
 
 func (api *API) SetIsActive(c *gin.Context) {
 	var input dto.SetIsActive
 	if err := c.ShouldBindJSON(&input); err != nil {
-		api.logger.Warn("Wrong json for SetIsActive", zap.Error(err))
-		c.JSON(http.StatusBadRequest, responses.Error("", "invalid JSON"))
+		api.log(c).Warn("Wrong json for SetIsActive", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
 		return
 	}
 
 	if input.UserID == "" {
-		api.logger.Warn("Empty user_id")
-		c.JSON(http.StatusBadRequest, responses.Error("", "user_id is required"))
+		api.log(c).Warn("Empty user_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "user_id is required"))
 		return
 	}
 
 	user, err := api.services.Users.SetActive(c, input.UserID, input.IsActive)
 	if err != nil {
 		if errors.Is(err, custom.ErrNotFound) {
-			c.JSON(http.StatusNotFound, responses.Error("NOT_FOUND", "user not found"))
+			c.JSON(http.StatusNotFound, responses.Error(c, "NOT_FOUND", "user not found"))
 			return
 		}
 
-		api.logger.Error("Failed to set is_active", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, responses.Error("", "internal server error"))
+		api.log(c).Error("Failed to set is_active", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
+func (api *API) SetSlackID(c *gin.Context) {
+	var input dto.SetSlackID
+	if err := c.ShouldBindJSON(&input); err != nil {
+		api.log(c).Warn("Wrong json for SetSlackID", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
+		return
+	}
+
+	if input.UserID == "" {
+		api.log(c).Warn("Empty user_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "user_id is required"))
+		return
+	}
+
+	user, err := api.services.Users.SetSlackID(c, input.UserID, input.SlackID)
+	if err != nil {
+		switch {
+		case errors.Is(err, custom.ErrInvalidSlackID):
+			c.JSON(http.StatusBadRequest, responses.Error(c, "INVALID_SLACK_ID", "slack_id must be a Slack member ID like U024BE7LH"))
+		case errors.Is(err, custom.ErrNotFound):
+			c.JSON(http.StatusNotFound, responses.Error(c, "NOT_FOUND", "user not found"))
+		default:
+			api.log(c).Error("Failed to set slack_id", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+func (api *API) SetEmail(c *gin.Context) {
+	var input dto.SetEmail
+	if err := c.ShouldBindJSON(&input); err != nil {
+		api.log(c).Warn("Wrong json for SetEmail", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid JSON"))
+		return
+	}
+
+	if input.UserID == "" {
+		api.log(c).Warn("Empty user_id")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "user_id is required"))
+		return
+	}
+
+	user, err := api.services.Users.SetEmail(c, input.UserID, input.Email, input.EmailOptOut)
+	if err != nil {
+		switch {
+		case errors.Is(err, custom.ErrInvalidEmail):
+			c.JSON(http.StatusBadRequest, responses.Error(c, "INVALID_EMAIL", "email must be a plain address like alice@example.com"))
+		case errors.Is(err, custom.ErrNotFound):
+			c.JSON(http.StatusNotFound, responses.Error(c, "NOT_FOUND", "user not found"))
+		default:
+			api.log(c).Error("Failed to set email", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, responses.Error(c, "", "internal server error"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// reviewStatusAll lifts the default open-only filter of GetReview.
+const reviewStatusAll = "ALL"
+
 func (api *API) GetReview(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		api.logger.Warn("Missing user_id for GetReview")
-		c.JSON(http.StatusBadRequest, responses.Error("", "user_id is required"))
+	var input dto.GetReview
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for GetReview", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid query parameters"))
+		return
+	}
+
+	if input.UserID == "" {
+		api.log(c).Warn("Missing user_id for GetReview")
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "user_id is required"))
 		return
 	}
 
-	prs, err := api.services.Users.GetUserReviews(c, userID)
+	filter := models.ReviewFilter{Limit: input.Limit}
+	switch input.Status {
+	case "":
+		filter.Status = custom.StatusOpen
+	case custom.StatusOpen, custom.StatusMerged:
+		filter.Status = input.Status
+	case reviewStatusAll:
+	default:
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "status must be OPEN, MERGED or ALL"))
+		return
+	}
+
+	if !api.validLimit(c, input.Limit) {
+		return
+	}
+
+	page, err := api.services.Users.GetUserReviews(c, input.UserID, filter, input.Cursor)
 	if err != nil {
 		if errors.Is(err, custom.ErrNotFound) {
-			c.JSON(http.StatusNotFound, responses.Error("NOT_FOUND", "user not found"))
+			c.JSON(http.StatusNotFound, responses.Error(c, "NOT_FOUND", "user not found"))
 			return
 		}
 
-		api.logger.Error("Error receiving reviews for user", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, responses.Error("", "internal server error"))
+		api.listFailed(c, err, "Error receiving reviews for user")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (api *API) ListUsers(c *gin.Context) {
+	var input dto.ListUsers
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		api.log(c).Warn("Wrong query for ListUsers", zap.Error(err))
+		c.JSON(http.StatusBadRequest, responses.Error(c, "", "invalid query parameters"))
+		return
+	}
+
+	if !api.validLimit(c, input.Limit) {
+		return
+	}
+
+	filter := models.UserFilter{
+		TeamName:       input.TeamName,
+		IsActive:       input.IsActive,
+		UsernamePrefix: input.UsernamePrefix,
+		Limit:          input.Limit,
+	}
+
+	page, err := api.services.Users.List(c, filter, input.Cursor)
+	if err != nil {
+		api.listFailed(c, err, "Error list users")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"user_id":       userID,
-		"pull_requests": prs,
-	})
+	c.JSON(http.StatusOK, page)
 }
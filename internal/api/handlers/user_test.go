@@ -12,10 +12,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
 
 	"mPR/internal/api/handlers"
 	"mPR/internal/api/middleware"
+	"mPR/internal/custom"
 	"mPR/internal/service"
 	"mPR/internal/service/users"
 	"mPR/internal/storage/models"
@@ -25,7 +25,6 @@ import (
 func TestSetIsActive_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
 	user := &models.Users{
 		ID:       "u1",
@@ -37,7 +36,7 @@ func TestSetIsActive_Success(t *testing.T) {
 	mockUsers.EXPECT().GetByID(mock.Anything, "u1").Return(user, nil)
 	mockUsers.EXPECT().UpdateIsActive(mock.Anything, "u1", false).Return(nil)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(passthroughTx(t), mockUsers, mockPR, expectEvents(t, "USER_ACTIVITY_CHANGED"))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -68,9 +67,8 @@ func TestSetIsActive_Success(t *testing.T) {
 func TestSetIsActive_Unauthorized(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -91,9 +89,8 @@ func TestSetIsActive_Unauthorized(t *testing.T) {
 func TestSetIsActive_InvalidToken(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -115,11 +112,10 @@ func TestSetIsActive_InvalidToken(t *testing.T) {
 func TestSetIsActive_UserNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	mockUsers.EXPECT().GetByID(mock.Anything, "u999").Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.EXPECT().GetByID(mock.Anything, "u999").Return(nil, custom.ErrNotFound)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -138,10 +134,91 @@ func TestSetIsActive_UserNotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "NOT_FOUND")
 }
 
+func TestSetSlackID_Success(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+	mockUsers.EXPECT().GetByID(mock.Anything, "u1").Return(&models.Users{ID: "u1", Username: "Alice"}, nil)
+	mockUsers.EXPECT().UpdateSlackID(mock.Anything, "u1", stringPtr("U024BE7LH")).Return(nil)
+
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: userService})
+
+	router := gin.New()
+	router.POST("/users/setSlackID", middleware.AdminAuth("test-token"), api.SetSlackID)
+
+	body := `{"user_id": "u1", "slack_id": "U024BE7LH"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setSlackID", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"slack_id":"U024BE7LH"`)
+}
+
+func TestSetSlackID_Invalid(t *testing.T) {
+	userService := users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: userService})
+
+	router := gin.New()
+	router.POST("/users/setSlackID", api.SetSlackID)
+
+	body := `{"user_id": "u1", "slack_id": "@alice"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setSlackID", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_SLACK_ID")
+}
+
+func TestSetEmail_OptOut(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+	mockUsers.EXPECT().GetByID(mock.Anything, "u1").Return(&models.Users{ID: "u1", Username: "Alice"}, nil)
+	mockUsers.EXPECT().UpdateEmail(mock.Anything, "u1", stringPtr("alice@example.com"), true).Return(nil)
+
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: userService})
+
+	router := gin.New()
+	router.POST("/users/setEmail", api.SetEmail)
+
+	body := `{"user_id": "u1", "email": "alice@example.com", "email_opt_out": true}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setEmail", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"email":"alice@example.com"`)
+	assert.Contains(t, w.Body.String(), `"email_opt_out":true`)
+}
+
+func TestSetEmail_Invalid(t *testing.T) {
+	userService := users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: userService})
+
+	router := gin.New()
+	router.POST("/users/setEmail", api.SetEmail)
+
+	body := `{"user_id": "u1", "email": "Alice <alice@example.com>"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setEmail", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_EMAIL")
+}
+
 func TestGetReview_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
 	user := &models.Users{
 		ID:       "u2",
@@ -150,7 +227,6 @@ func TestGetReview_Success(t *testing.T) {
 	}
 
 	now := time.Now()
-	prIDs := []string{"pr-1001", "pr-1002"}
 	prs := []models.PullRequests{
 		{
 			ID:        "pr-1001",
@@ -169,11 +245,9 @@ func TestGetReview_Success(t *testing.T) {
 	}
 
 	mockUsers.EXPECT().GetByID(mock.Anything, "u2").Return(user, nil)
-	mockReviewers.EXPECT().GetPRsByReviewer(mock.Anything, "u2").Return(prIDs, nil)
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1001").Return(&prs[0], nil)
-	mockPR.EXPECT().GetByID(mock.Anything, "pr-1002").Return(&prs[1], nil)
+	mockPR.EXPECT().GetByReviewer(mock.Anything, "u2", models.ReviewFilter{Status: "OPEN", Limit: 51}).Return(prs, nil)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -210,9 +284,8 @@ func TestGetReview_Success(t *testing.T) {
 func TestGetReview_MissingUserID(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -231,11 +304,10 @@ func TestGetReview_MissingUserID(t *testing.T) {
 func TestGetReview_UserNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	mockUsers.EXPECT().GetByID(mock.Anything, "u999").Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.EXPECT().GetByID(mock.Anything, "u999").Return(nil, custom.ErrNotFound)
 
-	userService := users.New(mockUsers, mockPR, mockReviewers)
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 	services := &service.Manager{Users: userService}
 	api := handlers.New(zap.NewNop(), services)
 
@@ -250,3 +322,73 @@ func TestGetReview_UserNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	assert.Contains(t, w.Body.String(), "NOT_FOUND")
 }
+
+func TestGetReview_StatusAll(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+	mockPR := mocks.NewMockPullRequests(t)
+
+	mockUsers.EXPECT().GetByID(mock.Anything, "u2").Return(&models.Users{ID: "u2"}, nil)
+	mockPR.EXPECT().GetByReviewer(mock.Anything, "u2", models.ReviewFilter{Limit: 11}).Return([]models.PullRequests{}, nil)
+
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))})
+
+	router := gin.New()
+	router.GET("/users/getReview", api.GetReview)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u2&status=ALL&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user_id":"u2","pull_requests":[]}`, w.Body.String())
+}
+
+func TestGetReview_InvalidStatus(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{
+		Users: users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t)),
+	})
+
+	router := gin.New()
+	router.GET("/users/getReview", api.GetReview)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u2&status=DRAFT", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "status must be OPEN, MERGED or ALL")
+}
+
+func TestListUsers_Filters(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+
+	mockUsers.EXPECT().List(mock.Anything, mock.MatchedBy(func(f models.UserFilter) bool {
+		return f.TeamName == "backend" && f.IsActive != nil && !*f.IsActive && f.UsernamePrefix == "al" && f.Limit == 51
+	})).Return([]models.UserSummary{
+		{Users: models.Users{ID: "u1", Username: "Alice", TeamName: stringPtr("backend")}, OpenReviews: 2},
+	}, nil)
+
+	userService := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+	api := handlers.New(zap.NewNop(), &service.Manager{Users: userService})
+
+	router := gin.New()
+	router.GET("/users/list", api.ListUsers)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/users/list?team_name=backend&is_active=false&username_prefix=al", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+	assert.Contains(t, w.Body.String(), `"open_reviews":2`)
+}
+
+func TestListUsers_InvalidActiveFlag(t *testing.T) {
+	api := handlers.New(zap.NewNop(), &service.Manager{})
+
+	router := gin.New()
+	router.GET("/users/list", api.ListUsers)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/list?is_active=maybe", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"mPR/internal/api/responses"
 )
 
 func AdminAuth(adminToken string) gin.HandlerFunc {
@@ -12,36 +14,21 @@ func AdminAuth(adminToken string) gin.HandlerFunc {
 		authHeader := c.GetHeader("Authorization")
 
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "missing Authorization header",
-				},
-			})
+			c.JSON(http.StatusUnauthorized, responses.Error(c, "UNAUTHORIZED", "missing Authorization header"))
 			c.Abort()
 			return
 		}
 
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "invalid Authorization header format",
-				},
-			})
+			c.JSON(http.StatusUnauthorized, responses.Error(c, "UNAUTHORIZED", "invalid Authorization header format"))
 			c.Abort()
 			return
 		}
 
 		token := parts[1]
 		if token != adminToken {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": gin.H{
-					"code":    "UNAUTHORIZED",
-					"message": "invalid admin token",
-				},
-			})
+			c.JSON(http.StatusUnauthorized, responses.Error(c, "UNAUTHORIZED", "invalid admin token"))
 			c.Abort()
 			return
 		}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/responses"
+	"mPR/internal/idempotency"
+	"mPR/internal/logger"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	idempotencyStoreTimeout  = 5 * time.Second
+	// maxIdempotentBodySize fits the largest body an idempotent route takes,
+	// a roster import.
+	maxIdempotentBodySize = 5 << 20
+)
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func Idempotency(store idempotency.Store, ttl time.Duration, log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if store == nil || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest,
+				responses.Error(c, "", "Idempotency-Key is too long"),
+			)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, responses.Error(c, "", "request body is too large"))
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, responses.Error(c, "", "invalid request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.FullPath() + "?" + c.Request.URL.RawQuery + "\n"))
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		// Keys are chosen by clients, so two callers may pick the same one;
+		// scoping by caller keeps them from seeing each other's responses.
+		scopedKey := idempotency.ScopedKey(clientKey(c), c.FullPath(), key)
+
+		existing, reserved, err := store.Reserve(c, scopedKey, requestHash, ttl)
+		if err != nil {
+			logger.WithContext(c, log).Error("Error reserve idempotency key", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError,
+				responses.Error(c, "", "internal server error"),
+			)
+			return
+		}
+
+		if !reserved {
+			replay(c, existing, requestHash)
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		defer func() {
+			if r := recover(); r != nil {
+				release(store, scopedKey, log)
+				panic(r)
+			}
+
+			if recorder.Status() >= http.StatusInternalServerError {
+				release(store, scopedKey, log)
+				return
+			}
+
+			ctx, cancel := detachedContext(c)
+			defer cancel()
+
+			if err := store.Complete(ctx, scopedKey, recorder.Status(), recorder.body.Bytes()); err != nil {
+				logger.WithContext(c, log).Error("Error store idempotent response", zap.Error(err))
+				release(store, scopedKey, log)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+func replay(c *gin.Context, record *idempotency.Record, requestHash string) {
+	if record.RequestHash != requestHash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity,
+			responses.Error(c, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request"),
+		)
+		return
+	}
+
+	if !record.Completed() {
+		c.AbortWithStatusJSON(http.StatusConflict,
+			responses.Error(c, "IDEMPOTENCY_IN_PROGRESS", "request with this Idempotency-Key is still in progress"),
+		)
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.StatusCode, "application/json; charset=utf-8", record.Body)
+	c.Abort()
+}
+
+// detachedContext outlives the request: the client may already have timed out,
+// and that is exactly when the outcome must be recorded for its retry.
+func detachedContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencyStoreTimeout)
+}
+
+func release(store idempotency.Store, key string, log *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	if err := store.Release(ctx, key); err != nil {
+		log.Error("Error release idempotency key", zap.Error(err))
+	}
+}
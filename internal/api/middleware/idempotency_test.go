@@ -0,0 +1,171 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/middleware"
+	"mPR/internal/idempotency"
+)
+
+func newIdempotentRouter(status *int, calls *int) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.Client(adminToken))
+	router.POST("/test", middleware.Idempotency(idempotency.NewMemory(), time.Hour, zap.NewNop()), func(c *gin.Context) {
+		*calls++
+		c.JSON(*status, gin.H{"call": *calls})
+	})
+	return router
+}
+
+func postWithKey(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	return postAs(router, "", key, body)
+}
+
+// postAs sends the request with the given bearer token, if any.
+func postAs(router *gin.Engine, token, key, body string) *httptest.ResponseRecorder {
+	return post(router, "/test", token, key, body)
+}
+
+func post(router *gin.Engine, target, token, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	first := postWithKey(router, "key-1", `{"a":1}`)
+	second := postWithKey(router, "key-1", `{"a":1}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Empty(t, first.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, "true", second.Header().Get(middleware.IdempotentReplayedHeader))
+}
+
+func TestIdempotency_DifferentBody(t *testing.T) {
+	status, calls := http.StatusOK, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	postWithKey(router, "key-1", `{"a":1}`)
+	w := postWithKey(router, "key-1", `{"a":2}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+}
+
+func TestIdempotency_DifferentQuery(t *testing.T) {
+	status, calls := http.StatusOK, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	post(router, "/test?dry_run=true", "", "key-1", `{"a":1}`)
+	w := post(router, "/test", "", "key-1", `{"a":1}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	status, calls := http.StatusOK, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	w := postWithKey(router, "key-1", `{"a":"`+strings.Repeat("x", 5<<20)+`"}`)
+
+	assert.Zero(t, calls)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestIdempotency_ServerErrorIsNotStored(t *testing.T) {
+	status, calls := http.StatusInternalServerError, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	postWithKey(router, "key-1", `{"a":1}`)
+
+	status = http.StatusOK
+	w := postWithKey(router, "key-1", `{"a":1}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	status, calls := http.StatusOK, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	postWithKey(router, "", `{"a":1}`)
+	postWithKey(router, "", `{"a":1}`)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_ScopedByClient(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	router := newIdempotentRouter(&status, &calls)
+
+	admin := postAs(router, adminToken, "key-1", `{"a":1}`)
+	anonymous := postAs(router, "", "key-1", `{"a":1}`)
+	other := postAs(router, "", "key-1", `{"a":2}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, `{"call":1}`, admin.Body.String())
+	assert.Equal(t, `{"call":2}`, anonymous.Body.String())
+	assert.Empty(t, anonymous.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+}
+
+// keyLengthStore fails like Postgres does when a key overflows the
+// idempotency_key VARCHAR(300) column.
+type keyLengthStore struct {
+	idempotency.Store
+}
+
+func (s keyLengthStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*idempotency.Record, bool, error) {
+	if len(key) > 300 {
+		return nil, false, errors.New("value too long for type character varying(300)")
+	}
+	return s.Store.Reserve(ctx, key, requestHash, ttl)
+}
+
+func TestIdempotency_LongestKeyFitsTheColumn(t *testing.T) {
+	calls := 0
+	router := gin.New()
+	router.Use(middleware.Client("secret-token"))
+	store := keyLengthStore{Store: idempotency.NewMemory()}
+	router.POST("/pullRequest/reassign", middleware.Idempotency(store, time.Hour, zap.NewNop()), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"call": calls})
+	})
+
+	key := strings.Repeat("k", 255)
+	first := post(router, "/pullRequest/reassign", "secret-token", key, `{"a":1}`)
+	second := post(router, "/pullRequest/reassign", "secret-token", key, `{"a":1}`)
+
+	assert.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Equal(t, "true", second.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/responses"
+	"mPR/internal/logger"
+)
+
+func AccessLog(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("route", c.FullPath()),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client", clientKey(c)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Int("response_size", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		entry := logger.WithContext(c, log)
+		switch {
+		case status >= http.StatusInternalServerError:
+			entry.Error("HTTP request", fields...)
+		case status >= http.StatusBadRequest:
+			entry.Warn("HTTP request", fields...)
+		default:
+			entry.Info("HTTP request", fields...)
+		}
+	}
+}
+
+func Recovery(log *zap.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.WithContext(c, log).Error("Panic recovered",
+			zap.Any("panic", recovered),
+			zap.Stack("stack"),
+		)
+		c.AbortWithStatusJSON(http.StatusInternalServerError,
+			responses.Error(c, "", "internal server error"),
+		)
+	})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mPR/internal/custom"
+	"mPR/internal/metrics"
+)
+
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+
+		for _, err := range c.Errors {
+			if errors.Is(err.Err, custom.ErrNoCandidate) {
+				m.IncNoCandidate(route)
+			}
+		}
+	}
+}
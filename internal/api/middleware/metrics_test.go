@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"mPR/internal/api/middleware"
+	"mPR/internal/custom"
+	"mPR/internal/metrics"
+)
+
+func scrape(m *metrics.Metrics) string {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return w.Body.String()
+}
+
+func TestMetrics_RecordsRouteAndStatus(t *testing.T) {
+	m := metrics.New()
+
+	router := gin.New()
+	router.Use(middleware.Metrics(m))
+	router.GET("/items/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	body := scrape(m)
+	assert.Contains(t, body, `pr_manager_http_requests_total{method="GET",route="/items/:id",status="204"} 1`)
+	assert.Contains(t, body, `pr_manager_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `pr_manager_http_request_duration_seconds_count{method="GET",route="/items/:id",status="204"} 1`)
+}
+
+func TestMetrics_CountsNoCandidate(t *testing.T) {
+	m := metrics.New()
+
+	router := gin.New()
+	router.Use(middleware.Metrics(m))
+	router.POST("/reassign", func(c *gin.Context) {
+		_ = c.Error(custom.ErrNoCandidate)
+		c.Status(http.StatusConflict)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/reassign", nil))
+
+	assert.Contains(t, scrape(m), `pr_manager_no_candidate_total{route="/reassign"} 1`)
+}
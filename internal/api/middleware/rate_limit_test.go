@@ -0,0 +1,118 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/middleware"
+	"mPR/internal/config"
+	"mPR/internal/ratelimit"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, float64, int) (bool, time.Duration, error) {
+	return false, 0, errors.New("db down")
+}
+
+func (failingStore) Sweep(context.Context, time.Time) error {
+	return errors.New("db down")
+}
+
+const adminToken = "admin-token"
+
+func newLimitedRouter(store ratelimit.Store, limit config.Limit, trustedProxies ...string) *gin.Engine {
+	router := gin.New()
+	_ = router.SetTrustedProxies(trustedProxies)
+	router.Use(middleware.Client(adminToken))
+	router.POST("/test", middleware.RateLimit(store, "test", limit, zap.NewNop()), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return router
+}
+
+func doRequest(router *gin.Engine, token string) *httptest.ResponseRecorder {
+	return doRequestVia(router, token, "")
+}
+
+// doRequestVia sends the request from httptest's 192.0.2.1 with the given
+// X-Forwarded-For, if any.
+func doRequestVia(router *gin.Engine, token, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_RejectsAfterBurst(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{Rate: 0.5, Burst: 2})
+
+	assert.Equal(t, http.StatusOK, doRequest(router, "").Code)
+	assert.Equal(t, http.StatusOK, doRequest(router, "").Code)
+
+	w := doRequest(router, "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":{"code":"RATE_LIMITED","message":"too many requests"}}`, w.Body.String())
+}
+
+func TestRateLimit_AdminTokenHasOwnBucket(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{Rate: 1, Burst: 1})
+
+	assert.Equal(t, http.StatusOK, doRequest(router, adminToken).Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, adminToken).Code)
+
+	assert.Equal(t, http.StatusOK, doRequest(router, "").Code)
+}
+
+func TestRateLimit_RotatingTokensShareBucket(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{Rate: 1, Burst: 1})
+
+	assert.Equal(t, http.StatusOK, doRequest(router, "token-a").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, "token-b").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(router, "").Code)
+}
+
+func TestRateLimit_ForwardedForIgnoredWithoutTrustedProxies(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{Rate: 1, Burst: 1})
+
+	assert.Equal(t, http.StatusOK, doRequestVia(router, "", "203.0.113.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequestVia(router, "", "203.0.113.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequestVia(router, "", "").Code)
+}
+
+func TestRateLimit_ForwardedForFromTrustedProxy(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{Rate: 1, Burst: 1}, "192.0.2.1")
+
+	assert.Equal(t, http.StatusOK, doRequestVia(router, "", "203.0.113.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequestVia(router, "", "203.0.113.1").Code)
+	assert.Equal(t, http.StatusOK, doRequestVia(router, "", "203.0.113.2").Code)
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	router := newLimitedRouter(ratelimit.NewMemory(), config.Limit{})
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, doRequest(router, "").Code)
+	}
+}
+
+func TestRateLimit_StoreErrorAllows(t *testing.T) {
+	router := newLimitedRouter(failingStore{}, config.Limit{Rate: 1, Burst: 1})
+
+	assert.Equal(t, http.StatusOK, doRequest(router, "").Code)
+}
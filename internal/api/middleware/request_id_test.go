@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"mPR/internal/api/middleware"
+	"mPR/internal/api/responses"
+	"mPR/internal/requestid"
+)
+
+func newLoggedRouter(log *zap.Logger) *gin.Engine {
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(middleware.RequestID(), middleware.AccessLog(log), middleware.Recovery(log))
+	router.GET("/fail", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, responses.Error(c, "NOT_FOUND", "user not found"))
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router
+}
+
+func TestRequestID_Propagated(t *testing.T) {
+	router := newLoggedRouter(zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(requestid.Header, "req-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "req-123", w.Header().Get(requestid.Header))
+	assert.JSONEq(t, `{"error":{"code":"NOT_FOUND","message":"user not found","request_id":"req-123"}}`, w.Body.String())
+}
+
+func TestRequestID_GeneratedWhenMissingOrInvalid(t *testing.T) {
+	router := newLoggedRouter(zap.NewNop())
+
+	for _, header := range []string{"", "bad id\nwith newline"} {
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		req.Header.Set(requestid.Header, header)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		id := w.Header().Get(requestid.Header)
+		assert.Len(t, id, 32)
+		assert.Contains(t, w.Body.String(), id)
+	}
+}
+
+func TestAccessLog_Fields(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	router := newLoggedRouter(zap.New(core))
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(requestid.Header, "req-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.FilterMessage("HTTP request").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "req-123", fields["request_id"])
+	assert.Equal(t, "GET", fields["method"])
+	assert.Equal(t, "/fail", fields["route"])
+	assert.EqualValues(t, http.StatusNotFound, fields["status"])
+	assert.Contains(t, fields, "latency")
+	assert.Contains(t, fields["client"], "ip:")
+}
+
+func TestRecovery_LogsPanic(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	router := newLoggedRouter(zap.New(core))
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(requestid.Header, "req-500")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), `"request_id":"req-500"`)
+
+	panics := logs.FilterMessage("Panic recovered").All()
+	require.Len(t, panics, 1)
+	assert.Equal(t, "req-500", panics[0].ContextMap()["request_id"])
+	assert.Len(t, logs.FilterMessage("HTTP request").All(), 1)
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+
+	"mPR/internal/api/openapi"
+	"mPR/internal/api/responses"
+)
+
+// ValidateRequest rejects requests that do not match the OpenAPI document with
+// 400 VALIDATION_FAILED and one field error per problem. Paths the document
+// does not know are passed on for the router to answer. Authentication is left
+// to AdminAuth, which has to run first on admin routes.
+func ValidateRequest(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, params, err := spec.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		skipBody, _ := route.Operation.Extensions[openapi.SkipBodyValidation].(bool)
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				ExcludeRequestBody: skipBody,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+
+		if err := openapi3filter.ValidateRequest(c, input); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, responses.ValidationError(c, fieldErrors(err)))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func fieldErrors(err error) []responses.FieldError {
+	var fields []responses.FieldError
+	for _, err := range flatten(err) {
+		var reqErr *openapi3filter.RequestError
+		if !errors.As(err, &reqErr) {
+			fields = append(fields, responses.FieldError{Message: err.Error()})
+			continue
+		}
+
+		switch {
+		case reqErr.Parameter != nil:
+			fields = append(fields, causes(reqErr, reqErr.Parameter.In, reqErr.Parameter.Name)...)
+		case reqErr.RequestBody != nil:
+			fields = append(fields, causes(reqErr, "body", "")...)
+		default:
+			fields = append(fields, responses.FieldError{Message: reqErr.Error()})
+		}
+	}
+
+	return fields
+}
+
+// causes turns the errors behind reqErr into field errors. Schema errors of a
+// body point at the offending field, e.g. members[0].user_id.
+func causes(reqErr *openapi3filter.RequestError, in, name string) []responses.FieldError {
+	if reqErr.Err == nil {
+		if in == "body" && strings.Contains(reqErr.Reason, "Content-Type") {
+			return []responses.FieldError{{In: "header", Field: "Content-Type", Message: reqErr.Reason}}
+		}
+		return []responses.FieldError{{In: in, Field: name, Message: reqErr.Reason}}
+	}
+
+	var fields []responses.FieldError
+	for _, err := range flatten(reqErr.Err) {
+		field := responses.FieldError{In: in, Field: name, Message: err.Error()}
+
+		var schemaErr *openapi3.SchemaError
+		var parseErr *openapi3filter.ParseError
+		switch {
+		case errors.As(err, &schemaErr):
+			if path := fieldPath(schemaErr.JSONPointer()); path != "" {
+				field.Field = joinPath(name, path)
+			}
+			field.Message = schemaErr.Reason
+			if schemaErr.SchemaField == "format" {
+				field.Message = fmt.Sprintf("must be a %s string", schemaErr.Schema.Format)
+			}
+		case errors.As(err, &parseErr):
+			field.Message = parseErr.Reason
+			if in == "body" {
+				field.Message = "invalid JSON"
+			}
+		case errors.Is(err, openapi3filter.ErrInvalidRequired):
+			field.Message = "is required"
+		case errors.Is(err, openapi3filter.ErrInvalidEmptyValue):
+			field.Message = "must not be empty"
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// flatten expands nested MultiErrors. It does not unwrap other errors, so a
+// RequestError keeps the parameter or body its causes belong to.
+func flatten(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var out []error
+	for _, e := range multi {
+		out = append(out, flatten(e)...)
+	}
+
+	return out
+}
+
+// fieldPath renders a JSON pointer as members[0].user_id.
+func fieldPath(pointer []string) string {
+	var b strings.Builder
+	for _, part := range pointer {
+		if _, err := strconv.Atoi(part); err == nil {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+func joinPath(name, path string) string {
+	if name == "" {
+		return path
+	}
+
+	return name + "." + path
+}
@@ -0,0 +1,146 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/api/middleware"
+	"mPR/internal/api/openapi"
+	"mPR/internal/api/responses"
+)
+
+// validatingRouter echoes the body the handler receives after validation.
+func validatingRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.ValidateRequest(spec))
+	router.Any("/*path", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	return router
+}
+
+func validate(t *testing.T, router *gin.Engine, method, target, contentType, body string) (int, []responses.FieldError, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		return w.Code, nil, w.Body.String()
+	}
+
+	var resp responses.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "VALIDATION_FAILED", resp.Error.Code)
+
+	return w.Code, resp.Error.Fields, ""
+}
+
+func TestValidateRequest_Body(t *testing.T) {
+	router := validatingRouter(t)
+
+	code, fields, _ := validate(t, router, http.MethodPost, "/team/add", "application/json",
+		`{"team_name": "", "members": [{"username": "Alice"}, {"user_id": 5}]}`)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.ElementsMatch(t, []responses.FieldError{
+		{In: "body", Field: "team_name", Message: "minimum string length is 1"},
+		{In: "body", Field: "members[0].user_id", Message: `property "user_id" is missing`},
+		{In: "body", Field: "members[1].user_id", Message: "value must be a string"},
+	}, fields)
+}
+
+func TestValidateRequest_PassesBodyOn(t *testing.T) {
+	router := validatingRouter(t)
+
+	body := `{"pull_request_id": "pr-1", "reviewer_id": "u2", "decision": "APPROVED"}`
+	code, _, echoed := validate(t, router, http.MethodPost, "/pullRequest/review", "application/json", body)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, body, echoed)
+}
+
+func TestValidateRequest_MalformedBody(t *testing.T) {
+	router := validatingRouter(t)
+
+	_, fields, _ := validate(t, router, http.MethodPost, "/pullRequest/merge", "application/json", `{"pull_request_id":`)
+	assert.Equal(t, []responses.FieldError{{In: "body", Message: "invalid JSON"}}, fields)
+
+	_, fields, _ = validate(t, router, http.MethodPost, "/pullRequest/merge", "text/plain", `{"pull_request_id": "pr-1"}`)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "header", fields[0].In)
+	assert.Equal(t, "Content-Type", fields[0].Field)
+}
+
+func TestValidateRequest_Query(t *testing.T) {
+	router := validatingRouter(t)
+
+	testCases := []struct {
+		name   string
+		target string
+		want   []responses.FieldError
+	}{
+		{
+			name:   "missing required",
+			target: "/team/get",
+			want:   []responses.FieldError{{In: "query", Field: "team_name", Message: "is required"}},
+		},
+		{
+			name:   "not an integer",
+			target: "/team/list?limit=ten",
+			want:   []responses.FieldError{{In: "query", Field: "limit", Message: "an invalid integer"}},
+		},
+		{
+			name:   "out of range",
+			target: "/users/list?limit=500",
+			want:   []responses.FieldError{{In: "query", Field: "limit", Message: "number must be at most 200"}},
+		},
+		{
+			name:   "several problems",
+			target: "/pullRequest/list?status=CLOSED&created_from=2025-01-01",
+			want: []responses.FieldError{
+				{In: "query", Field: "status", Message: `value is not one of the allowed values ["OPEN","MERGED"]`},
+				{In: "query", Field: "created_from", Message: "must be a date-time string"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, fields, _ := validate(t, router, http.MethodGet, tc.target, "", "")
+
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.ElementsMatch(t, tc.want, fields)
+		})
+	}
+}
+
+func TestValidateRequest_SkipsUnknownPathsAndMarkedBodies(t *testing.T) {
+	router := validatingRouter(t)
+
+	code, _, _ := validate(t, router, http.MethodGet, "/unknown", "", "")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _, echoed := validate(t, router, http.MethodPost, "/team/import?format=csv", "text/plain", "team_name,user_id,username\n")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "team_name,user_id,username\n", echoed)
+}
@@ -0,0 +1,71 @@
+// Package openapi holds the OpenAPI 3 description of the REST API and serves
+// it along with a rendered reference page.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// SkipBodyValidation marks operations whose body is checked by the handler
+// instead, because it is not JSON or is too large to decode twice.
+const SkipBodyValidation = "x-skip-body-validation"
+
+var (
+	//go:embed openapi.yaml
+	document []byte
+
+	//go:embed docs.html
+	docsPage []byte
+)
+
+type Spec struct {
+	Doc    *openapi3.T
+	router routers.Router
+	json   []byte
+}
+
+// Load parses and validates the embedded document.
+func Load() (*Spec, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("load openapi document: %w", err)
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("validate openapi document: %w", err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("build openapi router: %w", err)
+	}
+
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal openapi document: %w", err)
+	}
+
+	return &Spec{Doc: doc, router: router, json: data}, nil
+}
+
+// FindRoute returns the operation matching req, or routers.ErrPathNotFound and
+// routers.ErrMethodNotAllowed.
+func (s *Spec) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	return s.router.FindRoute(req)
+}
+
+func (s *Spec) ServeJSON(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", s.json)
+}
+
+func (s *Spec) ServeDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
@@ -1,7 +1,23 @@
 package responses
 
+import (
+	"context"
+
+	"mPR/internal/requestid"
+)
+
 type Detail struct {
-	Code    string `json:"code"`
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestID string       `json:"request_id,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is one problem found by request validation. In is where the
+// field lives: body, query, header or path.
+type FieldError struct {
+	In      string `json:"in"`
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
@@ -9,11 +25,19 @@ type Response struct {
 	Error Detail `json:"error"`
 }
 
-func Error(code string, message string) Response {
+func Error(ctx context.Context, code string, message string) Response {
 	return Response{
 		Error: Detail{
-			Code:    code,
-			Message: message,
+			Code:      code,
+			Message:   message,
+			RequestID: requestid.FromContext(ctx),
 		},
 	}
 }
+
+func ValidationError(ctx context.Context, fields []FieldError) Response {
+	resp := Error(ctx, "VALIDATION_FAILED", "request does not match the API specification")
+	resp.Error.Fields = fields
+
+	return resp
+}
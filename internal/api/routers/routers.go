@@ -1,35 +1,111 @@
 package routers
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
 
 	"mPR/internal/api/handlers"
 	"mPR/internal/api/middleware"
+	"mPR/internal/api/openapi"
+	"mPR/internal/config"
+	"mPR/internal/idempotency"
+	"mPR/internal/metrics"
+	"mPR/internal/ratelimit"
 )
 
-func Init(api *handlers.API, adminToken string) *gin.Engine {
-	router := gin.Default()
+func Init(
+	api *handlers.API,
+	cfg *config.Config,
+	spec *openapi.Spec,
+	limiter ratelimit.Store,
+	idempotencyKeys idempotency.Store,
+	m *metrics.Metrics,
+	log *zap.Logger,
+) *gin.Engine {
+	router := gin.New()
+	router.ContextWithFallback = true
+	// With no trusted proxies X-Forwarded-For is ignored: anyone can set it,
+	// and the rate limiter keys anonymous callers by their address.
+	if err := router.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		log.Warn("Invalid TRUSTED_PROXIES, trusting none", zap.Error(err))
+		_ = router.SetTrustedProxies(nil)
+	}
+	router.Use(
+		middleware.RequestID(),
+		middleware.Client(cfg.App.AdminToken),
+		otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(traced)),
+		middleware.AccessLog(log),
+		middleware.Metrics(m),
+		middleware.Recovery(log),
+	)
+
+	// Admin routes check the token before validating, so unauthenticated
+	// callers get 401 and no hints about the request schema.
+	validate := middleware.ValidateRequest(spec)
+	adminAuth := middleware.AdminAuth(cfg.App.AdminToken)
+	idempotent := middleware.Idempotency(idempotencyKeys, cfg.App.IdempotencyTTL, log)
 
 	router.GET("/health", api.Health)
+	router.GET("/livez", api.Livez)
+	router.GET("/readyz", api.Readyz)
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+	router.GET("/openapi.json", spec.ServeJSON)
+	router.GET("/docs", spec.ServeDocs)
 
-	team := router.Group("/team")
+	team := router.Group("/team", middleware.RateLimit(limiter, "team", cfg.Limits.Team, log))
 	{
-		team.POST("/add", api.AddTeam)
-		team.GET("/get", api.GetTeam)
+		team.POST("/add", validate, idempotent, api.AddTeam)
+		team.GET("/get", validate, api.GetTeam)
+		team.GET("/list", validate, api.ListTeams)
+		team.POST("/import", adminAuth, validate, idempotent, api.ImportRoster)
 	}
 
-	user := router.Group("/users")
+	user := router.Group("/users", middleware.RateLimit(limiter, "users", cfg.Limits.Users, log))
 	{
-		user.POST("/setIsActive", middleware.AdminAuth(adminToken), api.SetIsActive)
-		user.GET("/getReview", api.GetReview)
+		user.POST("/setIsActive", adminAuth, validate, idempotent, api.SetIsActive)
+		user.POST("/setSlackID", adminAuth, validate, idempotent, api.SetSlackID)
+		user.POST("/setEmail", adminAuth, validate, idempotent, api.SetEmail)
+		user.GET("/getReview", validate, api.GetReview)
+		user.GET("/list", validate, api.ListUsers)
 	}
 
-	pr := router.Group("/pullRequest")
+	pr := router.Group("/pullRequest", middleware.RateLimit(limiter, "pullRequest", cfg.Limits.PullRequest, log))
 	{
-		pr.POST("/create", api.Create)
-		pr.POST("/merge", api.Merge)
-		pr.POST("/reassign", api.Reassign)
+		pr.POST("/create", validate, idempotent, api.Create)
+		pr.POST("/merge", validate, idempotent, api.Merge)
+		pr.POST("/reassign", validate, idempotent, api.Reassign)
+		pr.POST("/review", validate, idempotent, api.Review)
+		pr.GET("/get", validate, api.GetPR)
+		pr.GET("/list", validate, api.ListPRs)
+	}
+
+	stats := router.Group("/stats", middleware.RateLimit(limiter, "stats", cfg.Limits.Stats, log), validate)
+	{
+		stats.GET("/reviewers", api.ReviewerStats)
+		stats.GET("/teams", api.TeamStats)
+		stats.GET("/cycle-time", api.CycleTimeStats)
+	}
+
+	router.GET("/events/stream", validate, api.StreamEvents)
+
+	admin := router.Group("/admin", adminAuth, validate)
+	{
+		admin.GET("/snapshot", api.ExportSnapshot)
+		admin.POST("/snapshot", api.ImportSnapshot)
 	}
 
 	return router
 }
+
+func traced(r *http.Request) bool {
+	switch r.URL.Path {
+	// A stream span would stay open for as long as the client is connected.
+	case "/health", "/livez", "/readyz", "/metrics", "/events/stream":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,167 @@
+package routers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/internal/api/handlers"
+	"mPR/internal/api/openapi"
+	"mPR/internal/api/routers"
+	"mPR/internal/config"
+	"mPR/internal/events"
+	"mPR/internal/metrics"
+	"mPR/internal/service"
+	"mPR/internal/storage/repository/memory"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newRouter(t *testing.T) (*gin.Engine, *openapi.Spec) {
+	t.Helper()
+
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	repos := memory.New(0)
+	api := handlers.New(zap.NewNop(), service.New(repos, events.NewBroker(8), 2, 0))
+	cfg := &config.Config{App: config.Application{AdminToken: "secret", IdempotencyTTL: time.Hour}}
+
+	return routers.Init(api, cfg, spec, nil, repos.IdempotencyKeys, metrics.New(), zap.NewNop()), spec
+}
+
+// ginParam matches gin path parameters like :id, written {id} in OpenAPI.
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+func TestEveryRouteIsInSpec(t *testing.T) {
+	router, spec := newRouter(t)
+
+	routes := make(map[string]bool)
+	for _, route := range router.Routes() {
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+		routes[route.Method+" "+path] = true
+
+		item := spec.Doc.Paths.Find(path)
+		if !assert.NotNil(t, item, "route %s %s has no path in openapi.yaml", route.Method, route.Path) {
+			continue
+		}
+		assert.NotNil(t, item.GetOperation(route.Method), "route %s %s has no operation in openapi.yaml", route.Method, route.Path)
+	}
+
+	for path, item := range spec.Doc.Paths.Map() {
+		for method := range item.Operations() {
+			assert.True(t, routes[method+" "+path], "openapi.yaml documents %s %s, which is not routed", method, path)
+		}
+	}
+}
+
+func TestServesSpecAndDocs(t *testing.T) {
+	router, _ := newRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/pullRequest/create")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `spec-url="/openapi.json"`)
+}
+
+func TestValidatesBeforeHandlers(t *testing.T) {
+	router, _ := newRouter(t)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-"+path)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/team/add", `{"team_name": "backend", "members": [{"username": "Alice"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"members[0].user_id"`)
+
+	w = post("/team/add", `{"team_name": "backend", "members": [{"user_id": "u1", "username": "Alice", "is_active": true}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func TestImportDryRunKeyIsNotReplayedForApply(t *testing.T) {
+	router, _ := newRouter(t)
+
+	importRoster := func(query, key string) *httptest.ResponseRecorder {
+		body := `{"teams": [{"name": "backend", "members": [{"user_id": "u1", "username": "Alice"}]}]}`
+		req := httptest.NewRequest(http.MethodPost, "/team/import"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	teamExists := func() bool {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+		return w.Code == http.StatusOK
+	}
+
+	w := importRoster("?dry_run=true", "import-1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.False(t, teamExists())
+
+	// Reusing the dry-run key for the apply must not replay the stored plan.
+	w = importRoster("", "import-1")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+	assert.False(t, teamExists())
+
+	w = importRoster("", "import-2")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.True(t, teamExists())
+}
+
+func TestAdminRoutesAuthenticateBeforeValidating(t *testing.T) {
+	router, _ := newRouter(t)
+
+	post := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"unexpected": true}`))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, path := range []string{"/users/setIsActive", "/users/setSlackID", "/users/setEmail"} {
+		w := post(path, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+		assert.NotContains(t, w.Body.String(), "VALIDATION_FAILED", path)
+
+		w = post(path, "secret")
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		assert.Contains(t, w.Body.String(), "VALIDATION_FAILED", path)
+	}
+}
@@ -3,14 +3,22 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	Postgres Database
+	SQLite   SQLite
 	App      Application
 	Log      Logger
+	Limits   RateLimit
+	Tracing  Tracing
+	Events   Events
+	Slack    Slack
+	Email    Email
 }
 
 type Database struct {
@@ -22,17 +30,84 @@ type Database struct {
 	Mode     string
 }
 
+type SQLite struct {
+	Path string
+}
+
 type Application struct {
 	Port         string
+	GRPCPort     string
 	Env          string
 	AdminToken   string
 	MaxReviewers int
+	Storage      string
+
+	// TrustedProxies lists the proxy addresses or CIDRs whose X-Forwarded-For
+	// is believed. Empty means the peer address is always the client.
+	TrustedProxies []string
+
+	// IdempotencyTTL applies to the REST API only: gRPC has no Idempotency-Key.
+	IdempotencyTTL           time.Duration
+	IdempotencySweepInterval time.Duration
+	MigrateOnStart           bool
 }
 
 type Logger struct {
 	Level string
 }
 
+type Tracing struct {
+	Exporter    string
+	Endpoint    string
+	ServiceName string
+	SampleRatio float64
+}
+
+type Events struct {
+	DispatchInterval time.Duration
+	BatchSize        int
+	ClaimLease       time.Duration
+	Log              bool
+
+	WebhookURL     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+	WebhookRetries int
+}
+
+type Slack struct {
+	// Webhooks maps a team name to its incoming-webhook URL.
+	Webhooks         map[string]string
+	Timeout          time.Duration
+	Retries          int
+	ReminderInterval time.Duration
+}
+
+type Email struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	Timeout      time.Duration
+	TemplatesDir string
+	// DigestAt is the local time of the daily digest as HH:MM.
+	DigestAt string
+}
+
+type RateLimit struct {
+	Backend     string
+	Team        Limit
+	Users       Limit
+	PullRequest Limit
+	Stats       Limit
+}
+
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
 func Load() *Config {
 	_ = godotenv.Load()
 
@@ -45,15 +120,66 @@ func Load() *Config {
 			Name:     os.Getenv("DB_NAME"),
 			Mode:     os.Getenv("DB_MODE"),
 		},
+		SQLite: SQLite{
+			Path: getEnvOrDefault("SQLITE_PATH", "pr-service.db"),
+		},
 		App: Application{
 			Port:         getEnvOrDefault("APP_PORT", "8080"),
+			GRPCPort:     getEnvOrDefault("GRPC_PORT", "9090"),
 			Env:          getEnvOrDefault("APP_ENV", "production"),
 			AdminToken:   os.Getenv("ADMIN_TOKEN"),
 			MaxReviewers: getEnvOrDefaultInt("MAX_REVIEWERS", 2),
+			Storage:      getEnvOrDefault("STORAGE", "postgres"),
+
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+			IdempotencyTTL:           getEnvOrDefaultDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			IdempotencySweepInterval: getEnvOrDefaultDuration("IDEMPOTENCY_SWEEP_INTERVAL", 10*time.Minute),
+			MigrateOnStart:           getEnvOrDefaultBool("MIGRATE_ON_START", true),
 		},
 		Log: Logger{
 			Level: getEnvOrDefault("LOG_LEVEL", "info"),
 		},
+		Limits: RateLimit{
+			Backend:     getEnvOrDefault("RATE_LIMIT_BACKEND", "memory"),
+			Team:        getLimit("RATE_LIMIT_TEAM", 10, 20),
+			Users:       getLimit("RATE_LIMIT_USERS", 10, 20),
+			PullRequest: getLimit("RATE_LIMIT_PULL_REQUEST", 5, 10),
+			Stats:       getLimit("RATE_LIMIT_STATS", 2, 5),
+		},
+		Tracing: Tracing{
+			Exporter:    getEnvOrDefault("TRACING_EXPORTER", "none"),
+			Endpoint:    os.Getenv("TRACING_ENDPOINT"),
+			ServiceName: getEnvOrDefault("TRACING_SERVICE_NAME", "pr-service"),
+			SampleRatio: getEnvOrDefaultFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Events: Events{
+			DispatchInterval: getEnvOrDefaultDuration("EVENTS_DISPATCH_INTERVAL", time.Second),
+			BatchSize:        getEnvOrDefaultInt("EVENTS_BATCH_SIZE", 100),
+			ClaimLease:       getEnvOrDefaultDuration("EVENTS_CLAIM_LEASE", time.Minute),
+			Log:              getEnvOrDefaultBool("EVENTS_LOG", false),
+
+			WebhookURL:     os.Getenv("EVENTS_WEBHOOK_URL"),
+			WebhookSecret:  os.Getenv("EVENTS_WEBHOOK_SECRET"),
+			WebhookTimeout: getEnvOrDefaultDuration("EVENTS_WEBHOOK_TIMEOUT", 5*time.Second),
+			WebhookRetries: getEnvOrDefaultInt("EVENTS_WEBHOOK_RETRIES", 3),
+		},
+		Slack: Slack{
+			Webhooks:         getEnvMap("SLACK_WEBHOOKS"),
+			Timeout:          getEnvOrDefaultDuration("SLACK_TIMEOUT", 5*time.Second),
+			Retries:          getEnvOrDefaultInt("SLACK_RETRIES", 3),
+			ReminderInterval: getEnvOrDefaultDuration("SLACK_REMINDER_INTERVAL", 0),
+		},
+		Email: Email{
+			SMTPHost:     os.Getenv("SMTP_HOST"),
+			SMTPPort:     getEnvOrDefault("SMTP_PORT", "587"),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+			From:         getEnvOrDefault("EMAIL_FROM", "noreply@localhost"),
+			Timeout:      getEnvOrDefaultDuration("SMTP_TIMEOUT", 10*time.Second),
+			TemplatesDir: os.Getenv("EMAIL_TEMPLATES_DIR"),
+			DigestAt:     os.Getenv("EMAIL_DIGEST_AT"),
+		},
 	}
 
 	return cfg
@@ -74,3 +200,60 @@ func getEnvOrDefaultInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvOrDefaultBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvOrDefaultFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvOrDefaultDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// getEnvMap reads comma-separated key=value pairs. Values may contain "=".
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// getEnvList reads a comma-separated list, skipping empty items.
+func getEnvList(key string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func getLimit(prefix string, rate float64, burst int) Limit {
+	return Limit{
+		Rate:  getEnvOrDefaultFloat(prefix+"_RPS", rate),
+		Burst: getEnvOrDefaultInt(prefix+"_BURST", burst),
+	}
+}
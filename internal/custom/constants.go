@@ -4,3 +4,9 @@ const (
 	StatusOpen   = "OPEN"
 	StatusMerged = "MERGED"
 )
+
+const (
+	DecisionApproved         = "APPROVED"
+	DecisionChangesRequested = "CHANGES_REQUESTED"
+	DecisionCommented        = "COMMENTED"
+)
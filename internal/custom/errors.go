@@ -9,4 +9,16 @@ var (
 	ErrPRMerged    = errors.New("PR_MERGED")
 	ErrNotAssigned = errors.New("NOT_ASSIGNED")
 	ErrNoCandidate = errors.New("NO_CANDIDATE")
+
+	ErrInvalidRoster    = errors.New("INVALID_ROSTER")
+	ErrInvalidSnapshot  = errors.New("INVALID_SNAPSHOT")
+	ErrDatabaseNotEmpty = errors.New("DATABASE_NOT_EMPTY")
+	ErrInvalidCursor    = errors.New("INVALID_CURSOR")
+	ErrInvalidSlackID   = errors.New("INVALID_SLACK_ID")
+	ErrInvalidEmail     = errors.New("INVALID_EMAIL")
+
+	// Storage errors every repository returns in place of its driver's own.
+	ErrDuplicateKey  = errors.New("DUPLICATE_KEY")
+	ErrForeignKey    = errors.New("FOREIGN_KEY_VIOLATION")
+	ErrSerialization = errors.New("SERIALIZATION_FAILURE")
 )
@@ -0,0 +1,210 @@
+// Package email sends review notifications by SMTP: a message when someone is
+// asked to review, and a daily digest of the reviews still waiting for them.
+// Users get email only when they have an address and have not opted out.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/custom"
+	"mPR/internal/events"
+	"mPR/internal/pagination"
+	"mPR/internal/service/users"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+// digestJob is the name digest runs are claimed under.
+const digestJob = "email_digests"
+
+// Notifier is the events.Sink for assignment emails and the sender of
+// digests.
+type Notifier struct {
+	smtp      *SMTP
+	templates *Templates
+	users     repository.Users
+	reviews   *users.Service
+	jobRuns   repository.JobRuns
+	log       *zap.Logger
+}
+
+func NewNotifier(
+	smtp *SMTP,
+	templates *Templates,
+	users repository.Users,
+	reviews *users.Service,
+	jobRuns repository.JobRuns,
+	log *zap.Logger,
+) *Notifier {
+	return &Notifier{
+		smtp:      smtp,
+		templates: templates,
+		users:     users,
+		reviews:   reviews,
+		jobRuns:   jobRuns,
+		log:       log,
+	}
+}
+
+func (n *Notifier) Name() string {
+	return "email"
+}
+
+// Deliver emails the reviewer of an assignment or reassignment.
+func (n *Notifier) Deliver(ctx context.Context, event events.Event) error {
+	if event.Type != events.TypeReviewerAssigned && event.Type != events.TypeReviewerReassigned {
+		return nil
+	}
+
+	reviewer, err := n.user(ctx, event.ReviewerID)
+	if err != nil || !subscribed(reviewer) {
+		return err
+	}
+	author, err := n.user(ctx, event.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	data := AssignmentData{
+		Reviewer:    reviewer,
+		Author:      author,
+		PullRequest: models.PullRequests{ID: event.PullRequestID, Name: event.PullRequestName},
+		Team:        event.TeamName,
+	}
+	if event.OldReviewerID != "" {
+		previous, err := n.user(ctx, event.OldReviewerID)
+		if err != nil {
+			return err
+		}
+		data.PreviousReviewer = &previous
+	}
+
+	msg := Message{To: *reviewer.Email}
+	if err := n.templates.render(kindAssignment, data, &msg); err != nil {
+		return err
+	}
+
+	return n.send(ctx, reviewer.ID, msg)
+}
+
+// Digest emails every active, subscribed user the open reviews waiting for
+// them. Users with nothing pending get no email.
+func (n *Notifier) Digest(ctx context.Context) error {
+	all, err := n.users.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get users: %w", err)
+	}
+
+	var errs []error
+	for _, user := range all {
+		if !user.IsActive || !subscribed(user) {
+			continue
+		}
+		if err := n.digest(ctx, user); err != nil {
+			errs = append(errs, fmt.Errorf("digest for %s: %w", user.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) digest(ctx context.Context, user models.Users) error {
+	page, err := n.reviews.GetUserReviews(ctx, user.ID, models.ReviewFilter{
+		Status: custom.StatusOpen,
+		Limit:  pagination.MaxLimit,
+	}, "")
+	if err != nil {
+		return err
+	}
+	if len(page.PullRequests) == 0 {
+		return nil
+	}
+
+	data := DigestData{User: user, PullRequests: page.PullRequests, More: page.NextCursor != ""}
+	msg := Message{To: *user.Email}
+	if err := n.templates.render(kindDigest, data, &msg); err != nil {
+		return err
+	}
+
+	return n.send(ctx, user.ID, msg)
+}
+
+// DigestAt sends the digest of the run scheduled at slot, unless another
+// replica has claimed that run already.
+func (n *Notifier) DigestAt(ctx context.Context, slot time.Time) error {
+	claimed, err := n.jobRuns.Claim(ctx, digestJob, slot, time.Now())
+	if err != nil {
+		return fmt.Errorf("claim digest run: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	return n.Digest(ctx)
+}
+
+// RunDigests calls DigestAt every day at hour:minute local time until ctx is
+// done. Replicas with the same settings share each run, and one sends it.
+func (n *Notifier) RunDigests(ctx context.Context, hour, minute int) {
+	for {
+		slot := nextRun(time.Now(), hour, minute)
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := n.DigestAt(ctx, slot); err != nil && ctx.Err() == nil {
+			n.log.Error("Error send review digests", zap.Error(err))
+		}
+	}
+}
+
+// nextRun returns the first hour:minute after now.
+func nextRun(now time.Time, hour, minute int) time.Time {
+	y, m, d := now.Date()
+	next := time.Date(y, m, d, hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, hour, minute, 0, 0, now.Location())
+	}
+
+	return next
+}
+
+// user loads the profile a message is about. Someone missing from storage is
+// still named by ID.
+func (n *Notifier) user(ctx context.Context, id string) (models.Users, error) {
+	user, err := n.users.GetByID(ctx, id)
+	if errors.Is(err, custom.ErrNotFound) {
+		return models.Users{ID: id}, nil
+	}
+	if err != nil {
+		return models.Users{}, fmt.Errorf("get user %s: %w", id, err)
+	}
+
+	return *user, nil
+}
+
+// send drops messages the server rejected for good, so that one bad address
+// is not retried forever. Transient failures are returned: the dispatcher
+// retries the event for this sink alone.
+func (n *Notifier) send(ctx context.Context, userID string, msg Message) error {
+	err := n.smtp.Send(ctx, msg)
+	if errors.Is(err, ErrRejected) {
+		n.log.Error("SMTP server rejected email", zap.String("user_id", userID), zap.Error(err))
+		return nil
+	}
+
+	return err
+}
+
+func subscribed(user models.Users) bool {
+	return user.Email != nil && *user.Email != "" && !user.EmailOptOut
+}
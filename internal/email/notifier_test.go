@@ -0,0 +1,221 @@
+package email_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/internal/email"
+	"mPR/internal/events"
+	"mPR/internal/service/users"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/memory"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func seed(t *testing.T) *repository.All {
+	t.Helper()
+
+	ctx := context.Background()
+	repos := memory.New(0)
+	require.NoError(t, repos.Teams.Create(ctx, &models.Teams{Name: "backend"}))
+	require.NoError(t, repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{
+		{ID: "u1", Username: "Alice", IsActive: true, Email: ptr("alice@example.com")},
+		{ID: "u2", Username: "Bob", IsActive: true, Email: ptr("bob@example.com")},
+		{ID: "u3", Username: "Carol", IsActive: true, Email: ptr("carol@example.com")},
+		{ID: "u4", Username: "Dave", IsActive: true},
+	}))
+	require.NoError(t, repos.Users.UpdateEmail(ctx, "u3", ptr("carol@example.com"), true))
+
+	return repos
+}
+
+func newNotifier(t *testing.T, repos *repository.All, stub *smtpStub, templatesDir string) *email.Notifier {
+	t.Helper()
+
+	sender, err := email.NewSMTP("127.0.0.1", stub.port(), "", "", "noreply@example.com", time.Second)
+	require.NoError(t, err)
+	templates, err := email.LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	reviews := users.New(repos.Tx, repos.Users, repos.PullRequests, repos.Outbox)
+
+	return email.NewNotifier(sender, templates, repos.Users, reviews, repos.JobRuns, zap.NewNop())
+}
+
+func TestNotifier_EmailsAssignedReviewer(t *testing.T) {
+	stub := newSMTPStub(t)
+	notifier := newNotifier(t, seed(t), stub, "")
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add <search>", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(context.Background(), events.ReviewerAssigned(pr, "backend", "u2")))
+
+	mail := stub.received()
+	require.Len(t, mail, 1)
+	assert.Equal(t, "bob@example.com", mail[0].To)
+	assert.Equal(t, "Review requested: Add <search>", mail[0].Subject)
+	assert.Contains(t, mail[0].Text, `Alice asked you to review "Add <search>" (pr-1).`)
+	assert.Contains(t, mail[0].HTML, "<b>Add &lt;search&gt;</b>")
+}
+
+func TestNotifier_EmailsNewReviewerOnReassignment(t *testing.T) {
+	stub := newSMTPStub(t)
+	notifier := newNotifier(t, seed(t), stub, "")
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(context.Background(), events.ReviewerReassigned(pr, "backend", "u4", "u2")))
+
+	mail := stub.received()
+	require.Len(t, mail, 1)
+	assert.Equal(t, "Review moved to you: Add search", mail[0].Subject)
+	assert.Contains(t, mail[0].Text, "Dave can no longer review")
+}
+
+func TestNotifier_SkipsOptedOutAndUnknownAddresses(t *testing.T) {
+	stub := newSMTPStub(t)
+	notifier := newNotifier(t, seed(t), stub, "")
+	ctx := context.Background()
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(ctx, events.ReviewerAssigned(pr, "backend", "u3")))
+	require.NoError(t, notifier.Deliver(ctx, events.ReviewerAssigned(pr, "backend", "u4")))
+	require.NoError(t, notifier.Deliver(ctx, events.PRMerged(pr, "backend")))
+
+	assert.Empty(t, stub.received())
+}
+
+func TestNotifier_DropsRejectedEmails(t *testing.T) {
+	stub := newSMTPStub(t)
+	stub.rcptReply = "550 5.1.1 no such user"
+	notifier := newNotifier(t, seed(t), stub, "")
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	assert.NoError(t, notifier.Deliver(context.Background(), events.ReviewerAssigned(pr, "backend", "u2")))
+}
+
+type countingSink struct {
+	delivered int
+}
+
+func (s *countingSink) Name() string {
+	return "slack"
+}
+
+func (s *countingSink) Deliver(context.Context, events.Event) error {
+	s.delivered++
+	return nil
+}
+
+func TestNotifier_TransientFailureRetriesOnlyEmail(t *testing.T) {
+	ctx := context.Background()
+	repos := seed(t)
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, events.Record(ctx, repos.Outbox, events.ReviewerAssigned(pr, "backend", "u2")))
+
+	down := newSMTPStub(t)
+	down.rcptReply = "451 4.3.0 try again later"
+	slack := &countingSink{}
+	// A lease this short lets the next pass retry at once.
+	_, err := events.NewDispatcher(repos.Outbox, time.Second, 10, time.Nanosecond, zap.NewNop(),
+		slack, newNotifier(t, repos, down, "")).Dispatch(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 1, slack.delivered)
+
+	up := newSMTPStub(t)
+	n, err := events.NewDispatcher(repos.Outbox, time.Second, 10, time.Nanosecond, zap.NewNop(),
+		slack, newNotifier(t, repos, up, "")).Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, up.received(), 1)
+	assert.Equal(t, 1, slack.delivered, "the sink that took the event is not sent it again")
+}
+
+func TestNotifier_DigestListsPendingReviews(t *testing.T) {
+	stub := newSMTPStub(t)
+	repos := seed(t)
+	notifier := newNotifier(t, repos, stub, "")
+	ctx := context.Background()
+
+	created := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, pr := range []models.PullRequests{
+		{ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: "OPEN", CreatedAt: created},
+		{ID: "pr-2", Name: "Fix login", AuthorID: "u1", Status: "OPEN", CreatedAt: created.Add(time.Hour)},
+		{ID: "pr-3", Name: "Old work", AuthorID: "u1", Status: "MERGED", CreatedAt: created},
+	} {
+		require.NoError(t, repos.PullRequests.Create(ctx, &pr))
+		require.NoError(t, repos.Reviewers.Add(ctx, []models.Reviewers{
+			{PRID: pr.ID, ReviewerID: "u2"},
+			{PRID: pr.ID, ReviewerID: "u3"},
+		}))
+	}
+
+	require.NoError(t, notifier.Digest(ctx))
+
+	// Carol opted out, and Alice has nothing to review.
+	mail := stub.received()
+	require.Len(t, mail, 1)
+	assert.Equal(t, "bob@example.com", mail[0].To)
+	assert.Equal(t, "2 reviews waiting for you", mail[0].Subject)
+	assert.Contains(t, mail[0].Text, "- Fix login (pr-2), opened 2025-05-01")
+	assert.Contains(t, mail[0].Text, "- Add search (pr-1), opened 2025-05-01")
+	assert.NotContains(t, mail[0].Text, "pr-3")
+	assert.Contains(t, mail[0].HTML, "<li><b>Add search</b>")
+}
+
+func TestNotifier_DigestAtSendsEachRunOnce(t *testing.T) {
+	stub := newSMTPStub(t)
+	repos := seed(t)
+	ctx := context.Background()
+
+	require.NoError(t, repos.PullRequests.Create(ctx, &models.PullRequests{
+		ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: "OPEN", CreatedAt: time.Now(),
+	}))
+	require.NoError(t, repos.Reviewers.Add(ctx, []models.Reviewers{{PRID: "pr-1", ReviewerID: "u2"}}))
+
+	// Two replicas wake up for the same run.
+	slot := time.Date(2025, 5, 2, 9, 0, 0, 0, time.Local)
+	require.NoError(t, newNotifier(t, repos, stub, "").DigestAt(ctx, slot))
+	require.NoError(t, newNotifier(t, repos, stub, "").DigestAt(ctx, slot))
+	assert.Len(t, stub.received(), 1)
+
+	require.NoError(t, newNotifier(t, repos, stub, "").DigestAt(ctx, slot.AddDate(0, 0, 1)))
+	assert.Len(t, stub.received(), 2)
+}
+
+func TestLoadTemplates_OverridesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assignment.subject.tmpl"),
+		[]byte("[{{.Team}}] {{.PullRequest.ID}} needs {{name .Reviewer}}\n"), 0o600))
+
+	stub := newSMTPStub(t)
+	notifier := newNotifier(t, seed(t), stub, dir)
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(context.Background(), events.ReviewerAssigned(pr, "backend", "u2")))
+
+	mail := stub.received()
+	require.Len(t, mail, 1)
+	assert.Equal(t, "[backend] pr-1 needs Bob", mail[0].Subject)
+	// Templates that were not overridden stay built in.
+	assert.Contains(t, mail[0].Text, "Alice asked you to review")
+}
+
+func TestLoadTemplates_InvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "digest.html.tmpl"), []byte("{{.Broken"), 0o600))
+
+	_, err := email.LoadTemplates(dir)
+
+	assert.ErrorContains(t, err, "parse digest HTML template")
+}
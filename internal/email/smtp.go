@@ -0,0 +1,132 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// ErrRejected marks a message the SMTP server refused for good, with a 5xx
+// reply such as an unknown mailbox. Sending it again cannot succeed.
+var ErrRejected = errors.New("smtp server rejected the message")
+
+// SMTP sends messages through one server. It upgrades the connection with
+// STARTTLS when the server offers it, and authenticates when a username is
+// set.
+type SMTP struct {
+	addr     string
+	host     string
+	from     string
+	envelope string
+	auth     smtp.Auth
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// NewSMTP checks that from is a valid address, optionally with a display
+// name like "PR Service <noreply@example.com>".
+func NewSMTP(host, port, username, password, from string, timeout time.Duration) (*SMTP, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("parse sender address: %w", err)
+	}
+
+	s := &SMTP{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		from:     sender.String(),
+		envelope: sender.Address,
+		timeout:  timeout,
+		now:      time.Now,
+	}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s, nil
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	body, err := msg.bytes(s.from, s.now())
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("connect to smtp server: %w", err)
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("greet smtp server: %w", classify(err))
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := s.deliver(client, msg.To, body); err != nil {
+		return classify(err)
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTP) deliver(client *smtp.Client, to string, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.envelope); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finish message: %w", err)
+	}
+
+	return nil
+}
+
+// classify marks permanent 5xx replies with ErrRejected. Everything else,
+// including 4xx replies and network errors, is worth retrying.
+func classify(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return fmt.Errorf("%w: %w", ErrRejected, err)
+	}
+
+	return err
+}
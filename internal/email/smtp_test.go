@@ -0,0 +1,226 @@
+package email_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/email"
+)
+
+// received is one email as the stub server saw it, with the bodies decoded.
+type received struct {
+	Auth    string
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// smtpStub is a minimal in-process SMTP server. rcptReply, when set, is the
+// reply to every RCPT command.
+type smtpStub struct {
+	ln        net.Listener
+	rcptReply string
+
+	mu   sync.Mutex
+	mail []received
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpStub{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(t, conn)
+		}
+	}()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	return s
+}
+
+func (s *smtpStub) port() string {
+	return strings.TrimPrefix(s.ln.Addr().String(), "127.0.0.1:")
+}
+
+func (s *smtpStub) received() []received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]received(nil), s.mail...)
+}
+
+func (s *smtpStub) serve(t *testing.T, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			_ = tp.PrintfLine("%s", line)
+		}
+	}
+
+	var msg received
+	reply("220 stub ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-stub", "250-AUTH PLAIN", "250 8BITMIME")
+		case "AUTH":
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			msg.Auth = string(creds)
+			reply("235 2.7.0 authenticated")
+		case "MAIL":
+			from, _, _ := strings.Cut(strings.TrimPrefix(arg, "FROM:"), " ")
+			msg.From = strings.Trim(from, "<>")
+			reply("250 ok")
+		case "RCPT":
+			if s.rcptReply != "" {
+				reply(s.rcptReply)
+				continue
+			}
+			msg.To = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			decode(t, string(data), &msg)
+
+			s.mu.Lock()
+			s.mail = append(s.mail, msg)
+			s.mu.Unlock()
+
+			msg = received{}
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func decode(t *testing.T, data string, msg *received) {
+	parsed, err := mail.ReadMessage(strings.NewReader(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	msg.Subject, err = new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			return
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		body, err := io.ReadAll(quotedprintable.NewReader(bufio.NewReader(part)))
+		assert.NoError(t, err)
+
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/html") {
+			msg.HTML = string(body)
+		} else {
+			msg.Text = string(body)
+		}
+	}
+}
+
+func TestSMTP_SendsMultipartMessage(t *testing.T) {
+	stub := newSMTPStub(t)
+
+	sender, err := email.NewSMTP("127.0.0.1", stub.port(), "bot", "secret", "PR Reviewer <noreply@example.com>", time.Second)
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), email.Message{
+		To:      "alice@example.com",
+		Subject: "Ревью ждёт",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	})
+	require.NoError(t, err)
+
+	mail := stub.received()
+	require.Len(t, mail, 1)
+	assert.Equal(t, "\x00bot\x00secret", mail[0].Auth)
+	assert.Equal(t, "noreply@example.com", mail[0].From)
+	assert.Equal(t, "alice@example.com", mail[0].To)
+	assert.Equal(t, "Ревью ждёт", mail[0].Subject)
+	assert.Equal(t, "plain body", mail[0].Text)
+	assert.Equal(t, "<p>html body</p>", mail[0].HTML)
+}
+
+func TestSMTP_PermanentFailureIsRejected(t *testing.T) {
+	stub := newSMTPStub(t)
+	stub.rcptReply = "550 5.1.1 no such user"
+
+	sender, err := email.NewSMTP("127.0.0.1", stub.port(), "", "", "noreply@example.com", time.Second)
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), email.Message{To: "ghost@example.com"})
+
+	require.ErrorIs(t, err, email.ErrRejected)
+	assert.Contains(t, err.Error(), "no such user")
+}
+
+func TestSMTP_TemporaryFailureIsNotRejected(t *testing.T) {
+	stub := newSMTPStub(t)
+	stub.rcptReply = "451 4.3.0 try again later"
+
+	sender, err := email.NewSMTP("127.0.0.1", stub.port(), "", "", "noreply@example.com", time.Second)
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), email.Message{To: "alice@example.com"})
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, email.ErrRejected)
+}
+
+func TestNewSMTP_InvalidFrom(t *testing.T) {
+	_, err := email.NewSMTP("127.0.0.1", "25", "", "", "not an address", time.Second)
+
+	assert.Error(t, err)
+}
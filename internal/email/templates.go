@@ -0,0 +1,150 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"mPR/internal/storage/models"
+)
+
+//go:embed templates/*.tmpl
+var defaults embed.FS
+
+const (
+	kindAssignment = "assignment"
+	kindDigest     = "digest"
+)
+
+// AssignmentData is what assignment templates render. PreviousReviewer is set
+// when the review moved from someone else.
+type AssignmentData struct {
+	Reviewer         models.Users
+	Author           models.Users
+	PreviousReviewer *models.Users
+	PullRequest      models.PullRequests
+	Team             string
+}
+
+// DigestData is what digest templates render. More means the user has more
+// pending reviews than are listed.
+type DigestData struct {
+	User         models.Users
+	PullRequests []models.PullRequests
+	More         bool
+}
+
+var funcs = map[string]any{
+	"name": func(u any) string {
+		var user models.Users
+		switch v := u.(type) {
+		case models.Users:
+			user = v
+		case *models.Users:
+			user = *v
+		}
+		if user.Username != "" {
+			return user.Username
+		}
+		return user.ID
+	},
+	"date": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02")
+	},
+}
+
+// Templates renders every kind of email as a subject, a plain-text body and
+// an HTML body, from <kind>.subject.tmpl, <kind>.txt.tmpl and
+// <kind>.html.tmpl.
+type Templates struct {
+	subject map[string]*texttemplate.Template
+	text    map[string]*texttemplate.Template
+	html    map[string]*htmltemplate.Template
+}
+
+// LoadTemplates parses the built-in templates, replacing each with the file
+// of the same name in dir when there is one. An empty dir keeps the built-in
+// set.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{
+		subject: make(map[string]*texttemplate.Template),
+		text:    make(map[string]*texttemplate.Template),
+		html:    make(map[string]*htmltemplate.Template),
+	}
+
+	for _, kind := range []string{kindAssignment, kindDigest} {
+		subject, err := read(dir, kind+".subject.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		if t.subject[kind], err = texttemplate.New(kind).Funcs(funcs).Parse(subject); err != nil {
+			return nil, fmt.Errorf("parse %s subject template: %w", kind, err)
+		}
+
+		text, err := read(dir, kind+".txt.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		if t.text[kind], err = texttemplate.New(kind).Funcs(funcs).Parse(text); err != nil {
+			return nil, fmt.Errorf("parse %s text template: %w", kind, err)
+		}
+
+		html, err := read(dir, kind+".html.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		if t.html[kind], err = htmltemplate.New(kind).Funcs(funcs).Parse(html); err != nil {
+			return nil, fmt.Errorf("parse %s HTML template: %w", kind, err)
+		}
+	}
+
+	return t, nil
+}
+
+func read(dir, name string) (string, error) {
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("read template %s: %w", name, err)
+		}
+	}
+
+	data, err := defaults.ReadFile("templates/" + name)
+	if err != nil {
+		return "", fmt.Errorf("read built-in template %s: %w", name, err)
+	}
+
+	return string(data), nil
+}
+
+// render fills msg with the subject and bodies of kind. The subject is
+// collapsed onto one line, since a header cannot span several.
+func (t *Templates) render(kind string, data any, msg *Message) error {
+	var subject, text, html bytes.Buffer
+	if err := t.subject[kind].Execute(&subject, data); err != nil {
+		return fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := t.text[kind].Execute(&text, data); err != nil {
+		return fmt.Errorf("render %s text: %w", kind, err)
+	}
+	if err := t.html[kind].Execute(&html, data); err != nil {
+		return fmt.Errorf("render %s HTML: %w", kind, err)
+	}
+
+	msg.Subject = strings.Join(strings.Fields(subject.String()), " ")
+	msg.Text = text.String()
+	msg.HTML = html.String()
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker is the in-process sink behind server-sent events. It fans events out
+// to subscribers without ever blocking the dispatcher: a subscriber whose
+// buffer is full misses the event and must catch up from the outbox.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	buffer      int
+}
+
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		subscribers: make(map[chan Event]struct{}),
+		buffer:      buffer,
+	}
+}
+
+func (b *Broker) Name() string {
+	return "sse"
+}
+
+func (b *Broker) Deliver(_ context.Context, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Wake tells every subscriber to look at the outbox again. It sends the zero
+// Event, so subscribers must treat what they receive as a hint, not as data.
+func (b *Broker) Wake() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- Event{}:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of events delivered from now on and a function
+// that unsubscribes and closes it.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, b.buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+// Sink receives dispatched events. An error makes the dispatcher retry the
+// event for this sink later, so Deliver must tolerate seeing an event twice.
+// Name must be stable across restarts: deliveries are recorded under it.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, event Event) error
+}
+
+// Waker is a sink that also wants to hear as soon as new events are
+// sequenced, before they are delivered. The broker uses it to wake SSE
+// subscribers, which read the outbox themselves.
+type Waker interface {
+	Wake()
+}
+
+// Dispatcher moves events from the outbox to the sinks. Each replica may run
+// one: claims keep them from delivering the same event at the same time.
+type Dispatcher struct {
+	outbox   repository.Outbox
+	sinks    []Sink
+	interval time.Duration
+	batch    int
+	lease    time.Duration
+	log      *zap.Logger
+	now      func() time.Time
+}
+
+func NewDispatcher(outbox repository.Outbox, interval time.Duration, batch int, lease time.Duration, log *zap.Logger, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		outbox:   outbox,
+		sinks:    sinks,
+		interval: interval,
+		batch:    batch,
+		lease:    lease,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// Run dispatches until ctx is done, polling every interval while the outbox
+// is drained. Sequencing runs on its own ticker, so a slow sink does not keep
+// new events from the feed.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.runSequencer(ctx)
+	}()
+	defer wg.Wait()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.Dispatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Error("Error dispatch events", zap.Error(err))
+		}
+
+		if n < d.batch || err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// runSequencer numbers committed events every interval and wakes the sinks
+// that implement Waker whenever the sequence has grown.
+func (d *Dispatcher) runSequencer(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	var last int64
+	for {
+		seq, err := d.sequence(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Error("Error sequence events", zap.Error(err))
+		}
+		if err == nil && seq > last {
+			last = seq
+			for _, sink := range d.sinks {
+				if waker, ok := sink.(Waker); ok {
+					waker.Wake()
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) sequence(ctx context.Context) (int64, error) {
+	if err := d.outbox.Sequence(ctx); err != nil {
+		return 0, fmt.Errorf("sequence events: %w", err)
+	}
+
+	seq, err := d.outbox.LastSeq(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get last event ID: %w", err)
+	}
+
+	return seq, nil
+}
+
+// Dispatch sequences newly committed events, then claims one batch and
+// delivers it in order. Each sink is tracked on its own: a sink that rejects
+// an event gets none of the rest of the batch, while the other sinks carry
+// on. An event goes back to the outbox until every sink has taken it, and
+// its retry after the claim expires reaches only the sinks still missing. It
+// returns the number of events delivered everywhere.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	if err := d.outbox.Sequence(ctx); err != nil {
+		return 0, fmt.Errorf("sequence events: %w", err)
+	}
+
+	rows, err := d.outbox.Claim(ctx, d.batch, d.now(), d.lease)
+	if err != nil {
+		return 0, fmt.Errorf("claim events: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	done, err := d.outbox.Deliveries(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("get event deliveries: %w", err)
+	}
+
+	dispatched := make([]int64, 0, len(rows))
+	var partial []models.OutboxDelivery
+	var deliverErr error
+	failed := make(map[string]bool)
+	for _, row := range rows {
+		event, err := FromOutbox(row)
+		if err != nil {
+			// A payload that cannot be read never will be; skip it rather
+			// than block the feed.
+			d.log.Error("Dropping unreadable event", zap.Int64("event_id", row.ID), zap.Error(err))
+			dispatched = append(dispatched, row.ID)
+			continue
+		}
+
+		var delivered []models.OutboxDelivery
+		complete := true
+		for _, sink := range d.sinks {
+			if slices.Contains(done[row.ID], sink.Name()) {
+				continue
+			}
+			if failed[sink.Name()] {
+				complete = false
+				continue
+			}
+
+			if err := sink.Deliver(ctx, event); err != nil {
+				failed[sink.Name()] = true
+				complete = false
+				deliverErr = errors.Join(deliverErr, fmt.Errorf("deliver event %d to %s: %w", event.ID, sink.Name(), err))
+				continue
+			}
+			delivered = append(delivered, models.OutboxDelivery{EventID: row.ID, Sink: sink.Name(), DeliveredAt: d.now()})
+		}
+
+		if complete {
+			dispatched = append(dispatched, row.ID)
+		} else {
+			partial = append(partial, delivered...)
+		}
+	}
+
+	if err := d.outbox.MarkDelivered(ctx, partial); err != nil {
+		return 0, errors.Join(deliverErr, fmt.Errorf("mark event deliveries: %w", err))
+	}
+	if err := d.outbox.MarkDispatched(ctx, dispatched, d.now()); err != nil {
+		return 0, errors.Join(deliverErr, fmt.Errorf("mark events dispatched: %w", err))
+	}
+
+	return len(dispatched), deliverErr
+}
@@ -0,0 +1,234 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/internal/events"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/memory"
+)
+
+type recordingSink struct {
+	name   string
+	events []events.Event
+	fail   map[int64]error
+}
+
+func (s *recordingSink) Name() string {
+	if s.name == "" {
+		return "recording"
+	}
+	return s.name
+}
+
+func (s *recordingSink) Deliver(_ context.Context, event events.Event) error {
+	if err := s.fail[event.ID]; err != nil {
+		return err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestDispatch_DeliversInOrderOnce(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, events.Record(ctx, outbox,
+		events.PRCreated(pr, "backend"),
+		events.ReviewerAssigned(pr, "backend", "u2"),
+	))
+
+	sink := &recordingSink{}
+	dispatcher := events.NewDispatcher(outbox, time.Second, 10, time.Minute, zap.NewNop(), sink)
+
+	n, err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, events.TypePRCreated, sink.events[0].Type)
+	assert.Equal(t, events.TypeReviewerAssigned, sink.events[1].Type)
+	assert.Less(t, sink.events[0].ID, sink.events[1].ID)
+	assert.Equal(t, "u2", sink.events[1].ReviewerID)
+	assert.Equal(t, "backend", sink.events[1].TeamName)
+
+	n, err = dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, sink.events, 2)
+}
+
+func TestDispatch_RetriesRejectedEventAfterLease(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	require.NoError(t, events.Record(ctx, outbox,
+		events.TeamCreated("backend", nil),
+		events.TeamCreated("frontend", nil),
+	))
+
+	sink := &recordingSink{fail: map[int64]error{2: errors.New("unavailable")}}
+	dispatcher := events.NewDispatcher(outbox, time.Second, 10, time.Minute, zap.NewNop(), sink)
+
+	n, err := dispatcher.Dispatch(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 1, n)
+
+	// Still claimed: nothing to do until the lease runs out.
+	n, err = dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	rows, err := outbox.Claim(ctx, 10, time.Now().Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 2, rows[0].ID)
+}
+
+func TestDispatch_RetriesOnlyTheFailedSink(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	require.NoError(t, events.Record(ctx, outbox,
+		events.TeamCreated("backend", nil),
+		events.TeamCreated("frontend", nil),
+	))
+
+	healthy := &recordingSink{name: "healthy"}
+	flaky := &recordingSink{name: "flaky", fail: map[int64]error{1: errors.New("unavailable")}}
+	// A lease this short lets the next pass retry at once.
+	dispatcher := events.NewDispatcher(outbox, time.Second, 10, time.Nanosecond, zap.NewNop(), flaky, healthy)
+
+	n, err := dispatcher.Dispatch(ctx)
+	require.Error(t, err)
+	assert.Zero(t, n)
+	// The healthy sink is not held up, and the failed one gets nothing past
+	// the event it rejected.
+	assert.Len(t, healthy.events, 2)
+	assert.Empty(t, flaky.events)
+
+	flaky.fail = nil
+	n, err = dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Len(t, healthy.events, 2)
+	require.Len(t, flaky.events, 2)
+	assert.Less(t, flaky.events[0].ID, flaky.events[1].ID)
+}
+
+func TestDispatch_SkipsUnreadablePayload(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	require.NoError(t, outbox.Add(ctx, []models.Outbox{{Type: "TEAM_CREATED", Payload: "{"}}))
+	require.NoError(t, events.Record(ctx, outbox, events.TeamCreated("backend", nil)))
+
+	sink := &recordingSink{}
+	dispatcher := events.NewDispatcher(outbox, time.Second, 10, time.Minute, zap.NewNop(), sink)
+
+	n, err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, "backend", sink.events[0].TeamName)
+}
+
+func TestRun_StopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	outbox := memory.New(0).Outbox
+	require.NoError(t, events.Record(ctx, outbox, events.TeamCreated("backend", nil)))
+
+	broker := events.NewBroker(2)
+	received, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		events.NewDispatcher(outbox, 10*time.Millisecond, 10, time.Minute, zap.NewNop(), broker).Run(ctx)
+		close(done)
+	}()
+
+	// The sequencer may wake the subscriber before the event is delivered.
+	timeout := time.After(time.Second)
+	for delivered := false; !delivered; {
+		select {
+		case event := <-received:
+			delivered = event.Type == events.TypeTeamCreated
+		case <-timeout:
+			t.Fatal("event was not dispatched")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop")
+	}
+}
+
+// blockingSink holds every delivery until release is closed.
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) Name() string {
+	return "blocking"
+}
+
+func (s *blockingSink) Deliver(ctx context.Context, _ events.Event) error {
+	select {
+	case <-s.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRun_WakesSubscribersWhileSinkIsSlow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	outbox := memory.New(0).Outbox
+	require.NoError(t, events.Record(ctx, outbox, events.TeamCreated("backend", nil)))
+
+	broker := events.NewBroker(8)
+	received, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	slow := &blockingSink{release: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		events.NewDispatcher(outbox, 10*time.Millisecond, 10, time.Minute, zap.NewNop(), broker, slow).Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Wait until the dispatcher is stuck delivering the first event.
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("first event was not seen")
+	}
+	time.Sleep(50 * time.Millisecond)
+	for len(received) > 0 {
+		<-received
+	}
+
+	require.NoError(t, events.Record(ctx, outbox, events.TeamCreated("frontend", nil)))
+
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatal("subscribers were not woken while the sink was blocked")
+	}
+}
@@ -0,0 +1,176 @@
+// Package events is the change feed of the service. Services record events
+// in the outbox within the transaction that makes the change, and the
+// Dispatcher later hands them to sinks at least once. Events normally arrive
+// in ID order, which is the order they committed in; one a sink rejected is
+// retried after its claim expires, by which time newer events may have gone
+// out.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+type Type string
+
+const (
+	TypeTeamCreated         Type = "TEAM_CREATED"
+	TypeUserActivityChanged Type = "USER_ACTIVITY_CHANGED"
+	TypePRCreated           Type = "PR_CREATED"
+	TypeReviewerAssigned    Type = "REVIEWER_ASSIGNED"
+	TypeReviewerReassigned  Type = "REVIEWER_REASSIGNED"
+	TypePRMerged            Type = "PR_MERGED"
+)
+
+// Event is one change. Fields that do not apply to the Type are left empty.
+// ID is the outbox Seq, so consumers can order events and drop duplicates.
+type Event struct {
+	ID         int64     `json:"id"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	TeamName   string    `json:"team_name,omitempty"`
+
+	PullRequestID   string   `json:"pull_request_id,omitempty"`
+	PullRequestName string   `json:"pull_request_name,omitempty"`
+	AuthorID        string   `json:"author_id,omitempty"`
+	ReviewerID      string   `json:"reviewer_id,omitempty"`
+	OldReviewerID   string   `json:"old_reviewer_id,omitempty"`
+	Reviewers       []string `json:"reviewers,omitempty"`
+
+	UserID   string   `json:"user_id,omitempty"`
+	IsActive *bool    `json:"is_active,omitempty"`
+	Members  []string `json:"members,omitempty"`
+}
+
+func TeamCreated(team string, members []models.Users) Event {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+
+	return Event{Type: TypeTeamCreated, OccurredAt: now(), TeamName: team, Members: ids}
+}
+
+func UserActivityChanged(user *models.Users) Event {
+	active := user.IsActive
+
+	return Event{
+		Type:       TypeUserActivityChanged,
+		OccurredAt: now(),
+		TeamName:   teamOf(user),
+		UserID:     user.ID,
+		IsActive:   &active,
+	}
+}
+
+// PRCreated describes a new PR with the reviewers it got. team is the
+// author's team.
+func PRCreated(pr *models.PullRequests, team string) Event {
+	event := prEvent(TypePRCreated, pr, team)
+	event.Reviewers = make([]string, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		event.Reviewers = append(event.Reviewers, r.ReviewerID)
+	}
+
+	return event
+}
+
+func ReviewerAssigned(pr *models.PullRequests, team, reviewerID string) Event {
+	event := prEvent(TypeReviewerAssigned, pr, team)
+	event.ReviewerID = reviewerID
+
+	return event
+}
+
+func ReviewerReassigned(pr *models.PullRequests, team, oldReviewerID, newReviewerID string) Event {
+	event := prEvent(TypeReviewerReassigned, pr, team)
+	event.OldReviewerID = oldReviewerID
+	event.ReviewerID = newReviewerID
+
+	return event
+}
+
+func PRMerged(pr *models.PullRequests, team string) Event {
+	return prEvent(TypePRMerged, pr, team)
+}
+
+func prEvent(typ Type, pr *models.PullRequests, team string) Event {
+	return Event{
+		Type:            typ,
+		OccurredAt:      now(),
+		TeamName:        team,
+		PullRequestID:   pr.ID,
+		PullRequestName: pr.Name,
+		AuthorID:        pr.AuthorID,
+	}
+}
+
+// UserIDs lists every user the event concerns.
+func (e *Event) UserIDs() []string {
+	ids := make([]string, 0, len(e.Reviewers)+len(e.Members)+4)
+	for _, id := range []string{e.AuthorID, e.ReviewerID, e.OldReviewerID, e.UserID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	ids = append(ids, e.Reviewers...)
+	ids = append(ids, e.Members...)
+
+	return ids
+}
+
+// Record writes events to the outbox. Call it with the context of the
+// transaction making the change, so the events commit or roll back with it.
+func Record(ctx context.Context, outbox repository.Outbox, events ...Event) error {
+	rows := make([]models.Outbox, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal %s event: %w", event.Type, err)
+		}
+
+		row := models.Outbox{Type: string(event.Type), Payload: string(payload)}
+		if event.TeamName != "" {
+			team := event.TeamName
+			row.TeamName = &team
+		}
+		rows = append(rows, row)
+	}
+
+	if err := outbox.Add(ctx, rows); err != nil {
+		return fmt.Errorf("add events to outbox: %w", err)
+	}
+
+	return nil
+}
+
+// FromOutbox decodes a stored event. The row must be sequenced.
+func FromOutbox(row models.Outbox) (Event, error) {
+	if row.Seq == nil {
+		return Event{}, fmt.Errorf("event %d is not sequenced", row.ID)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(row.Payload), &event); err != nil {
+		return Event{}, fmt.Errorf("unmarshal event %d: %w", row.ID, err)
+	}
+	event.ID = *row.Seq
+
+	return event, nil
+}
+
+func teamOf(user *models.Users) string {
+	if user.TeamName == nil {
+		return ""
+	}
+	return *user.TeamName
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
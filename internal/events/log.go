@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogSink writes every event to the service log.
+type LogSink struct {
+	log *zap.Logger
+}
+
+func NewLogSink(log *zap.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Deliver(_ context.Context, event Event) error {
+	s.log.Info("Event",
+		zap.Int64("event_id", event.ID),
+		zap.String("type", string(event.Type)),
+		zap.String("team_name", event.TeamName),
+		zap.String("pull_request_id", event.PullRequestID),
+		zap.Strings("user_ids", event.UserIDs()),
+	)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WebhookSink POSTs each event as JSON to a URL. With a secret, the body is
+// signed with HMAC-SHA256 in the X-Signature-256 header as "sha256=<hex>".
+// Failed attempts are retried with a doubling delay before the event goes
+// back to the outbox.
+type WebhookSink struct {
+	url     string
+	secret  []byte
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+func NewWebhookSink(url, secret string, client *http.Client, retries int, backoff time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:     url,
+		secret:  []byte(secret),
+		client:  client,
+		retries: retries,
+		backoff: backoff,
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	delay := s.backoff
+	for attempt := 0; ; attempt++ {
+		err = s.post(ctx, event, body)
+		if err == nil || attempt >= s.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (s *WebhookSink) post(ctx context.Context, event Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(event.ID, 10))
+	req.Header.Set("X-Event-Type", string(event.Type))
+	if len(s.secret) > 0 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write(body)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package events_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/events"
+)
+
+func TestWebhookSink_SignsAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Signature-256"))
+		assert.Equal(t, "7", r.Header.Get("X-Event-ID"))
+		assert.Equal(t, "TEAM_CREATED", r.Header.Get("X-Event-Type"))
+
+		var event events.Event
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, "backend", event.TeamName)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := events.NewWebhookSink(server.URL, "secret", server.Client(), 2, time.Millisecond)
+	event := events.TeamCreated("backend", nil)
+	event.ID = 7
+
+	require.NoError(t, sink.Deliver(context.Background(), event))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestWebhookSink_GivesUpAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := events.NewWebhookSink(server.URL, "", server.Client(), 2, time.Millisecond)
+
+	err := sink.Deliver(context.Background(), events.TeamCreated("backend", nil))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+	assert.EqualValues(t, 3, calls.Load())
+}
@@ -0,0 +1,125 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mPR/internal/custom"
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/storage/models"
+)
+
+var statuses = map[string]mprv1.PullRequestStatus{
+	custom.StatusOpen:   mprv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+	custom.StatusMerged: mprv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+}
+
+var decisions = map[string]mprv1.ReviewDecision{
+	custom.DecisionApproved:         mprv1.ReviewDecision_REVIEW_DECISION_APPROVED,
+	custom.DecisionChangesRequested: mprv1.ReviewDecision_REVIEW_DECISION_CHANGES_REQUESTED,
+	custom.DecisionCommented:        mprv1.ReviewDecision_REVIEW_DECISION_COMMENTED,
+}
+
+func toUser(u *models.Users) *mprv1.User {
+	return &mprv1.User{
+		UserId:      u.ID,
+		Username:    u.Username,
+		TeamName:    deref(u.TeamName),
+		IsActive:    u.IsActive,
+		SlackId:     deref(u.SlackID),
+		Email:       deref(u.Email),
+		EmailOptOut: u.EmailOptOut,
+		CreatedAt:   timestamp(&u.CreatedAt),
+	}
+}
+
+func toTeam(t *models.Teams) *mprv1.Team {
+	members := make([]*mprv1.User, 0, len(t.Users))
+	for i := range t.Users {
+		members = append(members, toUser(&t.Users[i]))
+	}
+
+	return &mprv1.Team{TeamName: t.Name, Members: members}
+}
+
+func toPullRequest(pr *models.PullRequests) *mprv1.PullRequest {
+	reviewers := make([]string, 0, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		reviewers = append(reviewers, r.ReviewerID)
+	}
+
+	return &mprv1.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            statuses[pr.Status],
+		AssignedReviewers: reviewers,
+		CreatedAt:         timestamp(&pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+	}
+}
+
+func toPullRequests(prs []models.PullRequests) []*mprv1.PullRequest {
+	out := make([]*mprv1.PullRequest, 0, len(prs))
+	for i := range prs {
+		out = append(out, toPullRequest(&prs[i]))
+	}
+
+	return out
+}
+
+func toReview(r *models.Reviews) *mprv1.Review {
+	return &mprv1.Review{
+		PullRequestId: r.PRID,
+		ReviewerId:    r.ReviewerID,
+		Decision:      decisions[r.Decision],
+		SubmittedAt:   timestamp(&r.SubmittedAt),
+	}
+}
+
+// fromStatus returns the domain status of s, or false for an unknown value.
+func fromStatus(s mprv1.PullRequestStatus) (string, bool) {
+	for name, value := range statuses {
+		if value == s {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func fromDecision(d mprv1.ReviewDecision) (string, bool) {
+	for name, value := range decisions {
+		if value == d {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,52 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mPR/internal/custom"
+	"mPR/internal/logger"
+)
+
+// errorCodes maps domain errors to gRPC codes. The status message is the error
+// code the REST API puts in error.code, so clients can tell e.g. PR_MERGED
+// from NOT_ASSIGNED.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{custom.ErrNotFound, codes.NotFound},
+	{custom.ErrTeamExists, codes.AlreadyExists},
+	{custom.ErrPRExists, codes.AlreadyExists},
+	{custom.ErrPRMerged, codes.FailedPrecondition},
+	{custom.ErrNotAssigned, codes.FailedPrecondition},
+	{custom.ErrNoCandidate, codes.FailedPrecondition},
+	{custom.ErrDatabaseNotEmpty, codes.FailedPrecondition},
+	{custom.ErrInvalidCursor, codes.InvalidArgument},
+	{custom.ErrInvalidSlackID, codes.InvalidArgument},
+	{custom.ErrInvalidEmail, codes.InvalidArgument},
+	{custom.ErrInvalidRoster, codes.InvalidArgument},
+	{custom.ErrInvalidSnapshot, codes.InvalidArgument},
+	{custom.ErrSerialization, codes.Aborted},
+}
+
+// toStatus converts a service error into a gRPC status error. Unknown errors
+// are logged and hidden behind codes.Internal.
+func toStatus(ctx context.Context, log *zap.Logger, err error, msg string) error {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+
+	logger.WithContext(ctx, log).Error(msg, zap.Error(err))
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
@@ -0,0 +1,202 @@
+package grpcapi
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"mPR/internal/config"
+	"mPR/internal/custom"
+	"mPR/internal/logger"
+	"mPR/internal/metrics"
+	"mPR/internal/ratelimit"
+	"mPR/internal/requestid"
+)
+
+// requestIDKey is the metadata key of requestid.Header, gRPC lowercases keys.
+var requestIDKey = strings.ToLower(requestid.Header)
+
+// requestID takes the caller's x-request-id or generates one, stores it in
+// the context and echoes it in the response header.
+func requestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIDKey); len(values) > 0 && requestid.Valid(values[0]) {
+				id = values[0]
+			}
+		}
+		if id == "" {
+			id = requestid.Generate()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+		return handler(requestid.NewContext(ctx, id), req)
+	}
+}
+
+func accessLog(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+
+		entry := logger.WithContext(ctx, log)
+		switch code {
+		case codes.OK:
+			entry.Info("gRPC request", fields...)
+		case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+			entry.Error("gRPC request", fields...)
+		default:
+			entry.Warn("gRPC request", fields...)
+		}
+
+		return resp, err
+	}
+}
+
+// observe records the call like middleware.Metrics records HTTP requests,
+// including NO_CANDIDATE failures labelled with the full method name.
+func observe(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		st := status.Convert(err)
+		m.ObserveGRPC(info.FullMethod, st.Code().String(), time.Since(start))
+		if st.Code() == codes.FailedPrecondition && st.Message() == custom.ErrNoCandidate.Error() {
+			m.IncNoCandidate(info.FullMethod)
+		}
+
+		return resp, err
+	}
+}
+
+func recovery(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.WithContext(ctx, log).Error("Panic recovered",
+					zap.Any("panic", recovered),
+					zap.Stack("stack"),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// rateLimit draws from the same buckets as the REST route groups: services map
+// to scopes, and callers are keyed by ratelimit.ClientKey on the admin token or
+// the peer address. Methods of other services, such as health, are not limited.
+func rateLimit(store ratelimit.Store, scopes map[string]string, limits map[string]config.Limit, adminToken string, log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		scope, ok := scopes[serviceName(info.FullMethod)]
+		limit := limits[scope]
+		if !ok || store == nil || limit.Rate <= 0 || limit.Burst <= 0 {
+			return handler(ctx, req)
+		}
+
+		key := scope + ":" + ratelimit.ClientKey(bearerToken(ctx), adminToken, peerAddr(ctx))
+
+		allowed, retryAfter, err := store.Take(ctx, key, limit.Rate, limit.Burst)
+		if err != nil {
+			logger.WithContext(ctx, log).Warn("Rate limiter unavailable, request allowed", zap.String("scope", scope), zap.Error(err))
+			return handler(ctx, req)
+		}
+
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+			return nil, status.Error(codes.ResourceExhausted, "RATE_LIMITED")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// serviceName returns "mpr.v1.TeamService" for "/mpr.v1.TeamService/AddTeam".
+func serviceName(fullMethod string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return name
+}
+
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || scheme != "Bearer" {
+		return ""
+	}
+
+	return token
+}
+
+// peerAddr is the caller's IP without the port, or the whole address when it
+// has none.
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+// adminAuth checks the "authorization: Bearer <token>" metadata on methods.
+func adminAuth(adminToken string, methods map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+		}
+
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || scheme != "Bearer" {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+		if adminToken == "" || token != adminToken {
+			return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,919 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: mpr/v1/pull_requests.proto
+
+package mprv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestSort int32
+
+const (
+	// Same as CREATED_AT.
+	PullRequestSort_PULL_REQUEST_SORT_UNSPECIFIED PullRequestSort = 0
+	PullRequestSort_PULL_REQUEST_SORT_CREATED_AT  PullRequestSort = 1
+	PullRequestSort_PULL_REQUEST_SORT_NAME        PullRequestSort = 2
+	PullRequestSort_PULL_REQUEST_SORT_ID          PullRequestSort = 3
+)
+
+// Enum value maps for PullRequestSort.
+var (
+	PullRequestSort_name = map[int32]string{
+		0: "PULL_REQUEST_SORT_UNSPECIFIED",
+		1: "PULL_REQUEST_SORT_CREATED_AT",
+		2: "PULL_REQUEST_SORT_NAME",
+		3: "PULL_REQUEST_SORT_ID",
+	}
+	PullRequestSort_value = map[string]int32{
+		"PULL_REQUEST_SORT_UNSPECIFIED": 0,
+		"PULL_REQUEST_SORT_CREATED_AT":  1,
+		"PULL_REQUEST_SORT_NAME":        2,
+		"PULL_REQUEST_SORT_ID":          3,
+	}
+)
+
+func (x PullRequestSort) Enum() *PullRequestSort {
+	p := new(PullRequestSort)
+	*p = x
+	return p
+}
+
+func (x PullRequestSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_mpr_v1_pull_requests_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestSort) Type() protoreflect.EnumType {
+	return &file_mpr_v1_pull_requests_proto_enumTypes[0]
+}
+
+func (x PullRequestSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestSort.Descriptor instead.
+func (PullRequestSort) EnumDescriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{0}
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type MergePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestResponse) Reset() {
+	*x = MergePullRequestResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestResponse) ProtoMessage() {}
+
+func (x *MergePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*MergePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MergePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId     string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReassignReviewerResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type ReviewPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Decision      ReviewDecision         `protobuf:"varint,3,opt,name=decision,proto3,enum=mpr.v1.ReviewDecision" json:"decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPullRequestRequest) Reset() {
+	*x = ReviewPullRequestRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPullRequestRequest) ProtoMessage() {}
+
+func (x *ReviewPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReviewPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReviewPullRequestRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewPullRequestRequest) GetDecision() ReviewDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return ReviewDecision_REVIEW_DECISION_UNSPECIFIED
+}
+
+type ReviewPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPullRequestResponse) Reset() {
+	*x = ReviewPullRequestResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPullRequestResponse) ProtoMessage() {}
+
+func (x *ReviewPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewPullRequestResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type GetPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestResponse) Reset() {
+	*x = GetPullRequestResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestResponse) ProtoMessage() {}
+
+func (x *GetPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ListPullRequestsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Status     PullRequestStatus      `protobuf:"varint,1,opt,name=status,proto3,enum=mpr.v1.PullRequestStatus" json:"status,omitempty"`
+	AuthorId   string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId string                 `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	TeamName   string                 `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// Case-insensitive substring of the PR name.
+	Query       string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MergedFrom  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	MergedTo    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=merged_to,json=mergedTo,proto3" json:"merged_to,omitempty"`
+	Sort        PullRequestSort        `protobuf:"varint,10,opt,name=sort,proto3,enum=mpr.v1.PullRequestSort" json:"sort,omitempty"`
+	// Rows are sorted in descending order unless ascending is set.
+	Ascending     bool   `protobuf:"varint,11,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	Limit         int32  `protobuf:"varint,12,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string `protobuf:"bytes,13,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetSort() PullRequestSort {
+	if x != nil {
+		return x.Sort
+	}
+	return PullRequestSort_PULL_REQUEST_SORT_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *ListPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_pull_requests_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_pull_requests_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_mpr_v1_pull_requests_proto protoreflect.FileDescriptor
+
+const file_mpr_v1_pull_requests_proto_rawDesc = "" +
+	"\n" +
+	"\x1ampr/v1/pull_requests.proto\x12\x06mpr.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16mpr/v1/resources.proto\"\x8b\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"@\n" +
+	"\x19CreatePullRequestResponse\x12#\n" +
+	"\x02pr\x18\x01 \x01(\v2\x13.mpr.v1.PullRequestR\x02pr\"A\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"?\n" +
+	"\x18MergePullRequestResponse\x12#\n" +
+	"\x02pr\x18\x01 \x01(\v2\x13.mpr.v1.PullRequestR\x02pr\"a\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\"`\n" +
+	"\x18ReassignReviewerResponse\x12#\n" +
+	"\x02pr\x18\x01 \x01(\v2\x13.mpr.v1.PullRequestR\x02pr\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x97\x01\n" +
+	"\x18ReviewPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x122\n" +
+	"\bdecision\x18\x03 \x01(\x0e2\x16.mpr.v1.ReviewDecisionR\bdecision\"C\n" +
+	"\x19ReviewPullRequestResponse\x12&\n" +
+	"\x06review\x18\x01 \x01(\v2\x0e.mpr.v1.ReviewR\x06review\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"=\n" +
+	"\x16GetPullRequestResponse\x12#\n" +
+	"\x02pr\x18\x01 \x01(\v2\x13.mpr.v1.PullRequestR\x02pr\"\xa6\x04\n" +
+	"\x17ListPullRequestsRequest\x121\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x19.mpr.v1.PullRequestStatusR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12;\n" +
+	"\vmerged_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mergedFrom\x127\n" +
+	"\tmerged_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmergedTo\x12+\n" +
+	"\x04sort\x18\n" +
+	" \x01(\x0e2\x17.mpr.v1.PullRequestSortR\x04sort\x12\x1c\n" +
+	"\tascending\x18\v \x01(\bR\tascending\x12\x14\n" +
+	"\x05limit\x18\f \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\r \x01(\tR\x06cursor\"u\n" +
+	"\x18ListPullRequestsResponse\x128\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x13.mpr.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor*\x8c\x01\n" +
+	"\x0fPullRequestSort\x12!\n" +
+	"\x1dPULL_REQUEST_SORT_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cPULL_REQUEST_SORT_CREATED_AT\x10\x01\x12\x1a\n" +
+	"\x16PULL_REQUEST_SORT_NAME\x10\x02\x12\x18\n" +
+	"\x14PULL_REQUEST_SORT_ID\x10\x032\x9e\x04\n" +
+	"\x12PullRequestService\x12X\n" +
+	"\x11CreatePullRequest\x12 .mpr.v1.CreatePullRequestRequest\x1a!.mpr.v1.CreatePullRequestResponse\x12U\n" +
+	"\x10MergePullRequest\x12\x1f.mpr.v1.MergePullRequestRequest\x1a .mpr.v1.MergePullRequestResponse\x12U\n" +
+	"\x10ReassignReviewer\x12\x1f.mpr.v1.ReassignReviewerRequest\x1a .mpr.v1.ReassignReviewerResponse\x12X\n" +
+	"\x11ReviewPullRequest\x12 .mpr.v1.ReviewPullRequestRequest\x1a!.mpr.v1.ReviewPullRequestResponse\x12O\n" +
+	"\x0eGetPullRequest\x12\x1d.mpr.v1.GetPullRequestRequest\x1a\x1e.mpr.v1.GetPullRequestResponse\x12U\n" +
+	"\x10ListPullRequests\x12\x1f.mpr.v1.ListPullRequestsRequest\x1a .mpr.v1.ListPullRequestsResponseB\"Z mPR/internal/grpcapi/mprv1;mprv1b\x06proto3"
+
+var (
+	file_mpr_v1_pull_requests_proto_rawDescOnce sync.Once
+	file_mpr_v1_pull_requests_proto_rawDescData []byte
+)
+
+func file_mpr_v1_pull_requests_proto_rawDescGZIP() []byte {
+	file_mpr_v1_pull_requests_proto_rawDescOnce.Do(func() {
+		file_mpr_v1_pull_requests_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mpr_v1_pull_requests_proto_rawDesc), len(file_mpr_v1_pull_requests_proto_rawDesc)))
+	})
+	return file_mpr_v1_pull_requests_proto_rawDescData
+}
+
+var file_mpr_v1_pull_requests_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mpr_v1_pull_requests_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_mpr_v1_pull_requests_proto_goTypes = []any{
+	(PullRequestSort)(0),              // 0: mpr.v1.PullRequestSort
+	(*CreatePullRequestRequest)(nil),  // 1: mpr.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil), // 2: mpr.v1.CreatePullRequestResponse
+	(*MergePullRequestRequest)(nil),   // 3: mpr.v1.MergePullRequestRequest
+	(*MergePullRequestResponse)(nil),  // 4: mpr.v1.MergePullRequestResponse
+	(*ReassignReviewerRequest)(nil),   // 5: mpr.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),  // 6: mpr.v1.ReassignReviewerResponse
+	(*ReviewPullRequestRequest)(nil),  // 7: mpr.v1.ReviewPullRequestRequest
+	(*ReviewPullRequestResponse)(nil), // 8: mpr.v1.ReviewPullRequestResponse
+	(*GetPullRequestRequest)(nil),     // 9: mpr.v1.GetPullRequestRequest
+	(*GetPullRequestResponse)(nil),    // 10: mpr.v1.GetPullRequestResponse
+	(*ListPullRequestsRequest)(nil),   // 11: mpr.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),  // 12: mpr.v1.ListPullRequestsResponse
+	(*PullRequest)(nil),               // 13: mpr.v1.PullRequest
+	(ReviewDecision)(0),               // 14: mpr.v1.ReviewDecision
+	(*Review)(nil),                    // 15: mpr.v1.Review
+	(PullRequestStatus)(0),            // 16: mpr.v1.PullRequestStatus
+	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
+}
+var file_mpr_v1_pull_requests_proto_depIdxs = []int32{
+	13, // 0: mpr.v1.CreatePullRequestResponse.pr:type_name -> mpr.v1.PullRequest
+	13, // 1: mpr.v1.MergePullRequestResponse.pr:type_name -> mpr.v1.PullRequest
+	13, // 2: mpr.v1.ReassignReviewerResponse.pr:type_name -> mpr.v1.PullRequest
+	14, // 3: mpr.v1.ReviewPullRequestRequest.decision:type_name -> mpr.v1.ReviewDecision
+	15, // 4: mpr.v1.ReviewPullRequestResponse.review:type_name -> mpr.v1.Review
+	13, // 5: mpr.v1.GetPullRequestResponse.pr:type_name -> mpr.v1.PullRequest
+	16, // 6: mpr.v1.ListPullRequestsRequest.status:type_name -> mpr.v1.PullRequestStatus
+	17, // 7: mpr.v1.ListPullRequestsRequest.created_from:type_name -> google.protobuf.Timestamp
+	17, // 8: mpr.v1.ListPullRequestsRequest.created_to:type_name -> google.protobuf.Timestamp
+	17, // 9: mpr.v1.ListPullRequestsRequest.merged_from:type_name -> google.protobuf.Timestamp
+	17, // 10: mpr.v1.ListPullRequestsRequest.merged_to:type_name -> google.protobuf.Timestamp
+	0,  // 11: mpr.v1.ListPullRequestsRequest.sort:type_name -> mpr.v1.PullRequestSort
+	13, // 12: mpr.v1.ListPullRequestsResponse.pull_requests:type_name -> mpr.v1.PullRequest
+	1,  // 13: mpr.v1.PullRequestService.CreatePullRequest:input_type -> mpr.v1.CreatePullRequestRequest
+	3,  // 14: mpr.v1.PullRequestService.MergePullRequest:input_type -> mpr.v1.MergePullRequestRequest
+	5,  // 15: mpr.v1.PullRequestService.ReassignReviewer:input_type -> mpr.v1.ReassignReviewerRequest
+	7,  // 16: mpr.v1.PullRequestService.ReviewPullRequest:input_type -> mpr.v1.ReviewPullRequestRequest
+	9,  // 17: mpr.v1.PullRequestService.GetPullRequest:input_type -> mpr.v1.GetPullRequestRequest
+	11, // 18: mpr.v1.PullRequestService.ListPullRequests:input_type -> mpr.v1.ListPullRequestsRequest
+	2,  // 19: mpr.v1.PullRequestService.CreatePullRequest:output_type -> mpr.v1.CreatePullRequestResponse
+	4,  // 20: mpr.v1.PullRequestService.MergePullRequest:output_type -> mpr.v1.MergePullRequestResponse
+	6,  // 21: mpr.v1.PullRequestService.ReassignReviewer:output_type -> mpr.v1.ReassignReviewerResponse
+	8,  // 22: mpr.v1.PullRequestService.ReviewPullRequest:output_type -> mpr.v1.ReviewPullRequestResponse
+	10, // 23: mpr.v1.PullRequestService.GetPullRequest:output_type -> mpr.v1.GetPullRequestResponse
+	12, // 24: mpr.v1.PullRequestService.ListPullRequests:output_type -> mpr.v1.ListPullRequestsResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_mpr_v1_pull_requests_proto_init() }
+func file_mpr_v1_pull_requests_proto_init() {
+	if File_mpr_v1_pull_requests_proto != nil {
+		return
+	}
+	file_mpr_v1_resources_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mpr_v1_pull_requests_proto_rawDesc), len(file_mpr_v1_pull_requests_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mpr_v1_pull_requests_proto_goTypes,
+		DependencyIndexes: file_mpr_v1_pull_requests_proto_depIdxs,
+		EnumInfos:         file_mpr_v1_pull_requests_proto_enumTypes,
+		MessageInfos:      file_mpr_v1_pull_requests_proto_msgTypes,
+	}.Build()
+	File_mpr_v1_pull_requests_proto = out.File
+	file_mpr_v1_pull_requests_proto_goTypes = nil
+	file_mpr_v1_pull_requests_proto_depIdxs = nil
+}
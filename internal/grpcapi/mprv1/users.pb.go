@@ -0,0 +1,766 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: mpr/v1/users.proto
+
+package mprv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReviewStatusFilter int32
+
+const (
+	// Same as OPEN.
+	ReviewStatusFilter_REVIEW_STATUS_FILTER_UNSPECIFIED ReviewStatusFilter = 0
+	ReviewStatusFilter_REVIEW_STATUS_FILTER_OPEN        ReviewStatusFilter = 1
+	ReviewStatusFilter_REVIEW_STATUS_FILTER_MERGED      ReviewStatusFilter = 2
+	ReviewStatusFilter_REVIEW_STATUS_FILTER_ALL         ReviewStatusFilter = 3
+)
+
+// Enum value maps for ReviewStatusFilter.
+var (
+	ReviewStatusFilter_name = map[int32]string{
+		0: "REVIEW_STATUS_FILTER_UNSPECIFIED",
+		1: "REVIEW_STATUS_FILTER_OPEN",
+		2: "REVIEW_STATUS_FILTER_MERGED",
+		3: "REVIEW_STATUS_FILTER_ALL",
+	}
+	ReviewStatusFilter_value = map[string]int32{
+		"REVIEW_STATUS_FILTER_UNSPECIFIED": 0,
+		"REVIEW_STATUS_FILTER_OPEN":        1,
+		"REVIEW_STATUS_FILTER_MERGED":      2,
+		"REVIEW_STATUS_FILTER_ALL":         3,
+	}
+)
+
+func (x ReviewStatusFilter) Enum() *ReviewStatusFilter {
+	p := new(ReviewStatusFilter)
+	*p = x
+	return p
+}
+
+func (x ReviewStatusFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewStatusFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_mpr_v1_users_proto_enumTypes[0].Descriptor()
+}
+
+func (ReviewStatusFilter) Type() protoreflect.EnumType {
+	return &file_mpr_v1_users_proto_enumTypes[0]
+}
+
+func (x ReviewStatusFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewStatusFilter.Descriptor instead.
+func (ReviewStatusFilter) EnumDescriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{0}
+}
+
+type SetIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_mpr_v1_users_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetIsActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveResponse) Reset() {
+	*x = SetIsActiveResponse{}
+	mi := &file_mpr_v1_users_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveResponse) ProtoMessage() {}
+
+func (x *SetIsActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetIsActiveResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetIsActiveResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SetSlackIDRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Empty clears the Slack member ID.
+	SlackId       string `protobuf:"bytes,2,opt,name=slack_id,json=slackId,proto3" json:"slack_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSlackIDRequest) Reset() {
+	*x = SetSlackIDRequest{}
+	mi := &file_mpr_v1_users_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSlackIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSlackIDRequest) ProtoMessage() {}
+
+func (x *SetSlackIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSlackIDRequest.ProtoReflect.Descriptor instead.
+func (*SetSlackIDRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetSlackIDRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetSlackIDRequest) GetSlackId() string {
+	if x != nil {
+		return x.SlackId
+	}
+	return ""
+}
+
+type SetSlackIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSlackIDResponse) Reset() {
+	*x = SetSlackIDResponse{}
+	mi := &file_mpr_v1_users_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSlackIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSlackIDResponse) ProtoMessage() {}
+
+func (x *SetSlackIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSlackIDResponse.ProtoReflect.Descriptor instead.
+func (*SetSlackIDResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetSlackIDResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SetEmailRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Empty clears the address.
+	Email         string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	EmailOptOut   bool   `protobuf:"varint,3,opt,name=email_opt_out,json=emailOptOut,proto3" json:"email_opt_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEmailRequest) Reset() {
+	*x = SetEmailRequest{}
+	mi := &file_mpr_v1_users_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEmailRequest) ProtoMessage() {}
+
+func (x *SetEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEmailRequest.ProtoReflect.Descriptor instead.
+func (*SetEmailRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetEmailRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SetEmailRequest) GetEmailOptOut() bool {
+	if x != nil {
+		return x.EmailOptOut
+	}
+	return false
+}
+
+type SetEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEmailResponse) Reset() {
+	*x = SetEmailResponse{}
+	mi := &file_mpr_v1_users_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEmailResponse) ProtoMessage() {}
+
+func (x *SetEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEmailResponse.ProtoReflect.Descriptor instead.
+func (*SetEmailResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetEmailResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        ReviewStatusFilter     `protobuf:"varint,2,opt,name=status,proto3,enum=mpr.v1.ReviewStatusFilter" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewRequest) Reset() {
+	*x = GetReviewRequest{}
+	mi := &file_mpr_v1_users_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewRequest) ProtoMessage() {}
+
+func (x *GetReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewRequest) GetStatus() ReviewStatusFilter {
+	if x != nil {
+		return x.Status
+	}
+	return ReviewStatusFilter_REVIEW_STATUS_FILTER_UNSPECIFIED
+}
+
+func (x *GetReviewRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReviewRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewResponse) Reset() {
+	*x = GetReviewResponse{}
+	mi := &file_mpr_v1_users_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewResponse) ProtoMessage() {}
+
+func (x *GetReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetReviewResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetReviewResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TeamName       string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive       *bool                  `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	UsernamePrefix string                 `protobuf:"bytes,3,opt,name=username_prefix,json=usernamePrefix,proto3" json:"username_prefix,omitempty"`
+	Limit          int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor         string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_mpr_v1_users_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListUsersRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetUsernamePrefix() string {
+	if x != nil {
+		return x.UsernamePrefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserSummary         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_mpr_v1_users_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpr_v1_users_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_mpr_v1_users_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserSummary {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_mpr_v1_users_proto protoreflect.FileDescriptor
+
+const file_mpr_v1_users_proto_rawDesc = "" +
+	"\n" +
+	"\x12mpr/v1/users.proto\x12\x06mpr.v1\x1a\x16mpr/v1/resources.proto\"J\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"7\n" +
+	"\x13SetIsActiveResponse\x12 \n" +
+	"\x04user\x18\x01 \x01(\v2\f.mpr.v1.UserR\x04user\"G\n" +
+	"\x11SetSlackIDRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bslack_id\x18\x02 \x01(\tR\aslackId\"6\n" +
+	"\x12SetSlackIDResponse\x12 \n" +
+	"\x04user\x18\x01 \x01(\v2\f.mpr.v1.UserR\x04user\"d\n" +
+	"\x0fSetEmailRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\"\n" +
+	"\remail_opt_out\x18\x03 \x01(\bR\vemailOptOut\"4\n" +
+	"\x10SetEmailResponse\x12 \n" +
+	"\x04user\x18\x01 \x01(\v2\f.mpr.v1.UserR\x04user\"\x8d\x01\n" +
+	"\x10GetReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1a.mpr.v1.ReviewStatusFilterR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x87\x01\n" +
+	"\x11GetReviewResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x128\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x13.mpr.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xb6\x01\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12 \n" +
+	"\tis_active\x18\x02 \x01(\bH\x00R\bisActive\x88\x01\x01\x12'\n" +
+	"\x0fusername_prefix\x18\x03 \x01(\tR\x0eusernamePrefix\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_is_active\"_\n" +
+	"\x11ListUsersResponse\x12)\n" +
+	"\x05users\x18\x01 \x03(\v2\x13.mpr.v1.UserSummaryR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor*\x98\x01\n" +
+	"\x12ReviewStatusFilter\x12$\n" +
+	" REVIEW_STATUS_FILTER_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19REVIEW_STATUS_FILTER_OPEN\x10\x01\x12\x1f\n" +
+	"\x1bREVIEW_STATUS_FILTER_MERGED\x10\x02\x12\x1c\n" +
+	"\x18REVIEW_STATUS_FILTER_ALL\x10\x032\xdd\x02\n" +
+	"\vUserService\x12F\n" +
+	"\vSetIsActive\x12\x1a.mpr.v1.SetIsActiveRequest\x1a\x1b.mpr.v1.SetIsActiveResponse\x12C\n" +
+	"\n" +
+	"SetSlackID\x12\x19.mpr.v1.SetSlackIDRequest\x1a\x1a.mpr.v1.SetSlackIDResponse\x12=\n" +
+	"\bSetEmail\x12\x17.mpr.v1.SetEmailRequest\x1a\x18.mpr.v1.SetEmailResponse\x12@\n" +
+	"\tGetReview\x12\x18.mpr.v1.GetReviewRequest\x1a\x19.mpr.v1.GetReviewResponse\x12@\n" +
+	"\tListUsers\x12\x18.mpr.v1.ListUsersRequest\x1a\x19.mpr.v1.ListUsersResponseB\"Z mPR/internal/grpcapi/mprv1;mprv1b\x06proto3"
+
+var (
+	file_mpr_v1_users_proto_rawDescOnce sync.Once
+	file_mpr_v1_users_proto_rawDescData []byte
+)
+
+func file_mpr_v1_users_proto_rawDescGZIP() []byte {
+	file_mpr_v1_users_proto_rawDescOnce.Do(func() {
+		file_mpr_v1_users_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mpr_v1_users_proto_rawDesc), len(file_mpr_v1_users_proto_rawDesc)))
+	})
+	return file_mpr_v1_users_proto_rawDescData
+}
+
+var file_mpr_v1_users_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mpr_v1_users_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_mpr_v1_users_proto_goTypes = []any{
+	(ReviewStatusFilter)(0),     // 0: mpr.v1.ReviewStatusFilter
+	(*SetIsActiveRequest)(nil),  // 1: mpr.v1.SetIsActiveRequest
+	(*SetIsActiveResponse)(nil), // 2: mpr.v1.SetIsActiveResponse
+	(*SetSlackIDRequest)(nil),   // 3: mpr.v1.SetSlackIDRequest
+	(*SetSlackIDResponse)(nil),  // 4: mpr.v1.SetSlackIDResponse
+	(*SetEmailRequest)(nil),     // 5: mpr.v1.SetEmailRequest
+	(*SetEmailResponse)(nil),    // 6: mpr.v1.SetEmailResponse
+	(*GetReviewRequest)(nil),    // 7: mpr.v1.GetReviewRequest
+	(*GetReviewResponse)(nil),   // 8: mpr.v1.GetReviewResponse
+	(*ListUsersRequest)(nil),    // 9: mpr.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),   // 10: mpr.v1.ListUsersResponse
+	(*User)(nil),                // 11: mpr.v1.User
+	(*PullRequest)(nil),         // 12: mpr.v1.PullRequest
+	(*UserSummary)(nil),         // 13: mpr.v1.UserSummary
+}
+var file_mpr_v1_users_proto_depIdxs = []int32{
+	11, // 0: mpr.v1.SetIsActiveResponse.user:type_name -> mpr.v1.User
+	11, // 1: mpr.v1.SetSlackIDResponse.user:type_name -> mpr.v1.User
+	11, // 2: mpr.v1.SetEmailResponse.user:type_name -> mpr.v1.User
+	0,  // 3: mpr.v1.GetReviewRequest.status:type_name -> mpr.v1.ReviewStatusFilter
+	12, // 4: mpr.v1.GetReviewResponse.pull_requests:type_name -> mpr.v1.PullRequest
+	13, // 5: mpr.v1.ListUsersResponse.users:type_name -> mpr.v1.UserSummary
+	1,  // 6: mpr.v1.UserService.SetIsActive:input_type -> mpr.v1.SetIsActiveRequest
+	3,  // 7: mpr.v1.UserService.SetSlackID:input_type -> mpr.v1.SetSlackIDRequest
+	5,  // 8: mpr.v1.UserService.SetEmail:input_type -> mpr.v1.SetEmailRequest
+	7,  // 9: mpr.v1.UserService.GetReview:input_type -> mpr.v1.GetReviewRequest
+	9,  // 10: mpr.v1.UserService.ListUsers:input_type -> mpr.v1.ListUsersRequest
+	2,  // 11: mpr.v1.UserService.SetIsActive:output_type -> mpr.v1.SetIsActiveResponse
+	4,  // 12: mpr.v1.UserService.SetSlackID:output_type -> mpr.v1.SetSlackIDResponse
+	6,  // 13: mpr.v1.UserService.SetEmail:output_type -> mpr.v1.SetEmailResponse
+	8,  // 14: mpr.v1.UserService.GetReview:output_type -> mpr.v1.GetReviewResponse
+	10, // 15: mpr.v1.UserService.ListUsers:output_type -> mpr.v1.ListUsersResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_mpr_v1_users_proto_init() }
+func file_mpr_v1_users_proto_init() {
+	if File_mpr_v1_users_proto != nil {
+		return
+	}
+	file_mpr_v1_resources_proto_init()
+	file_mpr_v1_users_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mpr_v1_users_proto_rawDesc), len(file_mpr_v1_users_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mpr_v1_users_proto_goTypes,
+		DependencyIndexes: file_mpr_v1_users_proto_depIdxs,
+		EnumInfos:         file_mpr_v1_users_proto_enumTypes,
+		MessageInfos:      file_mpr_v1_users_proto_msgTypes,
+	}.Build()
+	File_mpr_v1_users_proto = out.File
+	file_mpr_v1_users_proto_goTypes = nil
+	file_mpr_v1_users_proto_depIdxs = nil
+}
@@ -0,0 +1,168 @@
+package grpcapi
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/custom"
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/service"
+	"mPR/internal/storage/models"
+)
+
+type pullRequestServer struct {
+	mprv1.UnimplementedPullRequestServiceServer
+	services *service.Manager
+	log      *zap.Logger
+}
+
+var sorts = map[mprv1.PullRequestSort]string{
+	mprv1.PullRequestSort_PULL_REQUEST_SORT_UNSPECIFIED: "",
+	mprv1.PullRequestSort_PULL_REQUEST_SORT_CREATED_AT:  models.SortCreatedAt,
+	mprv1.PullRequestSort_PULL_REQUEST_SORT_NAME:        models.SortName,
+	mprv1.PullRequestSort_PULL_REQUEST_SORT_ID:          models.SortID,
+}
+
+func (s *pullRequestServer) CreatePullRequest(
+	ctx context.Context,
+	req *mprv1.CreatePullRequestRequest,
+) (*mprv1.CreatePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+	if req.GetAuthorId() == "" {
+		return nil, invalidArgument("author_id is required")
+	}
+
+	pr, err := s.services.PullRequests.Create(ctx, &models.PullRequests{
+		ID:       req.GetPullRequestId(),
+		Name:     req.GetPullRequestName(),
+		AuthorID: req.GetAuthorId(),
+		Status:   custom.StatusOpen,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error create PR")
+	}
+
+	return &mprv1.CreatePullRequestResponse{Pr: toPullRequest(pr)}, nil
+}
+
+func (s *pullRequestServer) MergePullRequest(
+	ctx context.Context,
+	req *mprv1.MergePullRequestRequest,
+) (*mprv1.MergePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.services.PullRequests.Merge(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error merge PR")
+	}
+
+	return &mprv1.MergePullRequestResponse{Pr: toPullRequest(pr)}, nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(
+	ctx context.Context,
+	req *mprv1.ReassignReviewerRequest,
+) (*mprv1.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+	if req.GetOldUserId() == "" {
+		return nil, invalidArgument("old_user_id is required")
+	}
+
+	pr, replacedBy, err := s.services.PullRequests.Reassign(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error reassign reviewer")
+	}
+
+	return &mprv1.ReassignReviewerResponse{Pr: toPullRequest(pr), ReplacedBy: replacedBy}, nil
+}
+
+func (s *pullRequestServer) ReviewPullRequest(
+	ctx context.Context,
+	req *mprv1.ReviewPullRequestRequest,
+) (*mprv1.ReviewPullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetReviewerId() == "" {
+		return nil, invalidArgument("pull_request_id and reviewer_id are required")
+	}
+
+	decision, ok := fromDecision(req.GetDecision())
+	if !ok {
+		return nil, invalidArgument("decision must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+	}
+
+	review, err := s.services.PullRequests.Review(ctx, req.GetPullRequestId(), req.GetReviewerId(), decision)
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error review PR")
+	}
+
+	return &mprv1.ReviewPullRequestResponse{Review: toReview(review)}, nil
+}
+
+func (s *pullRequestServer) GetPullRequest(
+	ctx context.Context,
+	req *mprv1.GetPullRequestRequest,
+) (*mprv1.GetPullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.services.PullRequests.Get(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error get PR")
+	}
+
+	return &mprv1.GetPullRequestResponse{Pr: toPullRequest(pr)}, nil
+}
+
+func (s *pullRequestServer) ListPullRequests(
+	ctx context.Context,
+	req *mprv1.ListPullRequestsRequest,
+) (*mprv1.ListPullRequestsResponse, error) {
+	var prStatus string
+	if req.GetStatus() != mprv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED {
+		var ok bool
+		if prStatus, ok = fromStatus(req.GetStatus()); !ok {
+			return nil, invalidArgument("status must be OPEN or MERGED")
+		}
+	}
+
+	sort, ok := sorts[req.GetSort()]
+	if !ok {
+		return nil, invalidArgument("sort must be created_at, name or id")
+	}
+
+	if err := validLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	filter := models.PullRequestFilter{
+		Status:      prStatus,
+		AuthorID:    req.GetAuthorId(),
+		ReviewerID:  req.GetReviewerId(),
+		TeamName:    req.GetTeamName(),
+		Search:      req.GetQuery(),
+		CreatedFrom: fromTimestamp(req.GetCreatedFrom()),
+		CreatedTo:   fromTimestamp(req.GetCreatedTo()),
+		MergedFrom:  fromTimestamp(req.GetMergedFrom()),
+		MergedTo:    fromTimestamp(req.GetMergedTo()),
+		Sort:        sort,
+		Desc:        !req.GetAscending(),
+		Limit:       int(req.GetLimit()),
+	}
+
+	page, err := s.services.PullRequests.List(ctx, filter, req.GetCursor())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error list PRs")
+	}
+
+	return &mprv1.ListPullRequestsResponse{
+		PullRequests: toPullRequests(page.PullRequests),
+		NextCursor:   page.NextCursor,
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Package grpcapi serves the team, user and pull request APIs over gRPC. The
+// services mirror the REST handlers and share service.Manager with them.
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/stats"
+
+	"mPR/internal/config"
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/metrics"
+	"mPR/internal/pagination"
+	"mPR/internal/ratelimit"
+	"mPR/internal/service"
+)
+
+// adminMethods need the admin token, like the routes behind AdminAuth.
+var adminMethods = map[string]bool{
+	mprv1.UserService_SetIsActive_FullMethodName: true,
+	mprv1.UserService_SetSlackID_FullMethodName:  true,
+	mprv1.UserService_SetEmail_FullMethodName:    true,
+}
+
+// limitScopes puts each service in the rate limit scope of the REST group it
+// mirrors, so a caller shares one bucket across both APIs.
+var limitScopes = map[string]string{
+	mprv1.TeamService_ServiceDesc.ServiceName:        "team",
+	mprv1.UserService_ServiceDesc.ServiceName:        "users",
+	mprv1.PullRequestService_ServiceDesc.ServiceName: "pullRequest",
+}
+
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// New builds the server. Idempotency-Key has no gRPC counterpart: retried
+// calls are not deduplicated.
+func New(
+	services *service.Manager,
+	cfg *config.Config,
+	limiter ratelimit.Store,
+	m *metrics.Metrics,
+	log *zap.Logger,
+) *Server {
+	limits := map[string]config.Limit{
+		"team":        cfg.Limits.Team,
+		"users":       cfg.Limits.Users,
+		"pullRequest": cfg.Limits.PullRequest,
+	}
+
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(traced))),
+		grpc.ChainUnaryInterceptor(
+			requestID(),
+			accessLog(log),
+			observe(m),
+			recovery(log),
+			rateLimit(limiter, limitScopes, limits, cfg.App.AdminToken, log),
+			adminAuth(cfg.App.AdminToken, adminMethods),
+		),
+	)
+
+	mprv1.RegisterTeamServiceServer(srv, &teamServer{services: services, log: log})
+	mprv1.RegisterUserServiceServer(srv, &userServer{services: services, log: log})
+	mprv1.RegisterPullRequestServiceServer(srv, &pullRequestServer{services: services, log: log})
+
+	healthServer := health.NewServer()
+	for _, name := range []string{
+		"",
+		mprv1.TeamService_ServiceDesc.ServiceName,
+		mprv1.UserService_ServiceDesc.ServiceName,
+		mprv1.PullRequestService_ServiceDesc.ServiceName,
+	} {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
+
+	return &Server{Server: srv, health: healthServer}
+}
+
+// traced skips health checks and reflection, like the HTTP probes.
+func traced(info *stats.RPCTagInfo) bool {
+	return !strings.HasPrefix(info.FullMethodName, "/grpc.")
+}
+
+// Shutdown reports NOT_SERVING to health checks and waits for in-flight calls
+// until ctx is done, after which the remaining calls are cancelled.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+		<-done
+	}
+}
+
+// validLimit rejects page sizes outside 0..pagination.MaxLimit, where 0 means the default.
+func validLimit(limit int32) error {
+	if limit < 0 || limit > pagination.MaxLimit {
+		return invalidArgument("limit must be between 1 and 200")
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"mPR/internal/config"
+	"mPR/internal/events"
+	"mPR/internal/grpcapi"
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/metrics"
+	"mPR/internal/ratelimit"
+	"mPR/internal/service"
+	"mPR/internal/storage/repository/memory"
+)
+
+const adminToken = "secret"
+
+type clients struct {
+	teams        mprv1.TeamServiceClient
+	users        mprv1.UserServiceClient
+	pullRequests mprv1.PullRequestServiceClient
+	health       healthpb.HealthClient
+}
+
+func dial(t *testing.T) clients {
+	t.Helper()
+
+	return dialWith(t, config.RateLimit{}, nil, metrics.New())
+}
+
+func dialWith(t *testing.T, limits config.RateLimit, limiter ratelimit.Store, m *metrics.Metrics) clients {
+	t.Helper()
+
+	cfg := &config.Config{App: config.Application{AdminToken: adminToken}, Limits: limits}
+	services := service.New(memory.New(0), events.NewBroker(8), 2, 0)
+	srv := grpcapi.New(services, cfg, limiter, m, zap.NewNop())
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return clients{
+		teams:        mprv1.NewTeamServiceClient(conn),
+		users:        mprv1.NewUserServiceClient(conn),
+		pullRequests: mprv1.NewPullRequestServiceClient(conn),
+		health:       healthpb.NewHealthClient(conn),
+	}
+}
+
+func seed(t *testing.T, c clients) {
+	t.Helper()
+
+	_, err := c.teams.AddTeam(context.Background(), &mprv1.AddTeamRequest{
+		TeamName: "backend",
+		Members: []*mprv1.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func admin(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+adminToken)
+}
+
+func TestPullRequestLifecycle(t *testing.T) {
+	c := dial(t)
+	seed(t, c)
+	ctx := context.Background()
+
+	created, err := c.pullRequests.CreatePullRequest(ctx, &mprv1.CreatePullRequestRequest{
+		PullRequestId: "pr1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mprv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, created.GetPr().GetStatus())
+	assert.ElementsMatch(t, []string{"u2", "u3"}, created.GetPr().GetAssignedReviewers())
+	assert.NotNil(t, created.GetPr().GetCreatedAt())
+
+	reviews, err := c.users.GetReview(ctx, &mprv1.GetReviewRequest{UserId: "u2"})
+	require.NoError(t, err)
+	require.Len(t, reviews.GetPullRequests(), 1)
+	assert.Equal(t, "pr1", reviews.GetPullRequests()[0].GetPullRequestId())
+
+	review, err := c.pullRequests.ReviewPullRequest(ctx, &mprv1.ReviewPullRequestRequest{
+		PullRequestId: "pr1", ReviewerId: "u2", Decision: mprv1.ReviewDecision_REVIEW_DECISION_APPROVED,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mprv1.ReviewDecision_REVIEW_DECISION_APPROVED, review.GetReview().GetDecision())
+
+	merged, err := c.pullRequests.MergePullRequest(ctx, &mprv1.MergePullRequestRequest{PullRequestId: "pr1"})
+	require.NoError(t, err)
+	assert.Equal(t, mprv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, merged.GetPr().GetStatus())
+	assert.NotNil(t, merged.GetPr().GetMergedAt())
+
+	list, err := c.pullRequests.ListPullRequests(ctx, &mprv1.ListPullRequestsRequest{
+		Status: mprv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+	})
+	require.NoError(t, err)
+	require.Len(t, list.GetPullRequests(), 1)
+
+	_, err = c.pullRequests.ReassignReviewer(ctx, &mprv1.ReassignReviewerRequest{PullRequestId: "pr1", OldUserId: "u2"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "PR_MERGED", status.Convert(err).Message())
+}
+
+func TestErrorCodes(t *testing.T) {
+	c := dial(t)
+	seed(t, c)
+	ctx := context.Background()
+
+	_, err := c.teams.AddTeam(ctx, &mprv1.AddTeamRequest{TeamName: "backend"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "TEAM_EXISTS", status.Convert(err).Message())
+
+	_, err = c.teams.GetTeam(ctx, &mprv1.GetTeamRequest{TeamName: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = c.teams.GetTeam(ctx, &mprv1.GetTeamRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = c.teams.ListTeams(ctx, &mprv1.ListTeamsRequest{Cursor: "!!"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "INVALID_CURSOR", status.Convert(err).Message())
+
+	_, err = c.users.ListUsers(ctx, &mprv1.ListUsersRequest{Limit: 500})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = c.pullRequests.ReviewPullRequest(ctx, &mprv1.ReviewPullRequestRequest{PullRequestId: "pr1", ReviewerId: "u2"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdminMethodsRequireToken(t *testing.T) {
+	c := dial(t)
+	seed(t, c)
+	ctx := context.Background()
+
+	_, err := c.users.SetIsActive(ctx, &mprv1.SetIsActiveRequest{UserId: "u1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	wrong := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer nope")
+	_, err = c.users.SetIsActive(wrong, &mprv1.SetIsActiveRequest{UserId: "u1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := c.users.SetIsActive(admin(ctx), &mprv1.SetIsActiveRequest{UserId: "u1"})
+	require.NoError(t, err)
+	assert.False(t, resp.GetUser().GetIsActive())
+
+	_, err = c.users.SetSlackID(admin(ctx), &mprv1.SetSlackIDRequest{UserId: "u1", SlackId: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	users, err := c.users.ListUsers(ctx, &mprv1.ListUsersRequest{IsActive: new(bool)})
+	require.NoError(t, err)
+	require.Len(t, users.GetUsers(), 1)
+	assert.Equal(t, "u1", users.GetUsers()[0].GetUser().GetUserId())
+}
+
+func TestHealthAndRequestID(t *testing.T) {
+	c := dial(t)
+
+	resp, err := c.health.Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: mprv1.PullRequestService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+	_, err = c.teams.ListTeams(ctx, &mprv1.ListTeamsRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+}
+
+func TestRateLimitSharesBucketAcrossTokens(t *testing.T) {
+	limits := config.RateLimit{Team: config.Limit{Rate: 0.01, Burst: 1}}
+	c := dialWith(t, limits, ratelimit.NewMemory(), metrics.New())
+	ctx := context.Background()
+
+	_, err := c.teams.ListTeams(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token-a"), &mprv1.ListTeamsRequest{})
+	require.NoError(t, err)
+
+	var header metadata.MD
+	rotated := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token-b")
+	_, err = c.teams.ListTeams(rotated, &mprv1.ListTeamsRequest{}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, "RATE_LIMITED", status.Convert(err).Message())
+	assert.NotEmpty(t, header.Get("retry-after"))
+
+	_, err = c.teams.ListTeams(admin(ctx), &mprv1.ListTeamsRequest{})
+	require.NoError(t, err)
+
+	_, err = c.users.ListUsers(ctx, &mprv1.ListUsersRequest{})
+	require.NoError(t, err)
+	_, err = c.health.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+}
+
+func TestMetricsCountCallsAndNoCandidate(t *testing.T) {
+	m := metrics.New()
+	c := dialWith(t, config.RateLimit{}, nil, m)
+	seed(t, c)
+	ctx := context.Background()
+
+	_, err := c.pullRequests.CreatePullRequest(ctx, &mprv1.CreatePullRequestRequest{
+		PullRequestId: "pr1", PullRequestName: "Add search", AuthorId: "u1",
+	})
+	require.NoError(t, err)
+
+	_, err = c.pullRequests.ReassignReviewer(ctx, &mprv1.ReassignReviewerRequest{PullRequestId: "pr1", OldUserId: "u2"})
+	require.Equal(t, "NO_CANDIDATE", status.Convert(err).Message())
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	assert.Contains(t, body, `pr_manager_grpc_requests_total{code="OK",method="/mpr.v1.PullRequestService/CreatePullRequest"} 1`)
+	assert.Contains(t, body, `pr_manager_grpc_requests_total{code="FailedPrecondition",method="/mpr.v1.PullRequestService/ReassignReviewer"} 1`)
+	assert.Contains(t, body, `pr_manager_no_candidate_total{route="/mpr.v1.PullRequestService/ReassignReviewer"} 1`)
+}
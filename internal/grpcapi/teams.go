@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/service"
+	userservice "mPR/internal/service/users"
+	"mPR/internal/slack"
+	"mPR/internal/storage/models"
+)
+
+type teamServer struct {
+	mprv1.UnimplementedTeamServiceServer
+	services *service.Manager
+	log      *zap.Logger
+}
+
+func (s *teamServer) AddTeam(ctx context.Context, req *mprv1.AddTeamRequest) (*mprv1.AddTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	users := make([]models.Users, 0, len(req.GetMembers()))
+	for _, m := range req.GetMembers() {
+		if m.GetUserId() == "" {
+			return nil, invalidArgument("user_id is required")
+		}
+
+		user := models.Users{
+			ID:       m.GetUserId(),
+			Username: m.GetUsername(),
+			IsActive: m.GetIsActive(),
+			TeamName: &req.TeamName,
+		}
+		if m.GetSlackId() != "" {
+			if !slack.ValidMemberID(m.GetSlackId()) {
+				return nil, invalidArgument("slack_id must be a Slack member ID like U024BE7LH")
+			}
+			user.SlackID = &m.SlackId
+		}
+		if m.GetEmail() != "" {
+			if !userservice.ValidEmail(m.GetEmail()) {
+				return nil, invalidArgument("email must be a plain address like alice@example.com")
+			}
+			user.Email = &m.Email
+		}
+
+		users = append(users, user)
+	}
+
+	team := models.Teams{Name: req.GetTeamName()}
+	if err := s.services.Teams.Add(ctx, &team, users); err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error add team")
+	}
+
+	team.Users = users
+	return &mprv1.AddTeamResponse{Team: toTeam(&team)}, nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *mprv1.GetTeamRequest) (*mprv1.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.services.Teams.Get(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error get team")
+	}
+
+	return &mprv1.GetTeamResponse{Team: toTeam(team)}, nil
+}
+
+func (s *teamServer) ListTeams(ctx context.Context, req *mprv1.ListTeamsRequest) (*mprv1.ListTeamsResponse, error) {
+	if err := validLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	page, err := s.services.Teams.List(ctx, int(req.GetLimit()), req.GetCursor())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error list teams")
+	}
+
+	teams := make([]*mprv1.TeamSummary, 0, len(page.Teams))
+	for _, t := range page.Teams {
+		teams = append(teams, &mprv1.TeamSummary{
+			TeamName:      t.Name,
+			Members:       t.Members,
+			ActiveMembers: t.ActiveMembers,
+		})
+	}
+
+	return &mprv1.ListTeamsResponse{Teams: teams, NextCursor: page.NextCursor}, nil
+}
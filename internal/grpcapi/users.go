@@ -0,0 +1,117 @@
+package grpcapi
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/custom"
+	"mPR/internal/grpcapi/mprv1"
+	"mPR/internal/service"
+	"mPR/internal/storage/models"
+)
+
+type userServer struct {
+	mprv1.UnimplementedUserServiceServer
+	services *service.Manager
+	log      *zap.Logger
+}
+
+func (s *userServer) SetIsActive(ctx context.Context, req *mprv1.SetIsActiveRequest) (*mprv1.SetIsActiveResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.services.Users.SetActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to set is_active")
+	}
+
+	return &mprv1.SetIsActiveResponse{User: toUser(user)}, nil
+}
+
+func (s *userServer) SetSlackID(ctx context.Context, req *mprv1.SetSlackIDRequest) (*mprv1.SetSlackIDResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.services.Users.SetSlackID(ctx, req.GetUserId(), req.GetSlackId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to set slack_id")
+	}
+
+	return &mprv1.SetSlackIDResponse{User: toUser(user)}, nil
+}
+
+func (s *userServer) SetEmail(ctx context.Context, req *mprv1.SetEmailRequest) (*mprv1.SetEmailResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.services.Users.SetEmail(ctx, req.GetUserId(), req.GetEmail(), req.GetEmailOptOut())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to set email")
+	}
+
+	return &mprv1.SetEmailResponse{User: toUser(user)}, nil
+}
+
+func (s *userServer) GetReview(ctx context.Context, req *mprv1.GetReviewRequest) (*mprv1.GetReviewResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	filter := models.ReviewFilter{Limit: int(req.GetLimit())}
+	switch req.GetStatus() {
+	case mprv1.ReviewStatusFilter_REVIEW_STATUS_FILTER_UNSPECIFIED, mprv1.ReviewStatusFilter_REVIEW_STATUS_FILTER_OPEN:
+		filter.Status = custom.StatusOpen
+	case mprv1.ReviewStatusFilter_REVIEW_STATUS_FILTER_MERGED:
+		filter.Status = custom.StatusMerged
+	case mprv1.ReviewStatusFilter_REVIEW_STATUS_FILTER_ALL:
+	default:
+		return nil, invalidArgument("status must be OPEN, MERGED or ALL")
+	}
+
+	if err := validLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	page, err := s.services.Users.GetUserReviews(ctx, req.GetUserId(), filter, req.GetCursor())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error receiving reviews for user")
+	}
+
+	return &mprv1.GetReviewResponse{
+		UserId:       page.UserID,
+		PullRequests: toPullRequests(page.PullRequests),
+		NextCursor:   page.NextCursor,
+	}, nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *mprv1.ListUsersRequest) (*mprv1.ListUsersResponse, error) {
+	if err := validLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	filter := models.UserFilter{
+		TeamName:       req.GetTeamName(),
+		IsActive:       req.IsActive,
+		UsernamePrefix: req.GetUsernamePrefix(),
+		Limit:          int(req.GetLimit()),
+	}
+
+	page, err := s.services.Users.List(ctx, filter, req.GetCursor())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Error list users")
+	}
+
+	users := make([]*mprv1.UserSummary, 0, len(page.Users))
+	for i := range page.Users {
+		users = append(users, &mprv1.UserSummary{
+			User:        toUser(&page.Users[i].Users),
+			OpenReviews: page.Users[i].OpenReviews,
+		})
+	}
+
+	return &mprv1.ListUsersResponse{Users: users, NextCursor: page.NextCursor}, nil
+}
@@ -0,0 +1,37 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type Record struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// ScopedKey stores the client's key under its caller and route. The parts are
+// hashed so the result is always 64 characters, however long they are.
+func ScopedKey(client, route, key string) string {
+	sum := sha256.Sum256([]byte(client + ":" + route + ":" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Store persists idempotency records. Reserve either claims the key for the
+// caller (returning true) or returns the record already stored under it; an
+// expired record counts as absent. Sweep deletes the records expired by now.
+type Store interface {
+	Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error)
+	Complete(ctx context.Context, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, key string) error
+	Sweep(ctx context.Context, now time.Time) error
+}
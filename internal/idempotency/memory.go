@@ -0,0 +1,72 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Memory struct {
+	mu      sync.Mutex
+	records map[string]*Record
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		records: make(map[string]*Record),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Reserve(_ context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if existing, ok := m.records[key]; ok && !now.After(existing.ExpiresAt) {
+		record := *existing
+		return &record, false, nil
+	}
+
+	m.records[key] = &Record{
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	return nil, true, nil
+}
+
+func (m *Memory) Complete(_ context.Context, key string, statusCode int, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[key]; ok {
+		record.StatusCode = statusCode
+		record.Body = append([]byte(nil), body...)
+	}
+
+	return nil
+}
+
+func (m *Memory) Release(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, key)
+	return nil
+}
+
+func (m *Memory) Sweep(_ context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, record := range m.records {
+		if now.After(record.ExpiresAt) {
+			delete(m.records, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunSweeper deletes expired records from store every interval until ctx is
+// done. Deleting is idempotent, so every replica may run it.
+func RunSweeper(ctx context.Context, store Store, interval time.Duration, log *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := store.Sweep(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.Error("Error sweep expired idempotency keys", zap.Error(err))
+		}
+	}
+}
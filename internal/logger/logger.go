@@ -1,12 +1,15 @@
 package logger
 
 import (
+	"context"
 	"log"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"mPR/internal/config"
+	"mPR/internal/requestid"
 )
 
 func New(cfg config.Config) *zap.Logger {
@@ -42,3 +45,26 @@ func parse(level string) zapcore.Level {
 		return zapcore.InfoLevel
 	}
 }
+
+// WithContext returns the logger enriched with the request ID and the trace of
+// the current request so log lines can be correlated with client errors and spans.
+func WithContext(ctx context.Context, log *zap.Logger) *zap.Logger {
+	fields := make([]zap.Field, 0, 3)
+
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+
+	if len(fields) == 0 {
+		return log
+	}
+
+	return log.With(fields...)
+}
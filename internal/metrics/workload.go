@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const collectTimeout = 5 * time.Second
+
+type OpenByTeamCounter interface {
+	CountOpenByTeam(ctx context.Context) (map[string]int64, error)
+}
+
+type OpenByReviewerCounter interface {
+	CountOpenByReviewer(ctx context.Context) (map[string]int64, error)
+}
+
+// WorkloadCollector reads reviewer load from the database on every scrape, so
+// the gauges are correct across replicas without any in-process bookkeeping.
+type WorkloadCollector struct {
+	pullRequests OpenByTeamCounter
+	reviewers    OpenByReviewerCounter
+
+	openPRs     *prometheus.Desc
+	openReviews *prometheus.Desc
+}
+
+func NewWorkloadCollector(pullRequests OpenByTeamCounter, reviewers OpenByReviewerCounter) *WorkloadCollector {
+	return &WorkloadCollector{
+		pullRequests: pullRequests,
+		reviewers:    reviewers,
+		openPRs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Number of open pull requests per author team.",
+			[]string{"team"}, nil,
+		),
+		openReviews: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_reviews"),
+			"Number of open pull requests assigned to each reviewer.",
+			[]string{"user_id"}, nil,
+		),
+	}
+}
+
+func (c *WorkloadCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openPRs
+	ch <- c.openReviews
+}
+
+func (c *WorkloadCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	byTeam, err := c.pullRequests.CountOpenByTeam(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.openPRs, err)
+	} else {
+		for team, count := range byTeam {
+			ch <- prometheus.MustNewConstMetric(c.openPRs, prometheus.GaugeValue, float64(count), team)
+		}
+	}
+
+	byReviewer, err := c.reviewers.CountOpenByReviewer(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.openReviews, err)
+	} else {
+		for userID, count := range byReviewer {
+			ch <- prometheus.MustNewConstMetric(c.openReviews, prometheus.GaugeValue, float64(count), userID)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package pagination implements opaque cursors for keyset pagination.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"mPR/internal/custom"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// Limit applies the default page size and caps it at MaxLimit.
+func Limit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultLimit
+	case limit > MaxLimit:
+		return MaxLimit
+	default:
+		return limit
+	}
+}
+
+// Encode turns the position of the last returned row into a cursor string.
+func Encode(position any) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode reads a cursor produced by Encode into position.
+func Decode(cursor string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return custom.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, position); err != nil {
+		return custom.ErrInvalidCursor
+	}
+
+	return nil
+}
+
+// Trim cuts a result fetched with limit+1 rows down to limit and reports
+// whether there is another page.
+func Trim[T any](rows []T, limit int) ([]T, bool) {
+	if len(rows) > limit {
+		return rows[:limit], true
+	}
+	if rows == nil {
+		rows = []T{}
+	}
+
+	return rows, false
+}
@@ -0,0 +1,45 @@
+package pagination_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/pagination"
+)
+
+func TestLimit(t *testing.T) {
+	assert.Equal(t, pagination.DefaultLimit, pagination.Limit(0))
+	assert.Equal(t, 10, pagination.Limit(10))
+	assert.Equal(t, pagination.MaxLimit, pagination.Limit(10_000))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	type position struct {
+		ID   string `json:"id"`
+		Sort string `json:"sort"`
+	}
+
+	cursor, err := pagination.Encode(position{ID: "pr-1", Sort: "name"})
+	require.NoError(t, err)
+
+	var got position
+	require.NoError(t, pagination.Decode(cursor, &got))
+	assert.Equal(t, position{ID: "pr-1", Sort: "name"}, got)
+
+	assert.True(t, errors.Is(pagination.Decode("%%%", &got), custom.ErrInvalidCursor))
+	assert.True(t, errors.Is(pagination.Decode("bm90LWpzb24", &got), custom.ErrInvalidCursor))
+}
+
+func TestTrim(t *testing.T) {
+	rows, more := pagination.Trim([]int{1, 2, 3}, 2)
+	assert.Equal(t, []int{1, 2}, rows)
+	assert.True(t, more)
+
+	rows, more = pagination.Trim([]int(nil), 2)
+	assert.Equal(t, []int{}, rows)
+	assert.False(t, more)
+}
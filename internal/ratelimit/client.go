@@ -0,0 +1,20 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// ClientKey names the bucket a caller draws from. Only the admin token earns a
+// bucket of its own: any other token is ignored, so rotating it cannot reset
+// the limit, and the caller is keyed by its address instead. The token is
+// hashed so it never reaches the store.
+func ClientKey(token, adminToken, addr string) string {
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		sum := sha256.Sum256([]byte(token))
+		return "token:" + hex.EncodeToString(sum[:])
+	}
+
+	return "ip:" + addr
+}
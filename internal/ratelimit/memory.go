@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type entry struct {
+	bucket Bucket
+	fullAt time.Time
+}
+
+type Memory struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries:   make(map[string]*entry),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry{bucket: NewBucket(burst, now)}
+		m.entries[key] = e
+	}
+
+	allowed, retryAfter := e.bucket.Take(now, rate, burst)
+	e.fullAt = e.bucket.FullAt(rate, burst)
+
+	return allowed, retryAfter, nil
+}
+
+func (m *Memory) Sweep(_ context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dropFull(now)
+	return nil
+}
+
+// sweep runs dropFull at most once per sweepInterval, so memory stays
+// bounded by active clients even when nothing calls Sweep.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	m.dropFull(now)
+}
+
+func (m *Memory) dropFull(now time.Time) {
+	for key, e := range m.entries {
+		if now.After(e.fullAt) {
+			delete(m.entries, key)
+		}
+	}
+}
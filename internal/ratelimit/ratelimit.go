@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Store keeps token buckets by key. Sweep drops the buckets that have refilled
+// completely by now: a fresh bucket is indistinguishable from them.
+type Store interface {
+	Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error)
+	Sweep(ctx context.Context, now time.Time) error
+}
+
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+func NewBucket(burst int, now time.Time) Bucket {
+	return Bucket{
+		Tokens:    float64(burst),
+		UpdatedAt: now,
+	}
+}
+
+func (b *Bucket) Take(now time.Time, rate float64, burst int) (bool, time.Duration) {
+	elapsed := now.Sub(b.UpdatedAt).Seconds()
+	if elapsed > 0 {
+		b.Tokens = math.Min(float64(burst), b.Tokens+elapsed*rate)
+	}
+	b.UpdatedAt = now
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.Tokens) / rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// FullAt returns when the bucket is back to burst tokens at the given rate.
+func (b *Bucket) FullAt(rate float64, burst int) time.Time {
+	return b.UpdatedAt.Add(time.Duration((float64(burst) - b.Tokens) / rate * float64(time.Second)))
+}
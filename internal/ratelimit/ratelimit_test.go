@@ -0,0 +1,38 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mPR/internal/ratelimit"
+)
+
+func TestBucket_RefillsOverTime(t *testing.T) {
+	start := time.Now()
+	bucket := ratelimit.NewBucket(2, start)
+
+	allowed, _ := bucket.Take(start, 1, 2)
+	assert.True(t, allowed)
+	allowed, _ = bucket.Take(start, 1, 2)
+	assert.True(t, allowed)
+
+	allowed, retryAfter := bucket.Take(start, 1, 2)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _ = bucket.Take(start.Add(time.Second), 1, 2)
+	assert.True(t, allowed)
+}
+
+func TestBucket_CapsAtBurst(t *testing.T) {
+	start := time.Now()
+	bucket := ratelimit.NewBucket(1, start)
+
+	allowed, _ := bucket.Take(start.Add(time.Hour), 10, 1)
+	assert.True(t, allowed)
+
+	allowed, _ = bucket.Take(start.Add(time.Hour), 10, 1)
+	assert.False(t, allowed)
+}
@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunSweeper sweeps store every sweepInterval until ctx is done. Deleting
+// full buckets is idempotent, so every replica may run it.
+func RunSweeper(ctx context.Context, store Store, log *zap.Logger) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := store.Sweep(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.Error("Error sweep full rate limit buckets", zap.Error(err))
+		}
+	}
+}
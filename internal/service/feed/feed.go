@@ -0,0 +1,164 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"mPR/internal/events"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+const (
+	pageSize = 100
+	// pollInterval bounds the delay for events dispatched by other replicas,
+	// which never reach this replica's broker.
+	pollInterval = 2 * time.Second
+)
+
+// ErrClosed is returned by Subscription.Next once the service is closed.
+var ErrClosed = errors.New("event feed closed")
+
+// Filter narrows a subscription. Empty fields match everything.
+type Filter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f Filter) matches(event events.Event) bool {
+	if f.TeamName != "" && event.TeamName != f.TeamName {
+		return false
+	}
+	if f.UserID != "" && !slices.Contains(event.UserIDs(), f.UserID) {
+		return false
+	}
+	return true
+}
+
+type Service struct {
+	outbox    repository.Outbox
+	broker    *events.Broker
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func New(outbox repository.Outbox, broker *events.Broker) *Service {
+	return &Service{
+		outbox: outbox,
+		broker: broker,
+		closed: make(chan struct{}),
+	}
+}
+
+// Close ends every subscription. Streams never finish on their own, so the
+// server calls it on shutdown instead of waiting for clients to go away.
+func (s *Service) Close() {
+	s.closeOnce.Do(func() { close(s.closed) })
+}
+
+// Subscribe follows the event sequence after the given ID, or from the
+// newest event when after is nil. Events become visible once a dispatcher
+// has sequenced them, in the order they committed. The caller must Close the subscription.
+func (s *Service) Subscribe(ctx context.Context, filter Filter, after *int64) (*Subscription, error) {
+	sub := &Subscription{outbox: s.outbox, filter: filter, closed: s.closed}
+
+	// Listen before reading the head, so nothing committed in between is
+	// waited on for a whole poll interval.
+	sub.wake, sub.unsubscribe = s.broker.Subscribe()
+
+	if after != nil {
+		sub.after = *after
+		return sub, nil
+	}
+
+	last, err := s.outbox.LastSeq(ctx)
+	if err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("get last event ID: %w", err)
+	}
+	sub.after = last
+
+	return sub, nil
+}
+
+// Subscription reads the outbox past the last event it returned. The broker
+// only tells it when to look, so a resumed or slow subscriber gets exactly
+// what is stored.
+type Subscription struct {
+	outbox      repository.Outbox
+	filter      Filter
+	after       int64
+	wake        <-chan events.Event
+	unsubscribe func()
+	closed      <-chan struct{}
+}
+
+// Next returns the next matching events, waiting up to timeout for them. It
+// returns no events when the timeout passes first.
+func (sub *Subscription) Next(ctx context.Context, timeout time.Duration) ([]events.Event, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-sub.closed:
+			return nil, ErrClosed
+		default:
+		}
+
+		list, full, err := sub.read(ctx)
+		if err != nil || len(list) > 0 {
+			return list, err
+		}
+		if full {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-sub.closed:
+			return nil, ErrClosed
+		case <-deadline.C:
+			return nil, nil
+		case <-sub.wake:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// read returns the matching events of one page and whether the page was
+// full, advancing past every event it saw.
+func (sub *Subscription) read(ctx context.Context) ([]events.Event, bool, error) {
+	rows, err := sub.outbox.List(ctx, models.EventFilter{
+		After:    sub.after,
+		TeamName: sub.filter.TeamName,
+		Limit:    pageSize,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("list events: %w", err)
+	}
+
+	list := make([]events.Event, 0, len(rows))
+	for _, row := range rows {
+		sub.after = *row.Seq
+
+		event, err := events.FromOutbox(row)
+		if err != nil {
+			continue
+		}
+		if sub.filter.matches(event) {
+			list = append(list, event)
+		}
+	}
+
+	return list, len(rows) == pageSize, nil
+}
+
+func (sub *Subscription) Close() {
+	sub.unsubscribe()
+}
@@ -0,0 +1,116 @@
+package feed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/events"
+	"mPR/internal/service/feed"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/memory"
+)
+
+func types(list []events.Event) []events.Type {
+	out := make([]events.Type, 0, len(list))
+	for _, e := range list {
+		out = append(out, e.Type)
+	}
+	return out
+}
+
+func TestSubscribe_ResumesAfterID(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, events.Record(ctx, outbox,
+		events.PRCreated(pr, "backend"),
+		events.ReviewerAssigned(pr, "backend", "u2"),
+		events.PRMerged(pr, "backend"),
+	))
+
+	after := int64(1)
+	sub, err := feed.New(outbox, events.NewBroker(1)).Subscribe(ctx, feed.Filter{}, &after)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	list, err := sub.Next(ctx, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []events.Type{events.TypeReviewerAssigned, events.TypePRMerged}, types(list))
+	assert.Equal(t, int64(2), list[0].ID)
+
+	list, err = sub.Next(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestSubscribe_StartsAtHeadAndWakesOnBroker(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+	broker := events.NewBroker(1)
+
+	old := &models.PullRequests{ID: "pr-1", Name: "Old", AuthorID: "u1"}
+	require.NoError(t, events.Record(ctx, outbox, events.PRCreated(old, "backend")))
+
+	sub, err := feed.New(outbox, broker).Subscribe(ctx, feed.Filter{UserID: "u3"}, nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+
+		pr := &models.PullRequests{ID: "pr-2", Name: "New", AuthorID: "u1"}
+		assert.NoError(t, events.Record(ctx, outbox,
+			events.ReviewerAssigned(pr, "backend", "u2"),
+			events.ReviewerAssigned(pr, "backend", "u3"),
+		))
+		assert.NoError(t, broker.Deliver(ctx, events.Event{}))
+	}()
+
+	// The timeout is shorter than the poll interval, so only the broker can
+	// wake the subscription in time.
+	list, err := sub.Next(ctx, time.Second)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "u3", list[0].ReviewerID)
+}
+
+func TestSubscribe_FiltersByTeam(t *testing.T) {
+	ctx := context.Background()
+	outbox := memory.New(0).Outbox
+
+	backend := &models.PullRequests{ID: "pr-1", Name: "Backend", AuthorID: "u1"}
+	frontend := &models.PullRequests{ID: "pr-2", Name: "Frontend", AuthorID: "u4"}
+	require.NoError(t, events.Record(ctx, outbox,
+		events.PRCreated(backend, "backend"),
+		events.PRCreated(frontend, "frontend"),
+	))
+
+	after := int64(0)
+	sub, err := feed.New(outbox, events.NewBroker(1)).Subscribe(ctx, feed.Filter{TeamName: "frontend"}, &after)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	list, err := sub.Next(ctx, time.Second)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "pr-2", list[0].PullRequestID)
+}
+
+func TestClose_EndsSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	service := feed.New(memory.New(0).Outbox, events.NewBroker(1))
+
+	sub, err := service.Subscribe(ctx, feed.Filter{}, nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	go service.Close()
+
+	_, err = sub.Next(ctx, time.Minute)
+	assert.ErrorIs(t, err, feed.ErrClosed)
+}
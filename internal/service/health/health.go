@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"mPR/internal/storage/repository"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type Check struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Pool struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+}
+
+type Report struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks"`
+	Pool   Pool             `json:"pool"`
+}
+
+func (r *Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+type Service struct {
+	health          repository.Health
+	expectedVersion uint
+}
+
+func New(health repository.Health, expectedVersion uint) *Service {
+	return &Service{
+		health:          health,
+		expectedVersion: expectedVersion,
+	}
+}
+
+func (s *Service) Check(ctx context.Context) *Report {
+	report := &Report{
+		Status: StatusOK,
+		Checks: make(map[string]Check, 2),
+	}
+
+	if err := s.health.Ping(ctx); err != nil {
+		report.fail("database", err)
+		report.fail("migrations", fmt.Errorf("database unavailable"))
+	} else {
+		report.Checks["database"] = Check{Status: StatusOK}
+		report.Checks["migrations"] = s.checkMigrations(ctx)
+		if report.Checks["migrations"].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	stats := s.health.Stats()
+	report.Pool = Pool{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+	}
+
+	return report
+}
+
+func (s *Service) checkMigrations(ctx context.Context) Check {
+	version, dirty, err := s.health.SchemaVersion(ctx)
+	if err != nil {
+		return Check{Status: StatusFail, Error: fmt.Sprintf("read schema version: %v", err)}
+	}
+
+	if dirty {
+		return Check{Status: StatusFail, Error: fmt.Sprintf("schema version %d is dirty", version)}
+	}
+
+	if version != s.expectedVersion {
+		return Check{
+			Status: StatusFail,
+			Error:  fmt.Sprintf("schema version %d, expected %d", version, s.expectedVersion),
+		}
+	}
+
+	return Check{Status: StatusOK}
+}
+
+func (r *Report) fail(name string, err error) {
+	r.Status = StatusFail
+	r.Checks[name] = Check{Status: StatusFail, Error: err.Error()}
+}
@@ -0,0 +1,76 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mPR/internal/service/health"
+	"mPR/mocks"
+)
+
+func TestCheck_Ready(t *testing.T) {
+	mockHealth := mocks.NewMockHealth(t)
+	service := health.New(mockHealth, 4)
+
+	ctx := context.Background()
+	mockHealth.On("Ping", ctx).Return(nil)
+	mockHealth.On("SchemaVersion", ctx).Return(uint(4), false, nil)
+	mockHealth.On("Stats").Return(sql.DBStats{MaxOpenConnections: 10, OpenConnections: 3, InUse: 1, Idle: 2})
+
+	report := service.Check(ctx)
+
+	assert.True(t, report.Ready())
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusOK, report.Checks["migrations"].Status)
+	assert.Equal(t, 10, report.Pool.MaxOpenConnections)
+	assert.Equal(t, 1, report.Pool.InUse)
+}
+
+func TestCheck_DatabaseDown(t *testing.T) {
+	mockHealth := mocks.NewMockHealth(t)
+	service := health.New(mockHealth, 4)
+
+	ctx := context.Background()
+	mockHealth.On("Ping", ctx).Return(errors.New("connection refused"))
+	mockHealth.On("Stats").Return(sql.DBStats{})
+
+	report := service.Check(ctx)
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, health.StatusFail, report.Checks["database"].Status)
+	assert.Contains(t, report.Checks["database"].Error, "connection refused")
+}
+
+func TestCheck_DirtySchema(t *testing.T) {
+	mockHealth := mocks.NewMockHealth(t)
+	service := health.New(mockHealth, 4)
+
+	ctx := context.Background()
+	mockHealth.On("Ping", ctx).Return(nil)
+	mockHealth.On("SchemaVersion", ctx).Return(uint(4), true, nil)
+	mockHealth.On("Stats").Return(sql.DBStats{})
+
+	report := service.Check(ctx)
+
+	assert.False(t, report.Ready())
+	assert.Contains(t, report.Checks["migrations"].Error, "dirty")
+}
+
+func TestCheck_VersionMismatch(t *testing.T) {
+	mockHealth := mocks.NewMockHealth(t)
+	service := health.New(mockHealth, 4)
+
+	ctx := context.Background()
+	mockHealth.On("Ping", ctx).Return(nil)
+	mockHealth.On("SchemaVersion", ctx).Return(uint(3), false, nil)
+	mockHealth.On("Stats").Return(sql.DBStats{})
+
+	report := service.Check(ctx)
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, "schema version 3, expected 4", report.Checks["migrations"].Error)
+}
@@ -0,0 +1,93 @@
+package pull_requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/pagination"
+	"mPR/internal/storage/models"
+	"mPR/internal/tracing"
+)
+
+type Page struct {
+	PullRequests []models.PullRequests `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// cursor remembers the sort order it was issued for, so a client cannot mix
+// pages from different orderings.
+type cursor struct {
+	Sort      string    `json:"s"`
+	Desc      bool      `json:"d"`
+	ID        string    `json:"id"`
+	Name      string    `json:"n,omitempty"`
+	CreatedAt time.Time `json:"c,omitempty"`
+}
+
+func (s *Service) Get(ctx context.Context, prID string) (_ *models.PullRequests, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.Get")
+	defer func() { tracing.End(span, err) }()
+
+	pr, err := s.pullRequests.GetByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, custom.ErrNotFound) {
+			return nil, custom.ErrNotFound
+		}
+		return nil, fmt.Errorf("get pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+func (s *Service) List(ctx context.Context, filter models.PullRequestFilter, after string) (_ *Page, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.List")
+	defer func() { tracing.End(span, err) }()
+
+	if filter.Sort == "" {
+		filter.Sort = models.SortCreatedAt
+	}
+	filter.Limit = pagination.Limit(filter.Limit)
+
+	if after != "" {
+		var c cursor
+		if err := pagination.Decode(after, &c); err != nil {
+			return nil, err
+		}
+		if c.Sort != filter.Sort || c.Desc != filter.Desc {
+			return nil, custom.ErrInvalidCursor
+		}
+		filter.After = &models.PullRequests{ID: c.ID, Name: c.Name, CreatedAt: c.CreatedAt}
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	prs, err := s.pullRequests.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list pull requests: %w", err)
+	}
+
+	page := &Page{}
+
+	var more bool
+	page.PullRequests, more = pagination.Trim(prs, limit)
+	if more {
+		last := page.PullRequests[limit-1]
+
+		page.NextCursor, err = pagination.Encode(cursor{
+			Sort:      filter.Sort,
+			Desc:      filter.Desc,
+			ID:        last.ID,
+			Name:      last.Name,
+			CreatedAt: last.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
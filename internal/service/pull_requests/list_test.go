@@ -0,0 +1,74 @@
+package pull_requests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/pagination"
+	"mPR/internal/service/pull_requests"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func TestGet_NotFound(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+	service := pull_requests.New(mocks.NewMockTransactor(t), mockPR, mocks.NewMockUsers(t), mocks.NewMockReviewers(t), mocks.NewMockOutbox(t), 2)
+
+	ctx := context.Background()
+	mockPR.On("GetByID", ctx, "pr-404").Return(nil, custom.ErrNotFound)
+
+	_, err := service.Get(ctx, "pr-404")
+
+	assert.True(t, errors.Is(err, custom.ErrNotFound))
+}
+
+func TestList_Pagination(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+	service := pull_requests.New(mocks.NewMockTransactor(t), mockPR, mocks.NewMockUsers(t), mocks.NewMockReviewers(t), mocks.NewMockOutbox(t), 2)
+
+	ctx := context.Background()
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []models.PullRequests{
+		{ID: "pr-3", CreatedAt: created.Add(2 * time.Hour)},
+		{ID: "pr-2", CreatedAt: created.Add(time.Hour)},
+		{ID: "pr-1", CreatedAt: created},
+	}
+
+	mockPR.On("List", ctx, mock.MatchedBy(func(f models.PullRequestFilter) bool {
+		return f.After == nil && f.Limit == 3 && f.Status == custom.StatusOpen
+	})).Return(rows, nil)
+
+	page, err := service.List(ctx, models.PullRequestFilter{Status: custom.StatusOpen, Desc: true, Limit: 2}, "")
+
+	require.NoError(t, err)
+	assert.Len(t, page.PullRequests, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	mockPR.On("List", ctx, mock.MatchedBy(func(f models.PullRequestFilter) bool {
+		return f.After != nil && f.After.ID == "pr-2" && f.After.CreatedAt.Equal(rows[1].CreatedAt)
+	})).Return(rows[2:], nil)
+
+	page, err = service.List(ctx, models.PullRequestFilter{Status: custom.StatusOpen, Desc: true, Limit: 2}, page.NextCursor)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.PullRequests{rows[2]}, page.PullRequests)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestList_CursorForAnotherSort(t *testing.T) {
+	service := pull_requests.New(mocks.NewMockTransactor(t), mocks.NewMockPullRequests(t), mocks.NewMockUsers(t), mocks.NewMockReviewers(t), mocks.NewMockOutbox(t), 2)
+
+	cursor, err := pagination.Encode(map[string]any{"s": models.SortName, "d": true, "id": "pr-1"})
+	require.NoError(t, err)
+
+	_, err = service.List(context.Background(), models.PullRequestFilter{Desc: true}, cursor)
+
+	assert.True(t, errors.Is(err, custom.ErrInvalidCursor))
+}
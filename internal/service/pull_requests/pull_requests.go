@@ -7,42 +7,40 @@ import (
 	"math/rand"
 	"time"
 
-	"gorm.io/gorm"
-
 	"mPR/internal/custom"
+	"mPR/internal/events"
 	"mPR/internal/storage/models"
 	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
 )
 
 type Service struct {
+	tx           repository.Transactor
 	pullRequests repository.PullRequests
 	users        repository.Users
 	reviewers    repository.Reviewers
+	outbox       repository.Outbox
 	maxReviewers int
 }
 
-func New(pullRequests repository.PullRequests, users repository.Users, reviewers repository.Reviewers, maxReviewers int) *Service {
+func New(tx repository.Transactor, pullRequests repository.PullRequests, users repository.Users, reviewers repository.Reviewers, outbox repository.Outbox, maxReviewers int) *Service {
 	return &Service{
+		tx:           tx,
 		pullRequests: pullRequests,
 		users:        users,
 		reviewers:    reviewers,
+		outbox:       outbox,
 		maxReviewers: maxReviewers,
 	}
 }
 
-func (s *Service) Create(ctx context.Context, pr *models.PullRequests) (*models.PullRequests, error) {
-	exist, err := s.pullRequests.GetByID(ctx, pr.ID)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("check PR existence: %w", err)
-	}
-
-	if exist != nil {
-		return nil, custom.ErrPRExists
-	}
+func (s *Service) Create(ctx context.Context, pr *models.PullRequests) (_ *models.PullRequests, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.Create")
+	defer func() { tracing.End(span, err) }()
 
 	author, err := s.users.GetByID(ctx, pr.AuthorID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, custom.ErrNotFound) {
 			return nil, custom.ErrNotFound
 		}
 		return nil, fmt.Errorf("get author by ID: %w", err)
@@ -53,20 +51,37 @@ func (s *Service) Create(ctx context.Context, pr *models.PullRequests) (*models.
 		return nil, err
 	}
 
-	if err := s.pullRequests.Create(ctx, pr); err != nil {
-		return nil, fmt.Errorf("create pull request: %w", err)
-	}
-
 	for i := range selected {
 		selected[i].PRID = pr.ID
 	}
 
-	if err := s.reviewers.Add(ctx, selected); err != nil {
-		return nil, fmt.Errorf("add reviewers: %w", err)
-	}
+	// A duplicate ID fails the insert, so the PR never ends up with the
+	// reviewers of a request that lost the race.
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.pullRequests.Create(ctx, pr); err != nil {
+			if errors.Is(err, custom.ErrDuplicateKey) {
+				return custom.ErrPRExists
+			}
+			return fmt.Errorf("create pull request: %w", err)
+		}
 
-	pr.Author = *author
-	pr.Reviewers = selected
+		if err := s.reviewers.Add(ctx, selected); err != nil {
+			return fmt.Errorf("add reviewers: %w", err)
+		}
+
+		pr.Author = *author
+		pr.Reviewers = selected
+
+		created := []events.Event{events.PRCreated(pr, *author.TeamName)}
+		for _, r := range selected {
+			created = append(created, events.ReviewerAssigned(pr, *author.TeamName, r.ReviewerID))
+		}
+
+		return events.Record(ctx, s.outbox, created...)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return pr, nil
 }
@@ -111,107 +126,138 @@ func (s *Service) selectReviewers(ctx context.Context, author *models.Users) ([]
 	return result, nil
 }
 
-func (s *Service) Merge(ctx context.Context, prID string) (*models.PullRequests, error) {
-	pr, err := s.pullRequests.GetByID(ctx, prID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, custom.ErrNotFound
+func (s *Service) Merge(ctx context.Context, prID string) (_ *models.PullRequests, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.Merge")
+	defer func() { tracing.End(span, err) }()
+
+	// The status is checked under the row lock, so of two concurrent merges
+	// only the first records PR_MERGED.
+	var pr *models.PullRequests
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		locked, err := s.pullRequests.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, custom.ErrNotFound) {
+				return custom.ErrNotFound
+			}
+			return fmt.Errorf("get pull request for merge: %w", err)
 		}
-		return nil, fmt.Errorf("get pull request for merge: %w", err)
-	}
+		pr = locked
 
-	if pr.Status == custom.StatusMerged {
-		return pr, nil
-	}
+		if pr.Status == custom.StatusMerged {
+			return nil
+		}
 
-	pr.Status = custom.StatusMerged
-	now := time.Now()
-	pr.MergedAt = &now
+		pr.Status = custom.StatusMerged
+		now := time.Now()
+		pr.MergedAt = &now
+
+		if err := s.pullRequests.Update(ctx, pr); err != nil {
+			return fmt.Errorf("update pull request status: %w", err)
+		}
 
-	if err := s.pullRequests.Update(ctx, pr); err != nil {
-		return nil, fmt.Errorf("update pull request status: %w", err)
+		return events.Record(ctx, s.outbox, events.PRMerged(pr, teamOf(&pr.Author)))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return pr, nil
 }
 
-func (s *Service) Reassign(ctx context.Context, prID, oldID string) (*models.PullRequests, string, error) {
-	pr, err := s.pullRequests.GetByID(ctx, prID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", custom.ErrNotFound
+// Reassign replaces oldID on the PR with a random free member of their team.
+// The PR row is locked while the reviewers are read and changed, so
+// concurrent reassigns of one PR see each other's result.
+func (s *Service) Reassign(ctx context.Context, prID, oldID string) (_ *models.PullRequests, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.Reassign")
+	defer func() { tracing.End(span, err) }()
+
+	var newReviewer string
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		pr, err := s.pullRequests.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, custom.ErrNotFound) {
+				return custom.ErrNotFound
+			}
+			return fmt.Errorf("get pull request for reassign: %w", err)
 		}
-		return nil, "", fmt.Errorf("get pull request for reassign: %w", err)
-	}
 
-	if pr.Status == custom.StatusMerged {
-		return nil, "", custom.ErrPRMerged
-	}
+		if pr.Status == custom.StatusMerged {
+			return custom.ErrPRMerged
+		}
 
-	reviewers, err := s.reviewers.GetByPR(ctx, prID)
-	if err != nil {
-		return nil, "", fmt.Errorf("get reviewers by PR: %w", err)
-	}
+		reviewers, err := s.reviewers.GetByPR(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("get reviewers by PR: %w", err)
+		}
 
-	isAssigned := false
-	for _, r := range reviewers {
-		if r.ReviewerID == oldID {
-			isAssigned = true
-			break
+		var oldAssignedAt *time.Time
+		for _, r := range reviewers {
+			if r.ReviewerID == oldID {
+				oldAssignedAt = &r.AssignedAt
+				break
+			}
+		}
+		if oldAssignedAt == nil {
+			return custom.ErrNotAssigned
 		}
-	}
-	if !isAssigned {
-		return nil, "", custom.ErrNotAssigned
-	}
 
-	oldUser, err := s.users.GetByID(ctx, oldID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", custom.ErrNotFound
+		oldUser, err := s.users.GetByID(ctx, oldID)
+		if err != nil {
+			if errors.Is(err, custom.ErrNotFound) {
+				return custom.ErrNotFound
+			}
+			return fmt.Errorf("get old reviewer user: %w", err)
 		}
-		return nil, "", fmt.Errorf("get old reviewer user: %w", err)
-	}
 
-	if oldUser.TeamName == nil {
-		return nil, "", custom.ErrNoCandidate
-	}
+		if oldUser.TeamName == nil {
+			return custom.ErrNoCandidate
+		}
 
-	candidates, err := s.users.GetActiveByTeam(ctx, *oldUser.TeamName)
-	if err != nil {
-		return nil, "", fmt.Errorf("get active team members: %w", err)
-	}
+		candidates, err := s.users.GetActiveByTeam(ctx, *oldUser.TeamName)
+		if err != nil {
+			return fmt.Errorf("get active team members: %w", err)
+		}
 
-	used := map[string]struct{}{
-		oldID:       {},
-		pr.AuthorID: {},
-	}
+		used := map[string]struct{}{
+			oldID:       {},
+			pr.AuthorID: {},
+		}
 
-	for _, r := range reviewers {
-		used[r.ReviewerID] = struct{}{}
-	}
+		for _, r := range reviewers {
+			used[r.ReviewerID] = struct{}{}
+		}
 
-	free := make([]models.Users, 0, len(candidates))
-	for _, c := range candidates {
-		if _, banned := used[c.ID]; !banned {
-			free = append(free, c)
+		free := make([]models.Users, 0, len(candidates))
+		for _, c := range candidates {
+			if _, banned := used[c.ID]; !banned {
+				free = append(free, c)
+			}
 		}
-	}
 
-	if len(free) == 0 {
-		return nil, "", custom.ErrNoCandidate
-	}
+		if len(free) == 0 {
+			return custom.ErrNoCandidate
+		}
 
-	rand.Shuffle(len(free), func(i, j int) {
-		free[i], free[j] = free[j], free[i]
-	})
+		rand.Shuffle(len(free), func(i, j int) {
+			free[i], free[j] = free[j], free[i]
+		})
 
-	newReviewer := free[0].ID
+		newReviewer = free[0].ID
 
-	if err := s.reviewers.Delete(ctx, prID, oldID); err != nil {
-		return nil, "", fmt.Errorf("delete old reviewer: %w", err)
-	}
-	if err := s.reviewers.AddOne(ctx, prID, newReviewer); err != nil {
-		return nil, "", fmt.Errorf("add new reviewer: %w", err)
+		if err := s.reviewers.Delete(ctx, prID, oldID); err != nil {
+			return fmt.Errorf("delete old reviewer: %w", err)
+		}
+		if err := s.reviewers.AddOne(ctx, prID, newReviewer); err != nil {
+			return fmt.Errorf("add new reviewer: %w", err)
+		}
+		if err := s.reviewers.LogReassignment(ctx, prID, oldID, newReviewer, *oldAssignedAt); err != nil {
+			return fmt.Errorf("log reassignment: %w", err)
+		}
+
+		return events.Record(ctx, s.outbox, events.ReviewerReassigned(pr, *oldUser.TeamName, oldID, newReviewer))
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
 	updatedPR, err := s.pullRequests.GetByID(ctx, prID)
@@ -221,3 +267,10 @@ func (s *Service) Reassign(ctx context.Context, prID, oldID string) (*models.Pul
 
 	return updatedPR, newReviewer, nil
 }
+
+func teamOf(user *models.Users) string {
+	if user.TeamName == nil {
+		return ""
+	}
+	return *user.TeamName
+}
@@ -3,12 +3,12 @@ package pull_requests_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 
 	"mPR/internal/custom"
 	"mPR/internal/service/pull_requests"
@@ -21,7 +21,7 @@ func TestCreate_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "PR_CREATED", "REVIEWER_ASSIGNED", "REVIEWER_ASSIGNED"), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -50,7 +50,6 @@ func TestCreate_Success(t *testing.T) {
 		{ID: reviewer2ID, Username: "reviewer2", IsActive: true, TeamName: &teamName},
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(nil, gorm.ErrRecordNotFound)
 	mockUsers.On("GetByID", ctx, authorID).Return(author, nil)
 	mockUsers.On("GetActiveByTeam", ctx, teamName).Return(activeUsers, nil)
 	mockPR.On("Create", ctx, pr).Return(nil)
@@ -68,26 +67,20 @@ func TestCreate_PRExists(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
-	prID := "pr1"
-	authorID := "u1"
-
-	pr := &models.PullRequests{
-		ID:       prID,
-		Name:     "Test PR",
-		AuthorID: authorID,
-		Status:   custom.StatusOpen,
-	}
+	teamName := "team1"
+	pr := &models.PullRequests{ID: "pr1", Name: "Test PR", AuthorID: "u1", Status: custom.StatusOpen}
+	author := &models.Users{ID: "u1", TeamName: &teamName, IsActive: true}
 
-	existingPR := &models.PullRequests{ID: prID}
-	mockPR.On("GetByID", ctx, prID).Return(existingPR, nil)
+	mockUsers.On("GetByID", ctx, "u1").Return(author, nil)
+	mockUsers.On("GetActiveByTeam", ctx, teamName).Return([]models.Users{*author}, nil)
+	mockPR.On("Create", ctx, pr).Return(fmt.Errorf("%w: duplicated key not allowed", custom.ErrDuplicateKey))
 
 	result, err := service.Create(ctx, pr)
 
-	assert.Error(t, err)
-	assert.True(t, errors.Is(err, custom.ErrPRExists))
+	assert.ErrorIs(t, err, custom.ErrPRExists)
 	assert.Nil(t, result)
 }
 
@@ -96,7 +89,7 @@ func TestCreate_AuthorNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(mocks.NewMockTransactor(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -109,8 +102,7 @@ func TestCreate_AuthorNotFound(t *testing.T) {
 		Status:   custom.StatusOpen,
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(nil, gorm.ErrRecordNotFound)
-	mockUsers.On("GetByID", ctx, authorID).Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.On("GetByID", ctx, authorID).Return(nil, custom.ErrNotFound)
 
 	result, err := service.Create(ctx, pr)
 
@@ -124,7 +116,7 @@ func TestMerge_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "PR_MERGED"), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -135,7 +127,7 @@ func TestMerge_Success(t *testing.T) {
 		Status: custom.StatusOpen,
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 	mockPR.On("Update", ctx, mock.MatchedBy(func(p *models.PullRequests) bool {
 		return p.ID == prID && p.Status == custom.StatusMerged && p.MergedAt != nil
 	})).Return(nil)
@@ -153,7 +145,7 @@ func TestMerge_AlreadyMerged(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -166,7 +158,7 @@ func TestMerge_AlreadyMerged(t *testing.T) {
 		MergedAt: &mergedAt,
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 
 	result, err := service.Merge(ctx, prID)
 
@@ -180,12 +172,12 @@ func TestMerge_PRNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
 
-	mockPR.On("GetByID", ctx, prID).Return(nil, gorm.ErrRecordNotFound)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(nil, custom.ErrNotFound)
 
 	result, err := service.Merge(ctx, prID)
 
@@ -199,7 +191,7 @@ func TestReassign_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, expectEvents(t, "REVIEWER_REASSIGNED"), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -222,8 +214,9 @@ func TestReassign_Success(t *testing.T) {
 		IsActive: true,
 	}
 
+	assignedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
 	reviewers := []models.Reviewers{
-		{ReviewerID: oldReviewerID, PRID: prID},
+		{ReviewerID: oldReviewerID, PRID: prID, AssignedAt: assignedAt},
 	}
 
 	activeUsers := []models.Users{
@@ -232,13 +225,14 @@ func TestReassign_Success(t *testing.T) {
 		{ID: newReviewerID, Username: "new_reviewer", IsActive: true, TeamName: &teamName},
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil).Once()
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 	mockReviewers.On("GetByPR", ctx, prID).Return(reviewers, nil)
 	mockUsers.On("GetByID", ctx, oldReviewerID).Return(oldReviewer, nil)
 	mockUsers.On("GetActiveByTeam", ctx, teamName).Return(activeUsers, nil)
 	mockReviewers.On("Delete", ctx, prID, oldReviewerID).Return(nil)
 	mockReviewers.On("AddOne", ctx, prID, mock.AnythingOfType("string")).Return(nil)
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil).Once()
+	mockReviewers.On("LogReassignment", ctx, prID, oldReviewerID, mock.AnythingOfType("string"), assignedAt).Return(nil)
+	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
 
 	result, replacedBy, err := service.Reassign(ctx, prID, oldReviewerID)
 
@@ -252,7 +246,7 @@ func TestReassign_PRMerged(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -264,7 +258,7 @@ func TestReassign_PRMerged(t *testing.T) {
 		Status: custom.StatusMerged,
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 
 	result, replacedBy, err := service.Reassign(ctx, prID, oldReviewerID)
 
@@ -279,7 +273,7 @@ func TestReassign_NotAssigned(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -296,7 +290,7 @@ func TestReassign_NotAssigned(t *testing.T) {
 		{ReviewerID: otherReviewerID, PRID: prID},
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 	mockReviewers.On("GetByPR", ctx, prID).Return(reviewers, nil)
 
 	result, replacedBy, err := service.Reassign(ctx, prID, oldReviewerID)
@@ -312,7 +306,7 @@ func TestReassign_NoCandidate(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := pull_requests.New(mockPR, mockUsers, mockReviewers, 2)
+	service := pull_requests.New(passthroughTx(t), mockPR, mockUsers, mockReviewers, mocks.NewMockOutbox(t), 2)
 
 	ctx := context.Background()
 	prID := "pr1"
@@ -343,7 +337,7 @@ func TestReassign_NoCandidate(t *testing.T) {
 		{ID: oldReviewerID, Username: "old_reviewer", IsActive: true, TeamName: &teamName},
 	}
 
-	mockPR.On("GetByID", ctx, prID).Return(pr, nil)
+	mockPR.On("GetByIDForUpdate", ctx, prID).Return(pr, nil)
 	mockReviewers.On("GetByPR", ctx, prID).Return(reviewers, nil)
 	mockUsers.On("GetByID", ctx, oldReviewerID).Return(oldReviewer, nil)
 	mockUsers.On("GetActiveByTeam", ctx, teamName).Return(activeUsers, nil)
@@ -355,3 +349,70 @@ func TestReassign_NoCandidate(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, "", replacedBy)
 }
+
+func TestReview_Success(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+	mockReviewers := mocks.NewMockReviewers(t)
+	service := pull_requests.New(passthroughTx(t), mockPR, mocks.NewMockUsers(t), mockReviewers, mocks.NewMockOutbox(t), 2)
+
+	ctx := context.Background()
+	mockPR.On("GetByIDForUpdate", ctx, "pr1").Return(&models.PullRequests{ID: "pr1", Status: custom.StatusOpen}, nil)
+	mockReviewers.On("GetByPR", ctx, "pr1").Return([]models.Reviewers{{PRID: "pr1", ReviewerID: "u2"}}, nil)
+	mockReviewers.On("AddReview", ctx, &models.Reviews{PRID: "pr1", ReviewerID: "u2", Decision: custom.DecisionApproved}).Return(nil)
+
+	review, err := service.Review(ctx, "pr1", "u2", custom.DecisionApproved)
+
+	assert.NoError(t, err)
+	assert.Equal(t, custom.DecisionApproved, review.Decision)
+}
+
+func TestReview_NotAssigned(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+	mockReviewers := mocks.NewMockReviewers(t)
+	service := pull_requests.New(passthroughTx(t), mockPR, mocks.NewMockUsers(t), mockReviewers, mocks.NewMockOutbox(t), 2)
+
+	ctx := context.Background()
+	mockPR.On("GetByIDForUpdate", ctx, "pr1").Return(&models.PullRequests{ID: "pr1", Status: custom.StatusOpen}, nil)
+	mockReviewers.On("GetByPR", ctx, "pr1").Return([]models.Reviewers{{PRID: "pr1", ReviewerID: "u2"}}, nil)
+
+	_, err := service.Review(ctx, "pr1", "u3", custom.DecisionApproved)
+
+	assert.True(t, errors.Is(err, custom.ErrNotAssigned))
+}
+
+func TestReview_Merged(t *testing.T) {
+	mockPR := mocks.NewMockPullRequests(t)
+	service := pull_requests.New(passthroughTx(t), mockPR, mocks.NewMockUsers(t), mocks.NewMockReviewers(t), mocks.NewMockOutbox(t), 2)
+
+	ctx := context.Background()
+	mockPR.On("GetByIDForUpdate", ctx, "pr1").Return(&models.PullRequests{ID: "pr1", Status: custom.StatusMerged}, nil)
+
+	_, err := service.Review(ctx, "pr1", "u2", custom.DecisionApproved)
+
+	assert.True(t, errors.Is(err, custom.ErrPRMerged))
+}
+
+// expectEvents expects one outbox write holding events of the given types.
+func expectEvents(t *testing.T, types ...string) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(t)
+	outbox.EXPECT().Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, rows []models.Outbox) error {
+			recorded := make([]string, 0, len(rows))
+			for _, row := range rows {
+				recorded = append(recorded, row.Type)
+			}
+			assert.Equal(t, types, recorded)
+			return nil
+		}).Once()
+
+	return outbox
+}
+
+// passthroughTx runs the transaction body directly, as if it committed.
+func passthroughTx(t *testing.T) *mocks.MockTransactor {
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	return tx
+}
@@ -0,0 +1,63 @@
+package pull_requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/tracing"
+)
+
+// Review records a reviewer's decision on an open PR they are assigned to.
+func (s *Service) Review(ctx context.Context, prID, reviewerID, decision string) (_ *models.Reviews, err error) {
+	ctx, span := tracing.Start(ctx, "pull_requests.Review")
+	defer func() { tracing.End(span, err) }()
+
+	// The PR row lock orders the review against Merge and Reassign, so a
+	// decision is never recorded on a PR merged or a reviewer replaced after
+	// it was checked.
+	var review *models.Reviews
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		pr, err := s.pullRequests.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			if errors.Is(err, custom.ErrNotFound) {
+				return custom.ErrNotFound
+			}
+			return fmt.Errorf("get pull request for review: %w", err)
+		}
+
+		if pr.Status == custom.StatusMerged {
+			return custom.ErrPRMerged
+		}
+
+		reviewers, err := s.reviewers.GetByPR(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("get reviewers by PR: %w", err)
+		}
+
+		isAssigned := false
+		for _, r := range reviewers {
+			if r.ReviewerID == reviewerID {
+				isAssigned = true
+				break
+			}
+		}
+		if !isAssigned {
+			return custom.ErrNotAssigned
+		}
+
+		review = &models.Reviews{PRID: prID, ReviewerID: reviewerID, Decision: decision}
+		if err := s.reviewers.AddReview(ctx, review); err != nil {
+			return fmt.Errorf("add review: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
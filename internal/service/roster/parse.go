@@ -0,0 +1,180 @@
+package roster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"mPR/internal/custom"
+)
+
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type Roster struct {
+	Teams []Team `json:"teams" yaml:"teams"`
+}
+
+type Team struct {
+	Name    string   `json:"name" yaml:"name"`
+	Members []Member `json:"members" yaml:"members"`
+}
+
+type Member struct {
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive *bool  `json:"is_active,omitempty" yaml:"is_active,omitempty"`
+}
+
+func (m Member) Active() bool {
+	return m.IsActive == nil || *m.IsActive
+}
+
+func Parse(format string, r io.Reader) (*Roster, error) {
+	var (
+		roster *Roster
+		err    error
+	)
+
+	switch format {
+	case FormatYAML:
+		roster, err = decode(r, func(data []byte, v any) error { return yaml.Unmarshal(data, v) })
+	case FormatJSON:
+		roster, err = decode(r, json.Unmarshal)
+	case FormatCSV:
+		roster, err = parseCSV(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", custom.ErrInvalidRoster, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := roster.validate(); err != nil {
+		return nil, err
+	}
+
+	return roster, nil
+}
+
+func decode(r io.Reader, unmarshal func([]byte, any) error) (*Roster, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read roster: %w", err)
+	}
+
+	var roster Roster
+	if err := unmarshal(data, &roster); err != nil {
+		return nil, fmt.Errorf("%w: %v", custom.ErrInvalidRoster, err)
+	}
+
+	return &roster, nil
+}
+
+// parseCSV reads rows of team_name,user_id,username[,is_active]; the header
+// row is required so columns can be given in any order.
+func parseCSV(r io.Reader) (*Roster, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: read CSV header: %v", custom.ErrInvalidRoster, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"team_name", "user_id", "username"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV header must contain %s", custom.ErrInvalidRoster, required)
+		}
+	}
+
+	roster := &Roster{}
+	teams := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", custom.ErrInvalidRoster, err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		member := Member{UserID: field("user_id"), Username: field("username")}
+		if value := field("is_active"); value != "" {
+			active, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: invalid is_active %q", custom.ErrInvalidRoster, line, value)
+			}
+			member.IsActive = &active
+		}
+
+		name := field("team_name")
+		idx, ok := teams[name]
+		if !ok {
+			idx = len(roster.Teams)
+			teams[name] = idx
+			roster.Teams = append(roster.Teams, Team{Name: name})
+		}
+
+		// A row with an empty user_id declares a team without members.
+		if member.UserID == "" && member.Username == "" {
+			continue
+		}
+		roster.Teams[idx].Members = append(roster.Teams[idx].Members, member)
+	}
+
+	return roster, nil
+}
+
+func (r *Roster) validate() error {
+	teams := make(map[string]struct{}, len(r.Teams))
+	users := make(map[string]string)
+
+	for _, team := range r.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("%w: team name is required", custom.ErrInvalidRoster)
+		}
+		if _, dup := teams[team.Name]; dup {
+			return fmt.Errorf("%w: team %q is listed twice", custom.ErrInvalidRoster, team.Name)
+		}
+		teams[team.Name] = struct{}{}
+
+		for _, m := range team.Members {
+			if m.UserID == "" {
+				return fmt.Errorf("%w: team %q has a member without user_id", custom.ErrInvalidRoster, team.Name)
+			}
+			if m.Username == "" {
+				return fmt.Errorf("%w: user %q has no username", custom.ErrInvalidRoster, m.UserID)
+			}
+			if other, dup := users[m.UserID]; dup {
+				return fmt.Errorf("%w: user %q is listed in teams %q and %q", custom.ErrInvalidRoster, m.UserID, other, team.Name)
+			}
+			users[m.UserID] = team.Name
+		}
+	}
+
+	return nil
+}
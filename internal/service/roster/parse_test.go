@@ -0,0 +1,73 @@
+package roster_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/service/roster"
+)
+
+func TestParse_YAML(t *testing.T) {
+	input := `
+teams:
+  - name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+  - name: frontend
+`
+	r, err := roster.Parse(roster.FormatYAML, strings.NewReader(input))
+
+	require.NoError(t, err)
+	require.Len(t, r.Teams, 2)
+	assert.Equal(t, "backend", r.Teams[0].Name)
+	assert.True(t, r.Teams[0].Members[0].Active())
+	assert.False(t, r.Teams[0].Members[1].Active())
+	assert.Empty(t, r.Teams[1].Members)
+}
+
+func TestParse_CSV(t *testing.T) {
+	input := "user_id,team_name,username,is_active\n" +
+		"u1,backend,Alice,true\n" +
+		"u2,backend,Bob,false\n" +
+		"u3,frontend,Charlie,\n"
+
+	r, err := roster.Parse(roster.FormatCSV, strings.NewReader(input))
+
+	require.NoError(t, err)
+	require.Len(t, r.Teams, 2)
+	assert.Len(t, r.Teams[0].Members, 2)
+	assert.False(t, r.Teams[0].Members[1].Active())
+	assert.Equal(t, "Charlie", r.Teams[1].Members[0].Username)
+	assert.True(t, r.Teams[1].Members[0].Active())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{"Duplicate user", roster.FormatYAML, "teams:\n- name: a\n  members: [{user_id: u1, username: A}]\n- name: b\n  members: [{user_id: u1, username: A}]\n"},
+		{"Duplicate team", roster.FormatYAML, "teams:\n- name: a\n- name: a\n"},
+		{"Missing username", roster.FormatJSON, `{"teams":[{"name":"a","members":[{"user_id":"u1"}]}]}`},
+		{"Missing CSV column", roster.FormatCSV, "team_name,user_id\na,u1\n"},
+		{"Bad is_active", roster.FormatCSV, "team_name,user_id,username,is_active\na,u1,A,maybe\n"},
+		{"Unknown format", "xml", "<teams/>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := roster.Parse(tc.format, strings.NewReader(tc.input))
+			assert.True(t, errors.Is(err, custom.ErrInvalidRoster), "got %v", err)
+		})
+	}
+}
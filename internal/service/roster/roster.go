@@ -0,0 +1,260 @@
+package roster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"mPR/internal/custom"
+	"mPR/internal/events"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
+)
+
+const (
+	ActionCreateTeam     = "create_team"
+	ActionCreateUser     = "create_user"
+	ActionUpdateUser     = "update_user"
+	ActionMoveUser       = "move_user"
+	ActionDeactivateUser = "deactivate_user"
+)
+
+type Options struct {
+	DryRun bool
+	Prune  bool
+}
+
+type Change struct {
+	Action   string   `json:"action"`
+	TeamName string   `json:"team_name,omitempty"`
+	UserID   string   `json:"user_id,omitempty"`
+	FromTeam string   `json:"from_team,omitempty"`
+	Fields   []string `json:"fields,omitempty"`
+}
+
+type Plan struct {
+	DryRun  bool           `json:"dry_run"`
+	Prune   bool           `json:"prune"`
+	Changes []Change       `json:"changes"`
+	Summary map[string]int `json:"summary"`
+}
+
+type Service struct {
+	tx     repository.Transactor
+	teams  repository.Teams
+	users  repository.Users
+	outbox repository.Outbox
+}
+
+func New(tx repository.Transactor, teams repository.Teams, users repository.Users, outbox repository.Outbox) *Service {
+	return &Service{
+		tx:     tx,
+		teams:  teams,
+		users:  users,
+		outbox: outbox,
+	}
+}
+
+// Sync brings teams and users in line with the roster. Users missing from the
+// roster are left untouched unless Prune is set, in which case they are deactivated.
+// A team created concurrently with the sync makes it fail with custom.ErrTeamExists.
+func (s *Service) Sync(ctx context.Context, roster *Roster, opts Options) (_ *Plan, err error) {
+	ctx, span := tracing.Start(ctx, "roster.Sync")
+	defer func() { tracing.End(span, err) }()
+
+	if opts.DryRun {
+		plan, _, _, err := s.plan(ctx, roster, opts)
+		return plan, err
+	}
+
+	// The plan is read in the transaction that applies it, so it is not
+	// built from a state other writers have already changed.
+	var plan *Plan
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		p, upserts, deactivations, err := s.plan(ctx, roster, opts)
+		if err != nil {
+			return err
+		}
+		if err := s.apply(ctx, roster, p, upserts, deactivations); err != nil {
+			return err
+		}
+
+		plan = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *Service) plan(ctx context.Context, roster *Roster, opts Options) (*Plan, map[string][]models.Users, []string, error) {
+	existingTeams, err := s.teams.GetAll(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get teams: %w", err)
+	}
+
+	existingUsers, err := s.users.GetAll(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get users: %w", err)
+	}
+
+	plan, upserts, deactivations := diff(roster, existingTeams, existingUsers, opts)
+	return plan, upserts, deactivations, nil
+}
+
+func (s *Service) apply(ctx context.Context, roster *Roster, plan *Plan, upserts map[string][]models.Users, deactivations []string) error {
+	for _, change := range plan.Changes {
+		if change.Action != ActionCreateTeam {
+			continue
+		}
+		if err := s.teams.Create(ctx, &models.Teams{Name: change.TeamName}); err != nil {
+			if errors.Is(err, custom.ErrDuplicateKey) {
+				return custom.ErrTeamExists
+			}
+			return fmt.Errorf("create team %s: %w", change.TeamName, err)
+		}
+	}
+
+	for _, team := range roster.Teams {
+		members := upserts[team.Name]
+		if len(members) == 0 {
+			continue
+		}
+		if err := s.users.CreateOrUpdate(ctx, team.Name, members); err != nil {
+			return fmt.Errorf("update members of team %s: %w", team.Name, err)
+		}
+	}
+
+	for _, userID := range deactivations {
+		if err := s.users.UpdateIsActive(ctx, userID, false); err != nil {
+			return fmt.Errorf("deactivate user %s: %w", userID, err)
+		}
+	}
+
+	return events.Record(ctx, s.outbox, planEvents(plan, upserts)...)
+}
+
+// planEvents describes an applied plan: created teams with the members they
+// got, and every user whose activity flipped.
+func planEvents(plan *Plan, upserts map[string][]models.Users) []events.Event {
+	desired := make(map[string]models.Users)
+	for _, members := range upserts {
+		for _, m := range members {
+			desired[m.ID] = m
+		}
+	}
+
+	list := make([]events.Event, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		switch {
+		case change.Action == ActionCreateTeam:
+			list = append(list, events.TeamCreated(change.TeamName, upserts[change.TeamName]))
+		case change.Action == ActionDeactivateUser:
+			list = append(list, events.UserActivityChanged(&models.Users{ID: change.UserID, TeamName: teamName(change.TeamName)}))
+		case slices.Contains(change.Fields, "is_active"):
+			user := desired[change.UserID]
+			user.TeamName = &change.TeamName
+			list = append(list, events.UserActivityChanged(&user))
+		}
+	}
+
+	return list
+}
+
+func teamName(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
+
+func diff(roster *Roster, teams []models.Teams, users []models.Users, opts Options) (*Plan, map[string][]models.Users, []string) {
+	plan := &Plan{
+		DryRun:  opts.DryRun,
+		Prune:   opts.Prune,
+		Changes: make([]Change, 0),
+		Summary: make(map[string]int),
+	}
+	upserts := make(map[string][]models.Users)
+	deactivations := make([]string, 0)
+
+	knownTeams := make(map[string]struct{}, len(teams))
+	for _, t := range teams {
+		knownTeams[t.Name] = struct{}{}
+	}
+
+	knownUsers := make(map[string]models.Users, len(users))
+	for _, u := range users {
+		knownUsers[u.ID] = u
+	}
+
+	listed := make(map[string]struct{})
+
+	for _, team := range roster.Teams {
+		if _, ok := knownTeams[team.Name]; !ok {
+			plan.add(Change{Action: ActionCreateTeam, TeamName: team.Name})
+		}
+
+		for _, m := range team.Members {
+			listed[m.UserID] = struct{}{}
+			desired := models.Users{ID: m.UserID, Username: m.Username, IsActive: m.Active()}
+
+			current, exists := knownUsers[m.UserID]
+			if !exists {
+				plan.add(Change{Action: ActionCreateUser, TeamName: team.Name, UserID: m.UserID})
+				upserts[team.Name] = append(upserts[team.Name], desired)
+				continue
+			}
+
+			var fields []string
+			if current.Username != desired.Username {
+				fields = append(fields, "username")
+			}
+			if current.IsActive != desired.IsActive {
+				fields = append(fields, "is_active")
+			}
+
+			currentTeam := ""
+			if current.TeamName != nil {
+				currentTeam = *current.TeamName
+			}
+
+			switch {
+			case currentTeam != team.Name:
+				plan.add(Change{Action: ActionMoveUser, TeamName: team.Name, UserID: m.UserID, FromTeam: currentTeam, Fields: fields})
+			case len(fields) > 0:
+				plan.add(Change{Action: ActionUpdateUser, TeamName: team.Name, UserID: m.UserID, Fields: fields})
+			default:
+				continue
+			}
+
+			upserts[team.Name] = append(upserts[team.Name], desired)
+		}
+	}
+
+	if opts.Prune {
+		for _, u := range users {
+			if _, ok := listed[u.ID]; ok || !u.IsActive {
+				continue
+			}
+
+			teamName := ""
+			if u.TeamName != nil {
+				teamName = *u.TeamName
+			}
+			plan.add(Change{Action: ActionDeactivateUser, TeamName: teamName, UserID: u.ID})
+			deactivations = append(deactivations, u.ID)
+		}
+	}
+
+	return plan, upserts, deactivations
+}
+
+func (p *Plan) add(change Change) {
+	p.Changes = append(p.Changes, change)
+	p.Summary[change.Action]++
+}
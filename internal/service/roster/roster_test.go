@@ -0,0 +1,154 @@
+package roster_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/events"
+	"mPR/internal/service/roster"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func active(v bool) *bool {
+	return &v
+}
+
+func existingState(mockTeams *mocks.MockTeams, mockUsers *mocks.MockUsers, ctx context.Context) {
+	mockTeams.On("GetAll", ctx).Return([]models.Teams{{Name: "backend"}, {Name: "frontend"}}, nil)
+	mockUsers.On("GetAll", ctx).Return([]models.Users{
+		{ID: "u1", Username: "Alice", TeamName: ptr("backend"), IsActive: true},
+		{ID: "u2", Username: "Bob", TeamName: ptr("backend"), IsActive: true},
+		{ID: "u3", Username: "Charlie", TeamName: ptr("frontend"), IsActive: true},
+		{ID: "u4", Username: "Dave", TeamName: ptr("frontend"), IsActive: true},
+	}, nil)
+}
+
+func desiredRoster() *roster.Roster {
+	return &roster.Roster{Teams: []roster.Team{
+		{Name: "backend", Members: []roster.Member{
+			{UserID: "u1", Username: "Alice"},
+			{UserID: "u2", Username: "Robert", IsActive: active(false)},
+			{UserID: "u3", Username: "Charlie"},
+		}},
+		{Name: "platform", Members: []roster.Member{
+			{UserID: "u5", Username: "Eve"},
+		}},
+	}}
+}
+
+func TestSync_DryRunDoesNotWrite(t *testing.T) {
+	mockTeams := mocks.NewMockTeams(t)
+	mockUsers := mocks.NewMockUsers(t)
+	service := roster.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	existingState(mockTeams, mockUsers, ctx)
+
+	plan, err := service.Sync(ctx, desiredRoster(), roster.Options{DryRun: true, Prune: true})
+
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.Equal(t, []roster.Change{
+		{Action: roster.ActionUpdateUser, TeamName: "backend", UserID: "u2", Fields: []string{"username", "is_active"}},
+		{Action: roster.ActionMoveUser, TeamName: "backend", UserID: "u3", FromTeam: "frontend"},
+		{Action: roster.ActionCreateTeam, TeamName: "platform"},
+		{Action: roster.ActionCreateUser, TeamName: "platform", UserID: "u5"},
+		{Action: roster.ActionDeactivateUser, TeamName: "frontend", UserID: "u4"},
+	}, plan.Changes)
+	assert.Equal(t, 1, plan.Summary[roster.ActionDeactivateUser])
+}
+
+func TestSync_Apply(t *testing.T) {
+	mockTeams := mocks.NewMockTeams(t)
+	mockUsers := mocks.NewMockUsers(t)
+	mockOutbox := mocks.NewMockOutbox(t)
+	service := roster.New(passthroughTx(t), mockTeams, mockUsers, mockOutbox)
+
+	ctx := context.Background()
+	existingState(mockTeams, mockUsers, ctx)
+
+	var recorded []models.Outbox
+	mockOutbox.EXPECT().Add(ctx, mock.Anything).RunAndReturn(func(_ context.Context, rows []models.Outbox) error {
+		recorded = rows
+		return nil
+	})
+	mockTeams.On("Create", ctx, &models.Teams{Name: "platform"}).Return(nil)
+	mockUsers.On("CreateOrUpdate", ctx, "backend", []models.Users{
+		{ID: "u2", Username: "Robert", IsActive: false},
+		{ID: "u3", Username: "Charlie", IsActive: true},
+	}).Return(nil)
+	mockUsers.On("CreateOrUpdate", ctx, "platform", []models.Users{
+		{ID: "u5", Username: "Eve", IsActive: true},
+	}).Return(nil)
+	mockUsers.On("UpdateIsActive", ctx, "u4", false).Return(nil)
+
+	plan, err := service.Sync(ctx, desiredRoster(), roster.Options{Prune: true})
+
+	require.NoError(t, err)
+	assert.Len(t, plan.Changes, 5)
+
+	types := make([]string, 0, len(recorded))
+	for _, row := range recorded {
+		types = append(types, row.Type)
+	}
+	assert.Equal(t, []string{"USER_ACTIVITY_CHANGED", "TEAM_CREATED", "USER_ACTIVITY_CHANGED"}, types)
+	assert.JSONEq(t, `{"id":0,"type":"USER_ACTIVITY_CHANGED","occurred_at":"`+occurredAt(t, recorded[0])+`",`+
+		`"team_name":"backend","user_id":"u2","is_active":false}`, recorded[0].Payload)
+}
+
+func TestSync_WithoutPruneKeepsMissingUsers(t *testing.T) {
+	mockTeams := mocks.NewMockTeams(t)
+	mockUsers := mocks.NewMockUsers(t)
+	service := roster.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	existingState(mockTeams, mockUsers, ctx)
+
+	plan, err := service.Sync(ctx, desiredRoster(), roster.Options{DryRun: true})
+
+	require.NoError(t, err)
+	assert.Zero(t, plan.Summary[roster.ActionDeactivateUser])
+	assert.Len(t, plan.Changes, 4)
+}
+
+func TestSync_ConcurrentlyCreatedTeamIsConflict(t *testing.T) {
+	mockTeams := mocks.NewMockTeams(t)
+	mockUsers := mocks.NewMockUsers(t)
+	service := roster.New(passthroughTx(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	existingState(mockTeams, mockUsers, ctx)
+	mockTeams.On("Create", ctx, &models.Teams{Name: "platform"}).Return(custom.ErrDuplicateKey)
+
+	plan, err := service.Sync(ctx, desiredRoster(), roster.Options{})
+
+	require.ErrorIs(t, err, custom.ErrTeamExists)
+	assert.Nil(t, plan)
+}
+
+func occurredAt(t *testing.T, row models.Outbox) string {
+	var event events.Event
+	require.NoError(t, json.Unmarshal([]byte(row.Payload), &event))
+	return event.OccurredAt.Format(time.RFC3339Nano)
+}
+
+// passthroughTx runs the transaction body directly, as if it committed.
+func passthroughTx(t *testing.T) *mocks.MockTransactor {
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	return tx
+}
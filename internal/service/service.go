@@ -1,7 +1,13 @@
 package service
 
 import (
+	"mPR/internal/events"
+	"mPR/internal/service/feed"
+	"mPR/internal/service/health"
 	"mPR/internal/service/pull_requests"
+	"mPR/internal/service/roster"
+	"mPR/internal/service/snapshot"
+	"mPR/internal/service/stats"
 	"mPR/internal/service/teams"
 	"mPR/internal/service/users"
 	"mPR/internal/storage/repository"
@@ -11,12 +17,22 @@ type Manager struct {
 	Teams        *teams.Service
 	Users        *users.Service
 	PullRequests *pull_requests.Service
+	Health       *health.Service
+	Roster       *roster.Service
+	Snapshot     *snapshot.Service
+	Stats        *stats.Service
+	Feed         *feed.Service
 }
 
-func New(all *repository.All, maxReviewers int) *Manager {
+func New(all *repository.All, broker *events.Broker, maxReviewers int, schemaVersion uint) *Manager {
 	return &Manager{
-		Teams:        teams.New(all.Teams, all.Users),
-		Users:        users.New(all.Users, all.PullRequests, all.Reviewers),
-		PullRequests: pull_requests.New(all.PullRequests, all.Users, all.Reviewers, maxReviewers),
+		Teams:        teams.New(all.Tx, all.Teams, all.Users, all.Outbox),
+		Users:        users.New(all.Tx, all.Users, all.PullRequests, all.Outbox),
+		PullRequests: pull_requests.New(all.Tx, all.PullRequests, all.Users, all.Reviewers, all.Outbox, maxReviewers),
+		Health:       health.New(all.Health, schemaVersion),
+		Roster:       roster.New(all.Tx, all.Teams, all.Users, all.Outbox),
+		Snapshot:     snapshot.New(all.Snapshots, maxReviewers, schemaVersion),
+		Stats:        stats.New(all.Stats),
+		Feed:         feed.New(all.Outbox, broker),
 	}
 }
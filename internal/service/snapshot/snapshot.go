@@ -0,0 +1,371 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
+)
+
+const (
+	Format  = "pr-manager-snapshot"
+	Version = 1
+)
+
+// Document is the versioned JSON representation of the whole database.
+// Fields are spelled out here rather than reusing the model JSON so the
+// format stays stable when the API responses change.
+type Document struct {
+	Format        string         `json:"format"`
+	Version       int            `json:"version"`
+	ExportedAt    time.Time      `json:"exported_at"`
+	SchemaVersion uint           `json:"schema_version"`
+	Settings      Settings       `json:"settings"`
+	Teams         []Team         `json:"teams"`
+	Users         []User         `json:"users"`
+	PullRequests  []PullRequest  `json:"pull_requests"`
+	Reviewers     []Reviewer     `json:"reviewers"`
+	Reassignments []Reassignment `json:"reassignments,omitempty"`
+	Reviews       []Review       `json:"reviews,omitempty"`
+}
+
+type Settings struct {
+	MaxReviewers int `json:"max_reviewers"`
+}
+
+type Team struct {
+	Name string `json:"team_name"`
+}
+
+type User struct {
+	ID        string    `json:"user_id"`
+	Username  string    `json:"username"`
+	TeamName  *string   `json:"team_name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PullRequest struct {
+	ID        string     `json:"pull_request_id"`
+	Name      string     `json:"pull_request_name"`
+	AuthorID  string     `json:"author_id"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+type Reviewer struct {
+	PullRequestID string     `json:"pull_request_id"`
+	ReviewerID    string     `json:"reviewer_id"`
+	AssignedAt    *time.Time `json:"assigned_at,omitempty"`
+}
+
+type Reassignment struct {
+	PullRequestID string     `json:"pull_request_id"`
+	OldReviewerID string     `json:"old_reviewer_id"`
+	NewReviewerID string     `json:"new_reviewer_id"`
+	OldAssignedAt *time.Time `json:"old_assigned_at,omitempty"`
+	ReassignedAt  time.Time  `json:"reassigned_at"`
+}
+
+type Review struct {
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	Decision      string    `json:"decision"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+type Result struct {
+	Teams         int      `json:"teams"`
+	Users         int      `json:"users"`
+	PullRequests  int      `json:"pull_requests"`
+	Reviewers     int      `json:"reviewers"`
+	Reassignments int      `json:"reassignments"`
+	Reviews       int      `json:"reviews"`
+	Warnings      []string `json:"warnings,omitempty"`
+}
+
+type Service struct {
+	repo          repository.Snapshots
+	maxReviewers  int
+	schemaVersion uint
+}
+
+func New(repo repository.Snapshots, maxReviewers int, schemaVersion uint) *Service {
+	return &Service{
+		repo:          repo,
+		maxReviewers:  maxReviewers,
+		schemaVersion: schemaVersion,
+	}
+}
+
+func (s *Service) Export(ctx context.Context) (_ *Document, err error) {
+	ctx, span := tracing.Start(ctx, "snapshot.Export")
+	defer func() { tracing.End(span, err) }()
+
+	data, err := s.repo.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load data: %w", err)
+	}
+
+	doc := &Document{
+		Format:        Format,
+		Version:       Version,
+		ExportedAt:    time.Now().UTC(),
+		SchemaVersion: s.schemaVersion,
+		Settings:      Settings{MaxReviewers: s.maxReviewers},
+		Teams:         make([]Team, 0, len(data.Teams)),
+		Users:         make([]User, 0, len(data.Users)),
+		PullRequests:  make([]PullRequest, 0, len(data.PullRequests)),
+		Reviewers:     make([]Reviewer, 0, len(data.Reviewers)),
+		Reassignments: make([]Reassignment, 0, len(data.Reassignments)),
+		Reviews:       make([]Review, 0, len(data.Reviews)),
+	}
+
+	for _, t := range data.Teams {
+		doc.Teams = append(doc.Teams, Team{Name: t.Name})
+	}
+	for _, u := range data.Users {
+		doc.Users = append(doc.Users, User{
+			ID:        u.ID,
+			Username:  u.Username,
+			TeamName:  u.TeamName,
+			IsActive:  u.IsActive,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+	for _, pr := range data.PullRequests {
+		doc.PullRequests = append(doc.PullRequests, PullRequest{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+			MergedAt:  pr.MergedAt,
+		})
+	}
+	for _, r := range data.Reviewers {
+		doc.Reviewers = append(doc.Reviewers, Reviewer{
+			PullRequestID: r.PRID,
+			ReviewerID:    r.ReviewerID,
+			AssignedAt:    &r.AssignedAt,
+		})
+	}
+	for _, r := range data.Reassignments {
+		doc.Reassignments = append(doc.Reassignments, Reassignment{
+			PullRequestID: r.PRID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+			OldAssignedAt: &r.OldAssignedAt,
+			ReassignedAt:  r.ReassignedAt,
+		})
+	}
+	for _, r := range data.Reviews {
+		doc.Reviews = append(doc.Reviews, Review{
+			PullRequestID: r.PRID,
+			ReviewerID:    r.ReviewerID,
+			Decision:      r.Decision,
+			SubmittedAt:   r.SubmittedAt,
+		})
+	}
+
+	return doc, nil
+}
+
+// Import loads a snapshot into an empty database. The document is checked in
+// full before anything is written, and the write itself is one transaction.
+func (s *Service) Import(ctx context.Context, doc *Document) (_ *Result, err error) {
+	ctx, span := tracing.Start(ctx, "snapshot.Import")
+	defer func() { tracing.End(span, err) }()
+
+	if err := Validate(doc); err != nil {
+		return nil, err
+	}
+
+	data := &models.Dataset{
+		Teams:         make([]models.Teams, 0, len(doc.Teams)),
+		Users:         make([]models.Users, 0, len(doc.Users)),
+		PullRequests:  make([]models.PullRequests, 0, len(doc.PullRequests)),
+		Reviewers:     make([]models.Reviewers, 0, len(doc.Reviewers)),
+		Reassignments: make([]models.Reassignments, 0, len(doc.Reassignments)),
+		Reviews:       make([]models.Reviews, 0, len(doc.Reviews)),
+	}
+
+	for _, t := range doc.Teams {
+		data.Teams = append(data.Teams, models.Teams{Name: t.Name})
+	}
+	for _, u := range doc.Users {
+		data.Users = append(data.Users, models.Users{
+			ID:        u.ID,
+			Username:  u.Username,
+			TeamName:  u.TeamName,
+			IsActive:  u.IsActive,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+	createdAt := make(map[string]time.Time, len(doc.PullRequests))
+	for _, pr := range doc.PullRequests {
+		createdAt[pr.ID] = pr.CreatedAt
+		data.PullRequests = append(data.PullRequests, models.PullRequests{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+			MergedAt:  pr.MergedAt,
+		})
+	}
+	for _, r := range doc.Reviewers {
+		reviewer := models.Reviewers{PRID: r.PullRequestID, ReviewerID: r.ReviewerID}
+		if r.AssignedAt != nil {
+			reviewer.AssignedAt = *r.AssignedAt
+		}
+		data.Reviewers = append(data.Reviewers, reviewer)
+	}
+	for _, r := range doc.Reassignments {
+		reassignment := models.Reassignments{
+			PRID:          r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+			OldAssignedAt: createdAt[r.PullRequestID],
+			ReassignedAt:  r.ReassignedAt,
+		}
+		// Snapshots taken before old_assigned_at existed fall back to the
+		// PR creation time, as the migration does.
+		if r.OldAssignedAt != nil {
+			reassignment.OldAssignedAt = *r.OldAssignedAt
+		}
+		data.Reassignments = append(data.Reassignments, reassignment)
+	}
+	for _, r := range doc.Reviews {
+		data.Reviews = append(data.Reviews, models.Reviews{
+			PRID:        r.PullRequestID,
+			ReviewerID:  r.ReviewerID,
+			Decision:    r.Decision,
+			SubmittedAt: r.SubmittedAt,
+		})
+	}
+
+	if err := s.repo.Restore(ctx, data); err != nil {
+		return nil, fmt.Errorf("restore data: %w", err)
+	}
+
+	result := &Result{
+		Teams:         len(data.Teams),
+		Users:         len(data.Users),
+		PullRequests:  len(data.PullRequests),
+		Reviewers:     len(data.Reviewers),
+		Reassignments: len(data.Reassignments),
+		Reviews:       len(data.Reviews),
+	}
+
+	if doc.SchemaVersion != s.schemaVersion {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"snapshot was taken at schema version %d, database is at %d", doc.SchemaVersion, s.schemaVersion,
+		))
+	}
+	if doc.Settings.MaxReviewers != 0 && doc.Settings.MaxReviewers != s.maxReviewers {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"snapshot used max_reviewers=%d, this instance runs with %d", doc.Settings.MaxReviewers, s.maxReviewers,
+		))
+	}
+
+	return result, nil
+}
+
+// Validate checks the document header and referential integrity: every user
+// belongs to a known team, every PR has a known author, and every assignment
+// points at a known PR and user.
+func Validate(doc *Document) error {
+	if doc.Format != Format {
+		return fmt.Errorf("%w: format must be %q, got %q", custom.ErrInvalidSnapshot, Format, doc.Format)
+	}
+	if doc.Version != Version {
+		return fmt.Errorf("%w: unsupported version %d", custom.ErrInvalidSnapshot, doc.Version)
+	}
+
+	teams := make(map[string]struct{}, len(doc.Teams))
+	for _, t := range doc.Teams {
+		if t.Name == "" {
+			return fmt.Errorf("%w: team name is required", custom.ErrInvalidSnapshot)
+		}
+		if _, ok := teams[t.Name]; ok {
+			return fmt.Errorf("%w: team %q is listed twice", custom.ErrInvalidSnapshot, t.Name)
+		}
+		teams[t.Name] = struct{}{}
+	}
+
+	users := make(map[string]struct{}, len(doc.Users))
+	for _, u := range doc.Users {
+		if u.ID == "" {
+			return fmt.Errorf("%w: user without user_id", custom.ErrInvalidSnapshot)
+		}
+		if _, ok := users[u.ID]; ok {
+			return fmt.Errorf("%w: user %q is listed twice", custom.ErrInvalidSnapshot, u.ID)
+		}
+		if u.TeamName != nil {
+			if _, ok := teams[*u.TeamName]; !ok {
+				return fmt.Errorf("%w: user %q references unknown team %q", custom.ErrInvalidSnapshot, u.ID, *u.TeamName)
+			}
+		}
+		users[u.ID] = struct{}{}
+	}
+
+	prs := make(map[string]struct{}, len(doc.PullRequests))
+	for _, pr := range doc.PullRequests {
+		if pr.ID == "" {
+			return fmt.Errorf("%w: pull request without pull_request_id", custom.ErrInvalidSnapshot)
+		}
+		if _, ok := prs[pr.ID]; ok {
+			return fmt.Errorf("%w: pull request %q is listed twice", custom.ErrInvalidSnapshot, pr.ID)
+		}
+		if _, ok := users[pr.AuthorID]; !ok {
+			return fmt.Errorf("%w: pull request %q references unknown author %q", custom.ErrInvalidSnapshot, pr.ID, pr.AuthorID)
+		}
+		if pr.Status != custom.StatusOpen && pr.Status != custom.StatusMerged {
+			return fmt.Errorf("%w: pull request %q has invalid status %q", custom.ErrInvalidSnapshot, pr.ID, pr.Status)
+		}
+		prs[pr.ID] = struct{}{}
+	}
+
+	assigned := make(map[[2]string]struct{}, len(doc.Reviewers))
+	for _, r := range doc.Reviewers {
+		if _, ok := prs[r.PullRequestID]; !ok {
+			return fmt.Errorf("%w: reviewer %q assigned to unknown pull request %q", custom.ErrInvalidSnapshot, r.ReviewerID, r.PullRequestID)
+		}
+		if _, ok := users[r.ReviewerID]; !ok {
+			return fmt.Errorf("%w: pull request %q has unknown reviewer %q", custom.ErrInvalidSnapshot, r.PullRequestID, r.ReviewerID)
+		}
+		key := [2]string{r.PullRequestID, r.ReviewerID}
+		if _, ok := assigned[key]; ok {
+			return fmt.Errorf("%w: reviewer %q is assigned to %q twice", custom.ErrInvalidSnapshot, r.ReviewerID, r.PullRequestID)
+		}
+		assigned[key] = struct{}{}
+	}
+
+	for _, r := range doc.Reassignments {
+		if _, ok := prs[r.PullRequestID]; !ok {
+			return fmt.Errorf("%w: reassignment references unknown pull request %q", custom.ErrInvalidSnapshot, r.PullRequestID)
+		}
+		for _, id := range []string{r.OldReviewerID, r.NewReviewerID} {
+			if _, ok := users[id]; !ok {
+				return fmt.Errorf("%w: reassignment on %q references unknown user %q", custom.ErrInvalidSnapshot, r.PullRequestID, id)
+			}
+		}
+	}
+
+	for _, r := range doc.Reviews {
+		if _, ok := prs[r.PullRequestID]; !ok {
+			return fmt.Errorf("%w: review references unknown pull request %q", custom.ErrInvalidSnapshot, r.PullRequestID)
+		}
+		if _, ok := users[r.ReviewerID]; !ok {
+			return fmt.Errorf("%w: review on %q references unknown user %q", custom.ErrInvalidSnapshot, r.PullRequestID, r.ReviewerID)
+		}
+	}
+
+	return nil
+}
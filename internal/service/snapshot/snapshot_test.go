@@ -0,0 +1,169 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/service/snapshot"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func dataset() *models.Dataset {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	merged := created.Add(time.Hour)
+
+	return &models.Dataset{
+		Teams: []models.Teams{{Name: "backend"}},
+		Users: []models.Users{
+			{ID: "u1", Username: "Alice", TeamName: ptr("backend"), IsActive: true, CreatedAt: created},
+			{ID: "u2", Username: "Bob", TeamName: ptr("backend"), IsActive: false, CreatedAt: created},
+		},
+		PullRequests: []models.PullRequests{
+			{ID: "pr-1", Name: "Add feature", AuthorID: "u1", Status: custom.StatusMerged, CreatedAt: created, MergedAt: &merged},
+		},
+		Reviewers: []models.Reviewers{{PRID: "pr-1", ReviewerID: "u2", AssignedAt: created}},
+		Reassignments: []models.Reassignments{
+			{PRID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2", ReassignedAt: created},
+		},
+		Reviews: []models.Reviews{
+			{PRID: "pr-1", ReviewerID: "u2", Decision: custom.DecisionApproved, SubmittedAt: merged},
+		},
+	}
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	repo := mocks.NewMockSnapshots(t)
+	service := snapshot.New(repo, 2, 4)
+
+	ctx := context.Background()
+	data := dataset()
+
+	repo.On("Load", ctx).Return(data, nil)
+	repo.On("Restore", ctx, data).Return(nil)
+
+	doc, err := service.Export(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Format, doc.Format)
+	assert.Equal(t, snapshot.Version, doc.Version)
+	assert.Equal(t, uint(4), doc.SchemaVersion)
+	assert.Equal(t, 2, doc.Settings.MaxReviewers)
+
+	result, err := service.Import(ctx, doc)
+	require.NoError(t, err)
+	assert.Equal(t, &snapshot.Result{Teams: 1, Users: 2, PullRequests: 1, Reviewers: 1, Reassignments: 1, Reviews: 1}, result)
+}
+
+func TestImport_WarnsOnDifferentSettings(t *testing.T) {
+	repo := mocks.NewMockSnapshots(t)
+	service := snapshot.New(repo, 3, 5)
+
+	ctx := context.Background()
+	repo.On("Restore", ctx, mock.Anything).Return(nil)
+
+	result, err := service.Import(ctx, &snapshot.Document{
+		Format:        snapshot.Format,
+		Version:       snapshot.Version,
+		SchemaVersion: 4,
+		Settings:      snapshot.Settings{MaxReviewers: 2},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Warnings, 2)
+}
+
+func TestImport_DatabaseNotEmpty(t *testing.T) {
+	repo := mocks.NewMockSnapshots(t)
+	service := snapshot.New(repo, 2, 4)
+
+	ctx := context.Background()
+	repo.On("Restore", ctx, mock.Anything).Return(custom.ErrDatabaseNotEmpty)
+
+	_, err := service.Import(ctx, &snapshot.Document{Format: snapshot.Format, Version: snapshot.Version})
+
+	assert.True(t, errors.Is(err, custom.ErrDatabaseNotEmpty))
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *snapshot.Document {
+		return &snapshot.Document{
+			Format:  snapshot.Format,
+			Version: snapshot.Version,
+			Teams:   []snapshot.Team{{Name: "backend"}},
+			Users: []snapshot.User{
+				{ID: "u1", Username: "Alice", TeamName: ptr("backend")},
+				{ID: "u2", Username: "Bob", TeamName: ptr("backend")},
+			},
+			PullRequests: []snapshot.PullRequest{{ID: "pr-1", AuthorID: "u1", Status: custom.StatusOpen}},
+			Reviewers:    []snapshot.Reviewer{{PullRequestID: "pr-1", ReviewerID: "u2"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(doc *snapshot.Document)
+		errMsg string
+	}{
+		{"valid", func(doc *snapshot.Document) {}, ""},
+		{"wrong format", func(doc *snapshot.Document) { doc.Format = "other" }, "format must be"},
+		{"future version", func(doc *snapshot.Document) { doc.Version = 2 }, "unsupported version 2"},
+		{"duplicate team", func(doc *snapshot.Document) {
+			doc.Teams = append(doc.Teams, snapshot.Team{Name: "backend"})
+		}, `team "backend" is listed twice`},
+		{"unknown team", func(doc *snapshot.Document) {
+			doc.Users[0].TeamName = ptr("frontend")
+		}, `unknown team "frontend"`},
+		{"duplicate user", func(doc *snapshot.Document) {
+			doc.Users = append(doc.Users, snapshot.User{ID: "u1"})
+		}, `user "u1" is listed twice`},
+		{"unknown author", func(doc *snapshot.Document) {
+			doc.PullRequests[0].AuthorID = "u9"
+		}, `unknown author "u9"`},
+		{"invalid status", func(doc *snapshot.Document) {
+			doc.PullRequests[0].Status = "CLOSED"
+		}, `invalid status "CLOSED"`},
+		{"unknown pull request", func(doc *snapshot.Document) {
+			doc.Reviewers[0].PullRequestID = "pr-9"
+		}, `unknown pull request "pr-9"`},
+		{"unknown reviewer", func(doc *snapshot.Document) {
+			doc.Reviewers[0].ReviewerID = "u9"
+		}, `unknown reviewer "u9"`},
+		{"duplicate assignment", func(doc *snapshot.Document) {
+			doc.Reviewers = append(doc.Reviewers, doc.Reviewers[0])
+		}, "twice"},
+		{"reassignment to unknown user", func(doc *snapshot.Document) {
+			doc.Reassignments = []snapshot.Reassignment{{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u9"}}
+		}, `unknown user "u9"`},
+		{"review on unknown pull request", func(doc *snapshot.Document) {
+			doc.Reviews = []snapshot.Review{{PullRequestID: "pr-9", ReviewerID: "u2"}}
+		}, `review references unknown pull request "pr-9"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := valid()
+			tt.modify(doc)
+
+			err := snapshot.Validate(doc)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, custom.ErrInvalidSnapshot))
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
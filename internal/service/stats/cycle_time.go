@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/tracing"
+)
+
+// Percentiles are in seconds and nil when no PR reached the milestone.
+type Percentiles struct {
+	Count int64    `json:"count"`
+	P50   *float64 `json:"p50"`
+	P75   *float64 `json:"p75"`
+	P90   *float64 `json:"p90"`
+}
+
+type CycleTime struct {
+	PullRequests int64       `json:"pull_requests"`
+	FirstReview  Percentiles `json:"time_to_first_review"`
+	Approval     Percentiles `json:"time_to_approval"`
+	Merge        Percentiles `json:"time_to_merge"`
+}
+
+type WeekCycleTime struct {
+	Week time.Time `json:"week"`
+	CycleTime
+}
+
+type TeamCycleTime struct {
+	TeamName string          `json:"team_name"`
+	Overall  CycleTime       `json:"overall"`
+	Weeks    []WeekCycleTime `json:"weeks"`
+}
+
+type CycleTimeReport struct {
+	From  time.Time       `json:"from"`
+	To    time.Time       `json:"to"`
+	Teams []TeamCycleTime `json:"teams"`
+}
+
+// CycleTimes reports how long PRs opened in the window took to get their
+// first review, first approval and merge, per team and per week.
+func (s *Service) CycleTimes(ctx context.Context, window models.StatsWindow) (_ *CycleTimeReport, err error) {
+	ctx, span := tracing.Start(ctx, "stats.CycleTimes")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.stats.CycleTimes(ctx, window)
+	if err != nil {
+		return nil, fmt.Errorf("get cycle times: %w", err)
+	}
+
+	report := &CycleTimeReport{From: window.From, To: window.To, Teams: []TeamCycleTime{}}
+	for _, r := range rows {
+		if len(report.Teams) == 0 || report.Teams[len(report.Teams)-1].TeamName != r.TeamName {
+			report.Teams = append(report.Teams, TeamCycleTime{TeamName: r.TeamName, Weeks: []WeekCycleTime{}})
+		}
+		team := &report.Teams[len(report.Teams)-1]
+
+		ct := CycleTime{
+			PullRequests: r.PullRequests,
+			FirstReview:  Percentiles{Count: r.FirstReviewCount, P50: r.FirstReviewP50, P75: r.FirstReviewP75, P90: r.FirstReviewP90},
+			Approval:     Percentiles{Count: r.ApprovalCount, P50: r.ApprovalP50, P75: r.ApprovalP75, P90: r.ApprovalP90},
+			Merge:        Percentiles{Count: r.MergeCount, P50: r.MergeP50, P75: r.MergeP75, P90: r.MergeP90},
+		}
+
+		if r.Week == nil {
+			team.Overall = ct
+			continue
+		}
+		team.Weeks = append(team.Weeks, WeekCycleTime{Week: *r.Week, CycleTime: ct})
+	}
+
+	return report, nil
+}
+
+var cycleTimeHeader = []string{
+	"team_name", "week", "pull_requests",
+	"first_review_count", "first_review_p50_seconds", "first_review_p75_seconds", "first_review_p90_seconds",
+	"approval_count", "approval_p50_seconds", "approval_p75_seconds", "approval_p90_seconds",
+	"merge_count", "merge_p50_seconds", "merge_p75_seconds", "merge_p90_seconds",
+}
+
+// WriteCSV writes one row per team and week, preceded by the team's total
+// row whose week is "all". Missing percentiles are left empty.
+func (r *CycleTimeReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(cycleTimeHeader); err != nil {
+		return err
+	}
+
+	for _, team := range r.Teams {
+		if err := cw.Write(cycleTimeRecord(team.TeamName, "all", team.Overall)); err != nil {
+			return err
+		}
+		for _, week := range team.Weeks {
+			if err := cw.Write(cycleTimeRecord(team.TeamName, week.Week.Format(time.DateOnly), week.CycleTime)); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func cycleTimeRecord(team, week string, ct CycleTime) []string {
+	record := []string{team, week, strconv.FormatInt(ct.PullRequests, 10)}
+	for _, p := range []Percentiles{ct.FirstReview, ct.Approval, ct.Merge} {
+		record = append(record,
+			strconv.FormatInt(p.Count, 10), formatSeconds(p.P50), formatSeconds(p.P75), formatSeconds(p.P90),
+		)
+	}
+	return record
+}
+
+func formatSeconds(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 0, 64)
+}
@@ -0,0 +1,62 @@
+package stats_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/service/stats"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func TestCycleTimes_GroupsByTeamAndWeek(t *testing.T) {
+	repo := mocks.NewMockStats(t)
+	service := stats.New(repo)
+
+	ctx := context.Background()
+	window := models.StatsWindow{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	week1 := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	week2 := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	repo.On("CycleTimes", ctx, window).Return([]models.CycleTimeStats{
+		{TeamName: "backend", PullRequests: 3, FirstReviewCount: 2, FirstReviewP50: ptr(3600.0), MergeCount: 1, MergeP50: ptr(86400.0)},
+		{TeamName: "backend", Week: &week1, PullRequests: 2, FirstReviewCount: 2, FirstReviewP50: ptr(3600.0)},
+		{TeamName: "backend", Week: &week2, PullRequests: 1, MergeCount: 1, MergeP50: ptr(86400.0)},
+		{TeamName: "frontend", PullRequests: 1},
+		{TeamName: "frontend", Week: &week2, PullRequests: 1},
+	}, nil)
+
+	report, err := service.CycleTimes(ctx, window)
+
+	require.NoError(t, err)
+	require.Len(t, report.Teams, 2)
+
+	backend := report.Teams[0]
+	assert.Equal(t, "backend", backend.TeamName)
+	assert.Equal(t, int64(3), backend.Overall.PullRequests)
+	assert.Equal(t, 3600.0, *backend.Overall.FirstReview.P50)
+	require.Len(t, backend.Weeks, 2)
+	assert.Equal(t, week1, backend.Weeks[0].Week)
+	assert.Nil(t, backend.Weeks[0].Merge.P50)
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteCSV(&out))
+	assert.Equal(t, ""+
+		"team_name,week,pull_requests,"+
+		"first_review_count,first_review_p50_seconds,first_review_p75_seconds,first_review_p90_seconds,"+
+		"approval_count,approval_p50_seconds,approval_p75_seconds,approval_p90_seconds,"+
+		"merge_count,merge_p50_seconds,merge_p75_seconds,merge_p90_seconds\n"+
+		"backend,all,3,2,3600,,,0,,,,1,86400,,\n"+
+		"backend,2025-01-06,2,2,3600,,,0,,,,0,,,\n"+
+		"backend,2025-01-13,1,0,,,,0,,,,1,86400,,\n"+
+		"frontend,all,1,0,,,,0,,,,0,,,\n"+
+		"frontend,2025-01-13,1,0,,,,0,,,,0,,,\n", out.String())
+}
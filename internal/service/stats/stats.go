@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
+)
+
+type ReviewerReport struct {
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Reviewers []models.ReviewerStats `json:"reviewers"`
+}
+
+type TeamStats struct {
+	TeamName           string   `json:"team_name"`
+	Members            int      `json:"members"`
+	ActiveMembers      int      `json:"active_members"`
+	Assignments        int64    `json:"assignments"`
+	OpenReviews        int64    `json:"open_reviews"`
+	Reassignments      int64    `json:"reassignments"`
+	AvgDecisionSeconds *float64 `json:"avg_decision_seconds"`
+	MergedUnreviewed   int64    `json:"merged_unreviewed"`
+	MinAssignments     int64    `json:"min_assignments"`
+	MaxAssignments     int64    `json:"max_assignments"`
+	Gini               float64  `json:"gini"`
+}
+
+type TeamReport struct {
+	From  time.Time   `json:"from"`
+	To    time.Time   `json:"to"`
+	Teams []TeamStats `json:"teams"`
+}
+
+type Service struct {
+	stats repository.Stats
+}
+
+func New(stats repository.Stats) *Service {
+	return &Service{
+		stats: stats,
+	}
+}
+
+func (s *Service) Reviewers(ctx context.Context, window models.StatsWindow) (_ *ReviewerReport, err error) {
+	ctx, span := tracing.Start(ctx, "stats.Reviewers")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.stats.Reviewers(ctx, window)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer stats: %w", err)
+	}
+
+	if rows == nil {
+		rows = []models.ReviewerStats{}
+	}
+
+	return &ReviewerReport{From: window.From, To: window.To, Reviewers: rows}, nil
+}
+
+// Teams rolls reviewer statistics up to teams. Fairness is measured over
+// members who could have been assigned: active ones and anyone who was.
+func (s *Service) Teams(ctx context.Context, window models.StatsWindow) (_ *TeamReport, err error) {
+	ctx, span := tracing.Start(ctx, "stats.Teams")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := s.stats.Reviewers(ctx, window)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer stats: %w", err)
+	}
+
+	type acc struct {
+		stats       TeamStats
+		assignments []int64
+		decided     int64
+		decisionSum float64
+	}
+
+	var order []string
+	teams := make(map[string]*acc)
+	for _, r := range rows {
+		if r.TeamName == nil {
+			continue
+		}
+
+		t, ok := teams[*r.TeamName]
+		if !ok {
+			t = &acc{stats: TeamStats{TeamName: *r.TeamName}}
+			teams[*r.TeamName] = t
+			order = append(order, *r.TeamName)
+		}
+
+		t.stats.Members++
+		if r.IsActive {
+			t.stats.ActiveMembers++
+		}
+		t.stats.Assignments += r.Assignments
+		t.stats.OpenReviews += r.OpenReviews
+		t.stats.Reassignments += r.ReassignedFrom
+		t.stats.MergedUnreviewed += r.MergedUnreviewed
+
+		if r.AvgDecisionSeconds != nil && r.Decided > 0 {
+			t.decided += r.Decided
+			t.decisionSum += *r.AvgDecisionSeconds * float64(r.Decided)
+		}
+
+		if r.IsActive || r.Assignments > 0 {
+			t.assignments = append(t.assignments, r.Assignments)
+		}
+	}
+
+	report := &TeamReport{From: window.From, To: window.To, Teams: make([]TeamStats, 0, len(order))}
+	for _, name := range order {
+		t := teams[name]
+
+		if t.decided > 0 {
+			avg := t.decisionSum / float64(t.decided)
+			t.stats.AvgDecisionSeconds = &avg
+		}
+		if len(t.assignments) > 0 {
+			t.stats.MinAssignments = slices.Min(t.assignments)
+			t.stats.MaxAssignments = slices.Max(t.assignments)
+		}
+		t.stats.Gini = Gini(t.assignments)
+
+		report.Teams = append(report.Teams, t.stats)
+	}
+
+	return report, nil
+}
+
+// Gini returns the Gini coefficient of the values: 0 when everyone has the
+// same share, approaching 1 when one member has everything.
+func Gini(values []int64) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	return 2*weighted/(float64(n)*sum) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,71 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/service/stats"
+	"mPR/internal/storage/models"
+	"mPR/mocks"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestGini(t *testing.T) {
+	assert.Equal(t, 0.0, stats.Gini(nil))
+	assert.Equal(t, 0.0, stats.Gini([]int64{5}))
+	assert.Equal(t, 0.0, stats.Gini([]int64{0, 0, 0}))
+	assert.InDelta(t, 0.0, stats.Gini([]int64{4, 4, 4, 4}), 1e-9)
+	assert.InDelta(t, 0.75, stats.Gini([]int64{0, 0, 0, 10}), 1e-9)
+	assert.InDelta(t, 0.25, stats.Gini([]int64{1, 3}), 1e-9)
+}
+
+func TestTeams_Aggregates(t *testing.T) {
+	repo := mocks.NewMockStats(t)
+	service := stats.New(repo)
+
+	ctx := context.Background()
+	window := models.StatsWindow{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	repo.On("Reviewers", ctx, window).Return([]models.ReviewerStats{
+		{UserID: "u1", TeamName: ptr("backend"), IsActive: true, Assignments: 1, Decided: 1, AvgDecisionSeconds: ptr(100.0), OpenReviews: 0},
+		{UserID: "u2", TeamName: ptr("backend"), IsActive: true, Assignments: 3, Decided: 3, AvgDecisionSeconds: ptr(300.0), OpenReviews: 2, ReassignedFrom: 1, MergedUnreviewed: 1},
+		{UserID: "u3", TeamName: ptr("backend"), IsActive: false},
+		{UserID: "u4", TeamName: ptr("frontend"), IsActive: true, Assignments: 2, MergedUnreviewed: 2},
+		{UserID: "u5", IsActive: true, Assignments: 7},
+	}, nil)
+
+	report, err := service.Teams(ctx, window)
+
+	require.NoError(t, err)
+	require.Len(t, report.Teams, 2)
+
+	backend := report.Teams[0]
+	assert.Equal(t, "backend", backend.TeamName)
+	assert.Equal(t, 3, backend.Members)
+	assert.Equal(t, 2, backend.ActiveMembers)
+	assert.Equal(t, int64(4), backend.Assignments)
+	assert.Equal(t, int64(2), backend.OpenReviews)
+	assert.Equal(t, int64(1), backend.Reassignments)
+	assert.Equal(t, int64(1), backend.MergedUnreviewed)
+	assert.Equal(t, int64(1), backend.MinAssignments)
+	assert.Equal(t, int64(3), backend.MaxAssignments)
+	require.NotNil(t, backend.AvgDecisionSeconds)
+	assert.InDelta(t, 250.0, *backend.AvgDecisionSeconds, 1e-9)
+	assert.InDelta(t, 0.25, backend.Gini, 1e-9, "inactive members without assignments are not counted")
+
+	frontend := report.Teams[1]
+	assert.Equal(t, "frontend", frontend.TeamName)
+	assert.Nil(t, frontend.AvgDecisionSeconds)
+	assert.Equal(t, int64(2), frontend.MergedUnreviewed)
+	assert.Equal(t, 0.0, frontend.Gini)
+}
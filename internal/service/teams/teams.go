@@ -5,50 +5,68 @@ import (
 	"errors"
 	"fmt"
 
-	"gorm.io/gorm"
-
 	"mPR/internal/custom"
+	"mPR/internal/events"
+	"mPR/internal/pagination"
 	"mPR/internal/storage/models"
 	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
 )
 
+type Page struct {
+	Teams      []models.TeamSummary `json:"teams"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+type cursor struct {
+	Name string `json:"n"`
+}
+
 type Service struct {
-	teams repository.Teams
-	users repository.Users
+	tx     repository.Transactor
+	teams  repository.Teams
+	users  repository.Users
+	outbox repository.Outbox
 }
 
-func New(teams repository.Teams, users repository.Users) *Service {
+func New(tx repository.Transactor, teams repository.Teams, users repository.Users, outbox repository.Outbox) *Service {
 	return &Service{
-		teams: teams,
-		users: users,
+		tx:     tx,
+		teams:  teams,
+		users:  users,
+		outbox: outbox,
 	}
 }
 
-func (t *Service) Add(ctx context.Context, team *models.Teams, members []models.Users) error {
-	exist, err := t.teams.GetByName(ctx, team.Name)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("check team existence: %w", err)
-	}
-
-	if exist != nil {
-		return custom.ErrTeamExists
-	}
+func (t *Service) Add(ctx context.Context, team *models.Teams, members []models.Users) (err error) {
+	ctx, span := tracing.Start(ctx, "teams.Add")
+	defer func() { tracing.End(span, err) }()
 
-	if err := t.teams.Create(ctx, team); err != nil {
-		return fmt.Errorf("create team: %w", err)
-	}
+	// The insert itself decides which of concurrent requests wins, and the
+	// members are only written together with the team.
+	return t.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := t.teams.Create(ctx, team); err != nil {
+			if errors.Is(err, custom.ErrDuplicateKey) {
+				return custom.ErrTeamExists
+			}
+			return fmt.Errorf("create team: %w", err)
+		}
 
-	if err := t.users.CreateOrUpdate(ctx, team.Name, members); err != nil {
-		return fmt.Errorf("create or update team members: %w", err)
-	}
+		if err := t.users.CreateOrUpdate(ctx, team.Name, members); err != nil {
+			return fmt.Errorf("create or update team members: %w", err)
+		}
 
-	return nil
+		return events.Record(ctx, t.outbox, events.TeamCreated(team.Name, members))
+	})
 }
 
-func (t *Service) Get(ctx context.Context, name string) (*models.Teams, error) {
+func (t *Service) Get(ctx context.Context, name string) (_ *models.Teams, err error) {
+	ctx, span := tracing.Start(ctx, "teams.Get")
+	defer func() { tracing.End(span, err) }()
+
 	team, err := t.teams.GetByName(ctx, name)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, custom.ErrNotFound) {
 			return nil, custom.ErrNotFound
 		}
 		return nil, fmt.Errorf("get team by name: %w", err)
@@ -56,3 +74,38 @@ func (t *Service) Get(ctx context.Context, name string) (*models.Teams, error) {
 
 	return team, nil
 }
+
+func (t *Service) List(ctx context.Context, limit int, after string) (_ *Page, err error) {
+	ctx, span := tracing.Start(ctx, "teams.List")
+	defer func() { tracing.End(span, err) }()
+
+	filter := models.TeamFilter{Limit: pagination.Limit(limit)}
+	if after != "" {
+		var c cursor
+		if err := pagination.Decode(after, &c); err != nil {
+			return nil, err
+		}
+		filter.After = c.Name
+	}
+
+	limit = filter.Limit
+	filter.Limit++
+
+	teams, err := t.teams.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+
+	page := &Page{}
+
+	var more bool
+	page.Teams, more = pagination.Trim(teams, limit)
+	if more {
+		page.NextCursor, err = pagination.Encode(cursor{Name: page.Teams[limit-1].Name})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
@@ -3,11 +3,12 @@ package teams_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"gorm.io/gorm"
 
 	"mPR/internal/custom"
 	"mPR/internal/service/teams"
@@ -19,7 +20,7 @@ func TestAdd_Success(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(passthroughTx(t), mockTeams, mockUsers, expectEvents(t, "TEAM_CREATED"))
 
 	ctx := context.Background()
 	teamName := "team1"
@@ -29,7 +30,6 @@ func TestAdd_Success(t *testing.T) {
 		{Username: "user2", IsActive: true},
 	}
 
-	mockTeams.On("GetByName", ctx, teamName).Return(nil, gorm.ErrRecordNotFound)
 	mockTeams.On("Create", ctx, team).Return(nil)
 	mockUsers.On("CreateOrUpdate", ctx, teamName, members).Return(nil)
 
@@ -42,29 +42,23 @@ func TestAdd_TeamExists(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(passthroughTx(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
-	teamName := "team1"
-	team := &models.Teams{Name: teamName}
-	members := []models.Users{
-		{Username: "user1", IsActive: true},
-	}
+	team := &models.Teams{Name: "team1"}
 
-	existingTeam := &models.Teams{Name: teamName}
-	mockTeams.On("GetByName", ctx, teamName).Return(existingTeam, nil)
+	mockTeams.On("Create", ctx, team).Return(fmt.Errorf("%w: duplicated key not allowed", custom.ErrDuplicateKey))
 
-	err := service.Add(ctx, team, members)
+	err := service.Add(ctx, team, nil)
 
-	assert.Error(t, err)
-	assert.True(t, errors.Is(err, custom.ErrTeamExists))
+	assert.ErrorIs(t, err, custom.ErrTeamExists)
 }
 
 func TestAdd_CreateError(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(passthroughTx(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	teamName := "team1"
@@ -73,7 +67,6 @@ func TestAdd_CreateError(t *testing.T) {
 		{Username: "user1", IsActive: true},
 	}
 
-	mockTeams.On("GetByName", ctx, teamName).Return(nil, gorm.ErrRecordNotFound)
 	mockTeams.On("Create", ctx, team).Return(errors.New("db error"))
 
 	err := service.Add(ctx, team, members)
@@ -86,7 +79,7 @@ func TestGet_Success(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	teamName := "team1"
@@ -105,12 +98,12 @@ func TestGet_NotFound(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	teamName := "nonexistent"
 
-	mockTeams.On("GetByName", ctx, teamName).Return(nil, gorm.ErrRecordNotFound)
+	mockTeams.On("GetByName", ctx, teamName).Return(nil, custom.ErrNotFound)
 
 	result, err := service.Get(ctx, teamName)
 
@@ -123,7 +116,7 @@ func TestGet_DBError(t *testing.T) {
 	mockTeams := mocks.NewMockTeams(t)
 	mockUsers := mocks.NewMockUsers(t)
 
-	service := teams.New(mockTeams, mockUsers)
+	service := teams.New(mocks.NewMockTransactor(t), mockTeams, mockUsers, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	teamName := "team1"
@@ -136,3 +129,28 @@ func TestGet_DBError(t *testing.T) {
 	assert.Contains(t, err.Error(), "connection error")
 	assert.Nil(t, result)
 }
+
+// expectEvents expects one outbox write holding events of the given types.
+func expectEvents(t *testing.T, types ...string) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(t)
+	outbox.EXPECT().Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, rows []models.Outbox) error {
+			recorded := make([]string, 0, len(rows))
+			for _, row := range rows {
+				recorded = append(recorded, row.Type)
+			}
+			assert.Equal(t, types, recorded)
+			return nil
+		}).Once()
+
+	return outbox
+}
+
+// passthroughTx runs the transaction body directly, as if it committed.
+func passthroughTx(t *testing.T) *mocks.MockTransactor {
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	return tx
+}
@@ -0,0 +1,54 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"mPR/internal/pagination"
+	"mPR/internal/storage/models"
+	"mPR/internal/tracing"
+)
+
+type Page struct {
+	Users      []models.UserSummary `json:"users"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+type cursor struct {
+	ID string `json:"id"`
+}
+
+func (s *Service) List(ctx context.Context, filter models.UserFilter, after string) (_ *Page, err error) {
+	ctx, span := tracing.Start(ctx, "users.List")
+	defer func() { tracing.End(span, err) }()
+
+	filter.Limit = pagination.Limit(filter.Limit)
+	if after != "" {
+		var c cursor
+		if err := pagination.Decode(after, &c); err != nil {
+			return nil, err
+		}
+		filter.After = c.ID
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	users, err := s.users.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	page := &Page{}
+
+	var more bool
+	page.Users, more = pagination.Trim(users, limit)
+	if more {
+		page.NextCursor, err = pagination.Encode(cursor{ID: page.Users[limit-1].ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,69 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/pagination"
+	"mPR/internal/storage/models"
+	"mPR/internal/tracing"
+)
+
+type ReviewsPage struct {
+	UserID       string                `json:"user_id"`
+	PullRequests []models.PullRequests `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+type reviewCursor struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"c"`
+}
+
+// GetUserReviews returns a page of the PRs the user is assigned to, newest
+// first. An empty filter.Status lists PRs in every status.
+func (s *Service) GetUserReviews(ctx context.Context, userID string, filter models.ReviewFilter, after string) (_ *ReviewsPage, err error) {
+	ctx, span := tracing.Start(ctx, "users.GetUserReviews")
+	defer func() { tracing.End(span, err) }()
+
+	filter.Limit = pagination.Limit(filter.Limit)
+	if after != "" {
+		var c reviewCursor
+		if err := pagination.Decode(after, &c); err != nil {
+			return nil, err
+		}
+		filter.After = &models.PullRequests{ID: c.ID, CreatedAt: c.CreatedAt}
+	}
+
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, custom.ErrNotFound) {
+			return nil, custom.ErrNotFound
+		}
+		return nil, fmt.Errorf("get user by ID: %w", err)
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	prs, err := s.pullRequests.GetByReviewer(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get pull requests by reviewer: %w", err)
+	}
+
+	page := &ReviewsPage{UserID: userID}
+
+	var more bool
+	page.PullRequests, more = pagination.Trim(prs, limit)
+	if more {
+		last := page.PullRequests[limit-1]
+		page.NextCursor, err = pagination.Encode(reviewCursor{ID: last.ID, CreatedAt: last.CreatedAt})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
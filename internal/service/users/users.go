@@ -4,66 +4,128 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/mail"
+
+	"mPR/internal/events"
+	"mPR/internal/slack"
 	models2 "mPR/internal/storage/models"
 	"mPR/internal/storage/repository"
+	"mPR/internal/tracing"
 
 	"mPR/internal/custom"
-
-	"gorm.io/gorm"
 )
 
 type Service struct {
+	tx           repository.Transactor
 	users        repository.Users
 	pullRequests repository.PullRequests
-	reviewers    repository.Reviewers
+	outbox       repository.Outbox
 }
 
-func New(users repository.Users, pullRequests repository.PullRequests, reviewers repository.Reviewers) *Service {
+func New(tx repository.Transactor, users repository.Users, pullRequests repository.PullRequests, outbox repository.Outbox) *Service {
 	return &Service{
+		tx:           tx,
 		users:        users,
 		pullRequests: pullRequests,
-		reviewers:    reviewers,
+		outbox:       outbox,
 	}
 }
 
-func (s *Service) SetActive(ctx context.Context, userID string, active bool) (*models2.Users, error) {
+func (s *Service) SetActive(ctx context.Context, userID string, active bool) (_ *models2.Users, err error) {
+	ctx, span := tracing.Start(ctx, "users.SetActive")
+	defer func() { tracing.End(span, err) }()
+
 	user, err := s.users.GetByID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, custom.ErrNotFound) {
 			return nil, custom.ErrNotFound
 		}
 		return nil, fmt.Errorf("get user by ID: %w", err)
 	}
 
-	if err := s.users.UpdateIsActive(ctx, userID, active); err != nil {
-		return nil, fmt.Errorf("update user is_active status: %w", err)
+	if user.IsActive == active {
+		return user, nil
 	}
 
 	user.IsActive = active
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.users.UpdateIsActive(ctx, userID, active); err != nil {
+			return fmt.Errorf("update user is_active status: %w", err)
+		}
+
+		return events.Record(ctx, s.outbox, events.UserActivityChanged(user))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
-func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]models2.PullRequests, error) {
-	_, err := s.users.GetByID(ctx, userID)
+// SetSlackID links the user to a Slack member ID for mentions; an empty ID
+// removes the link.
+func (s *Service) SetSlackID(ctx context.Context, userID, slackID string) (_ *models2.Users, err error) {
+	ctx, span := tracing.Start(ctx, "users.SetSlackID")
+	defer func() { tracing.End(span, err) }()
+
+	if slackID != "" && !slack.ValidMemberID(slackID) {
+		return nil, custom.ErrInvalidSlackID
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, custom.ErrNotFound) {
 			return nil, custom.ErrNotFound
 		}
 		return nil, fmt.Errorf("get user by ID: %w", err)
 	}
 
-	prIDs, err := s.reviewers.GetPRsByReviewer(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("get pull requests by reviewer: %w", err)
+	user.SlackID = nil
+	if slackID != "" {
+		user.SlackID = &slackID
+	}
+
+	if err := s.users.UpdateSlackID(ctx, userID, user.SlackID); err != nil {
+		return nil, fmt.Errorf("update user slack_id: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetEmail sets where the user gets email notifications and whether they
+// opted out; an empty address removes it.
+func (s *Service) SetEmail(ctx context.Context, userID, email string, optOut bool) (_ *models2.Users, err error) {
+	ctx, span := tracing.Start(ctx, "users.SetEmail")
+	defer func() { tracing.End(span, err) }()
+
+	if email != "" && !ValidEmail(email) {
+		return nil, custom.ErrInvalidEmail
 	}
 
-	prs := make([]models2.PullRequests, 0, len(prIDs))
-	for _, id := range prIDs {
-		pr, err := s.pullRequests.GetByID(ctx, id)
-		if err == nil {
-			prs = append(prs, *pr)
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, custom.ErrNotFound) {
+			return nil, custom.ErrNotFound
 		}
+		return nil, fmt.Errorf("get user by ID: %w", err)
+	}
+
+	user.Email = nil
+	if email != "" {
+		user.Email = &email
+	}
+	user.EmailOptOut = optOut
+
+	if err := s.users.UpdateEmail(ctx, userID, user.Email, optOut); err != nil {
+		return nil, fmt.Errorf("update user email: %w", err)
 	}
 
-	return prs, nil
+	return user, nil
+}
+
+// ValidEmail reports whether email is a bare address such as
+// alice@example.com, without a display name.
+func ValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
 }
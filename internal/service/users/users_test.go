@@ -5,21 +5,21 @@ import (
 	"errors"
 	models2 "mPR/internal/storage/models"
 	"testing"
+	"time"
 
 	"mPR/internal/custom"
 	"mPR/internal/service/users"
 	"mPR/mocks"
 
 	"github.com/stretchr/testify/assert"
-	"gorm.io/gorm"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestSetActive_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(passthroughTx(t), mockUsers, mockPR, expectEvents(t, "USER_ACTIVITY_CHANGED"))
 
 	ctx := context.Background()
 	userID := "u1"
@@ -43,14 +43,13 @@ func TestSetActive_Success(t *testing.T) {
 func TestSetActive_UserNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
 
-	mockUsers.On("GetByID", ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.On("GetByID", ctx, userID).Return(nil, custom.ErrNotFound)
 
 	result, err := service.SetActive(ctx, userID, true)
 
@@ -62,9 +61,8 @@ func TestSetActive_UserNotFound(t *testing.T) {
 func TestSetActive_UpdateError(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(passthroughTx(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
@@ -84,12 +82,78 @@ func TestSetActive_UpdateError(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestSetActive_Unchanged(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	user := &models2.Users{ID: "u1", Username: "testuser", IsActive: true}
+
+	mockUsers.On("GetByID", ctx, "u1").Return(user, nil)
+
+	result, err := service.SetActive(ctx, "u1", true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsActive)
+}
+
+func TestSetSlackID_Clears(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	slackID := "U024BE7LH"
+	user := &models2.Users{ID: "u1", Username: "testuser", SlackID: &slackID}
+
+	mockUsers.On("GetByID", ctx, "u1").Return(user, nil)
+	mockUsers.On("UpdateSlackID", ctx, "u1", (*string)(nil)).Return(nil)
+
+	result, err := service.SetSlackID(ctx, "u1", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.SlackID)
+}
+
+func TestSetSlackID_Invalid(t *testing.T) {
+	service := users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	_, err := service.SetSlackID(context.Background(), "u1", "alice")
+
+	assert.ErrorIs(t, err, custom.ErrInvalidSlackID)
+}
+
+func TestSetEmail_Success(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	email := "alice@example.com"
+
+	mockUsers.On("GetByID", ctx, "u1").Return(&models2.Users{ID: "u1", Username: "testuser"}, nil)
+	mockUsers.On("UpdateEmail", ctx, "u1", &email, false).Return(nil)
+
+	result, err := service.SetEmail(ctx, "u1", email, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, email, *result.Email)
+}
+
+func TestSetEmail_Invalid(t *testing.T) {
+	service := users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	_, err := service.SetEmail(context.Background(), "u1", "not-an-email", false)
+
+	assert.ErrorIs(t, err, custom.ErrInvalidEmail)
+}
+
 func TestGetUserReviews_Success(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
@@ -99,48 +163,80 @@ func TestGetUserReviews_Success(t *testing.T) {
 		IsActive: true,
 	}
 
-	prID1 := "pr1"
-	prID2 := "pr2"
-	prIDs := []string{prID1, prID2}
-
-	pr1 := &models2.PullRequests{
-		ID:     prID1,
-		Name:   "PR 1",
-		Status: custom.StatusOpen,
-	}
-	pr2 := &models2.PullRequests{
-		ID:     prID2,
-		Name:   "PR 2",
-		Status: custom.StatusMerged,
+	prs := []models2.PullRequests{
+		{ID: "pr2", Name: "PR 2", Status: custom.StatusOpen, Reviewers: []models2.Reviewers{{PRID: "pr2", ReviewerID: userID}}},
+		{ID: "pr1", Name: "PR 1", Status: custom.StatusOpen, Reviewers: []models2.Reviewers{{PRID: "pr1", ReviewerID: userID}}},
 	}
 
+	filter := models2.ReviewFilter{Status: custom.StatusOpen, Limit: 51}
+
 	mockUsers.On("GetByID", ctx, userID).Return(user, nil)
-	mockReviewers.On("GetPRsByReviewer", ctx, userID).Return(prIDs, nil)
-	mockPR.On("GetByID", ctx, prID1).Return(pr1, nil)
-	mockPR.On("GetByID", ctx, prID2).Return(pr2, nil)
+	mockPR.On("GetByReviewer", ctx, userID, filter).Return(prs, nil)
 
-	result, err := service.GetUserReviews(ctx, userID)
+	result, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{Status: custom.StatusOpen}, "")
 
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 2)
-	assert.Equal(t, prID1, result[0].ID)
-	assert.Equal(t, prID2, result[1].ID)
+	assert.Equal(t, userID, result.UserID)
+	assert.Len(t, result.PullRequests, 2)
+	assert.Equal(t, "pr2", result.PullRequests[0].ID)
+	assert.Equal(t, "pr1", result.PullRequests[1].ID)
+	assert.Empty(t, result.NextCursor)
+}
+
+func TestGetUserReviews_Pagination(t *testing.T) {
+	mockUsers := mocks.NewMockUsers(t)
+	mockPR := mocks.NewMockPullRequests(t)
+
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
+
+	ctx := context.Background()
+	userID := "u1"
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mockUsers.On("GetByID", ctx, userID).Return(&models2.Users{ID: userID}, nil)
+	mockPR.On("GetByReviewer", ctx, userID, models2.ReviewFilter{Limit: 2}).Return([]models2.PullRequests{
+		{ID: "pr3", CreatedAt: created.Add(time.Hour)},
+		{ID: "pr2", CreatedAt: created},
+	}, nil)
+
+	first, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{Limit: 1}, "")
+	assert.NoError(t, err)
+	assert.Len(t, first.PullRequests, 1)
+	assert.NotEmpty(t, first.NextCursor)
+
+	mockPR.On("GetByReviewer", ctx, userID, models2.ReviewFilter{
+		After: &models2.PullRequests{ID: "pr3", CreatedAt: created.Add(time.Hour)},
+		Limit: 2,
+	}).Return([]models2.PullRequests{{ID: "pr2", CreatedAt: created}}, nil)
+
+	second, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{Limit: 1}, first.NextCursor)
+	assert.NoError(t, err)
+	assert.Len(t, second.PullRequests, 1)
+	assert.Equal(t, "pr2", second.PullRequests[0].ID)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestGetUserReviews_InvalidCursor(t *testing.T) {
+	service := users.New(mocks.NewMockTransactor(t), mocks.NewMockUsers(t), mocks.NewMockPullRequests(t), mocks.NewMockOutbox(t))
+
+	result, err := service.GetUserReviews(context.Background(), "u1", models2.ReviewFilter{}, "not-a-cursor")
+
+	assert.ErrorIs(t, err, custom.ErrInvalidCursor)
+	assert.Nil(t, result)
 }
 
 func TestGetUserReviews_UserNotFound(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
 
-	mockUsers.On("GetByID", ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+	mockUsers.On("GetByID", ctx, userID).Return(nil, custom.ErrNotFound)
 
-	result, err := service.GetUserReviews(ctx, userID)
+	result, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{}, "")
 
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, custom.ErrNotFound))
@@ -150,9 +246,8 @@ func TestGetUserReviews_UserNotFound(t *testing.T) {
 func TestGetUserReviews_NoPRs(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
@@ -163,49 +258,54 @@ func TestGetUserReviews_NoPRs(t *testing.T) {
 	}
 
 	mockUsers.On("GetByID", ctx, userID).Return(user, nil)
-	mockReviewers.On("GetPRsByReviewer", ctx, userID).Return([]string{}, nil)
+	mockPR.On("GetByReviewer", ctx, userID, models2.ReviewFilter{Limit: 51}).Return(nil, nil)
 
-	result, err := service.GetUserReviews(ctx, userID)
+	result, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{}, "")
 
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Empty(t, result)
+	assert.NotNil(t, result.PullRequests)
+	assert.Empty(t, result.PullRequests)
 }
 
-func TestGetUserReviews_SkipsMissingPRs(t *testing.T) {
+func TestGetUserReviews_PropagatesErrors(t *testing.T) {
 	mockUsers := mocks.NewMockUsers(t)
 	mockPR := mocks.NewMockPullRequests(t)
-	mockReviewers := mocks.NewMockReviewers(t)
 
-	service := users.New(mockUsers, mockPR, mockReviewers)
+	service := users.New(mocks.NewMockTransactor(t), mockUsers, mockPR, mocks.NewMockOutbox(t))
 
 	ctx := context.Background()
 	userID := "u1"
-	user := &models2.Users{
-		ID:       userID,
-		Username: "reviewer",
-		IsActive: true,
-	}
 
-	prID1 := "pr1"
-	prID2 := "pr2"
-	prIDs := []string{prID1, prID2}
+	mockUsers.On("GetByID", ctx, userID).Return(&models2.Users{ID: userID}, nil)
+	mockPR.On("GetByReviewer", ctx, userID, models2.ReviewFilter{Limit: 51}).Return(nil, errors.New("connection reset"))
 
-	pr1 := &models2.PullRequests{
-		ID:     prID1,
-		Name:   "PR 1",
-		Status: custom.StatusOpen,
-	}
+	result, err := service.GetUserReviews(ctx, userID, models2.ReviewFilter{}, "")
 
-	mockUsers.On("GetByID", ctx, userID).Return(user, nil)
-	mockReviewers.On("GetPRsByReviewer", ctx, userID).Return(prIDs, nil)
-	mockPR.On("GetByID", ctx, prID1).Return(pr1, nil)
-	mockPR.On("GetByID", ctx, prID2).Return(nil, gorm.ErrRecordNotFound)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Nil(t, result)
+}
 
-	result, err := service.GetUserReviews(ctx, userID)
+// expectEvents expects one outbox write holding events of the given types.
+func expectEvents(t *testing.T, types ...string) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(t)
+	outbox.EXPECT().Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, rows []models2.Outbox) error {
+			recorded := make([]string, 0, len(rows))
+			for _, row := range rows {
+				recorded = append(recorded, row.Type)
+			}
+			assert.Equal(t, types, recorded)
+			return nil
+		}).Once()
+
+	return outbox
+}
 
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
-	assert.Equal(t, prID1, result[0].ID)
+// passthroughTx runs the transaction body directly, as if it committed.
+func passthroughTx(t *testing.T) *mocks.MockTransactor {
+	tx := mocks.NewMockTransactor(t)
+	tx.EXPECT().InTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	return tx
 }
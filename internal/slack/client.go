@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRejected marks a message Slack refused for good, for instance because
+// the webhook was revoked or the payload is invalid. Sending it again cannot
+// succeed.
+var ErrRejected = errors.New("slack rejected the message")
+
+// HTTPClient sends the webhook requests. *http.Client satisfies it; callers
+// may pass their own to add proxies, tracing or a stub in tests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client posts messages to incoming webhooks. Network errors, 5xx and 429
+// responses are retried with a doubling delay, or after the Retry-After Slack
+// asks for. A Post never runs longer than budget: when the next wait would not
+// fit, it gives up with the last error and the event is redelivered later.
+type Client struct {
+	http    HTTPClient
+	retries int
+	backoff time.Duration
+	budget  time.Duration
+}
+
+func NewClient(client HTTPClient, retries int, backoff, budget time.Duration) *Client {
+	return &Client{
+		http:    client,
+		retries: retries,
+		backoff: backoff,
+		budget:  budget,
+	}
+}
+
+func (c *Client) Post(ctx context.Context, url string, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal slack message: %w", err)
+	}
+
+	deadline := time.Now().Add(c.budget)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	delay := c.backoff
+	for attempt := 0; ; attempt++ {
+		wait, err := c.post(ctx, url, body)
+		if err == nil || errors.Is(err, ErrRejected) || attempt >= c.retries {
+			return err
+		}
+
+		if wait == 0 {
+			wait = delay
+			delay *= 2
+		}
+		if time.Until(deadline) < wait {
+			return fmt.Errorf("%w; retry in %s does not fit the delivery budget", err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// post makes one attempt. On a rate limit it also returns how long Slack
+// asked to wait.
+func (c *Client) post(ctx context.Context, url string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("%w: build request: %v", ErrRejected, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post slack webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Slack explains errors in a short plain-text body, like "no_service".
+	reason, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return time.Duration(seconds) * time.Second, fmt.Errorf("slack webhook rate limited")
+	case resp.StatusCode >= 500:
+		return 0, fmt.Errorf("slack webhook responded %s: %s", resp.Status, reason)
+	default:
+		return 0, fmt.Errorf("%w: %s: %s", ErrRejected, resp.Status, reason)
+	}
+}
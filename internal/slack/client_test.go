@@ -0,0 +1,94 @@
+package slack_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/slack"
+)
+
+func TestClient_RetriesRateLimitsAndServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := slack.NewClient(server.Client(), 2, time.Millisecond, time.Minute)
+
+	require.NoError(t, client.Post(context.Background(), server.URL, slack.Message{Text: "hi"}))
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestClient_DoesNotRetryRejected(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	client := slack.NewClient(server.Client(), 3, time.Millisecond, time.Minute)
+
+	err := client.Post(context.Background(), server.URL, slack.Message{Text: "hi"})
+
+	require.ErrorIs(t, err, slack.ErrRejected)
+	assert.Contains(t, err.Error(), "no_service")
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_GivesUpAfterRetries(t *testing.T) {
+	calls := 0
+	client := slack.NewClient(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return nil, assert.AnError
+	}), 2, time.Millisecond, time.Minute)
+
+	err := client.Post(context.Background(), "http://slack.invalid/hook", slack.Message{Text: "hi"})
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.NotErrorIs(t, err, slack.ErrRejected)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClient_GivesUpWhenRetryAfterExceedsBudget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := slack.NewClient(server.Client(), 3, time.Millisecond, time.Second)
+
+	start := time.Now()
+	err := client.Post(context.Background(), server.URL, slack.Message{Text: "hi"})
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, slack.ErrRejected)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualValues(t, 1, calls.Load())
+}
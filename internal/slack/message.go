@@ -0,0 +1,135 @@
+// Package slack tells people in Slack about the reviews they are asked for.
+// Messages are Block Kit JSON posted to a team's incoming webhook, and users
+// with a Slack member ID are mentioned so that Slack notifies them.
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mPR/internal/storage/models"
+)
+
+// Message is the body of an incoming-webhook request. Text is the fallback
+// shown in notifications and by clients that cannot render blocks.
+type Message struct {
+	Text   string  `json:"text"`
+	Blocks []Block `json:"blocks"`
+}
+
+type Block struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text,omitempty"`
+	Elements []Text `json:"elements,omitempty"`
+}
+
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Pending is a reviewer and the open pull requests waiting for them.
+type Pending struct {
+	Reviewer     models.Users
+	PullRequests []models.PullRequests
+}
+
+var memberID = regexp.MustCompile(`^[UW][A-Z0-9]{2,31}$`)
+
+// ValidMemberID reports whether id looks like a Slack member ID, such as
+// U024BE7LH.
+func ValidMemberID(id string) bool {
+	return memberID.MatchString(id)
+}
+
+func Assignment(pr *models.PullRequests, team string, author, reviewer models.Users) Message {
+	return Message{
+		Text: fmt.Sprintf("%s, please review %s", plain(reviewer), pr.Name),
+		Blocks: []Block{
+			section(fmt.Sprintf("%s, please review %s by %s.", Mention(reviewer), prRef(pr), Mention(author))),
+			footer(team),
+		},
+	}
+}
+
+func Reassignment(pr *models.PullRequests, team string, oldReviewer, newReviewer models.Users) Message {
+	return Message{
+		Text: fmt.Sprintf("%s, please review %s instead of %s", plain(newReviewer), pr.Name, plain(oldReviewer)),
+		Blocks: []Block{
+			section(fmt.Sprintf("%s, please review %s: it moved to you from %s.",
+				Mention(newReviewer), prRef(pr), Mention(oldReviewer))),
+			footer(team),
+		},
+	}
+}
+
+func Reminder(team string, pending []Pending) Message {
+	total := 0
+	blocks := make([]Block, 0, len(pending)+2)
+	blocks = append(blocks, Block{Type: "header", Text: &Text{Type: "plain_text", Text: "Reviews waiting"}})
+
+	for _, p := range pending {
+		total += len(p.PullRequests)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s, %s waiting for you:", Mention(p.Reviewer), count(len(p.PullRequests)))
+		for i := range p.PullRequests {
+			pr := &p.PullRequests[i]
+			fmt.Fprintf(&b, "\n• %s, opened %s", prRef(pr), date(pr))
+		}
+		blocks = append(blocks, section(b.String()))
+	}
+	blocks = append(blocks, footer(team))
+
+	return Message{
+		Text:   fmt.Sprintf("%s waiting in team %s", count(total), team),
+		Blocks: blocks,
+	}
+}
+
+// Mention renders the user as a Slack mention, or by name when their member
+// ID is unknown.
+func Mention(user models.Users) string {
+	if user.SlackID != nil && *user.SlackID != "" {
+		return "<@" + *user.SlackID + ">"
+	}
+
+	return escape(plain(user))
+}
+
+func plain(user models.Users) string {
+	if user.Username != "" {
+		return user.Username
+	}
+
+	return user.ID
+}
+
+func prRef(pr *models.PullRequests) string {
+	return fmt.Sprintf("*%s* (`%s`)", escape(pr.Name), escape(pr.ID))
+}
+
+// date lets each reader's Slack client show the time in their own zone.
+func date(pr *models.PullRequests) string {
+	return fmt.Sprintf("<!date^%d^{date_short_pretty}|%s>", pr.CreatedAt.Unix(), pr.CreatedAt.UTC().Format("2006-01-02"))
+}
+
+func count(n int) string {
+	if n == 1 {
+		return "1 review"
+	}
+
+	return fmt.Sprintf("%d reviews", n)
+}
+
+func section(text string) Block {
+	return Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: text}}
+}
+
+func footer(team string) Block {
+	return Block{Type: "context", Elements: []Text{{Type: "mrkdwn", Text: "Team *" + escape(team) + "*"}}}
+}
+
+// escape keeps user-supplied text from being read as Slack markup.
+var escape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
@@ -0,0 +1,200 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mPR/internal/custom"
+	"mPR/internal/events"
+	"mPR/internal/pagination"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+// reminderJob is the name reminder runs are claimed under.
+const reminderJob = "slack_reminders"
+
+// Notifier posts review requests to the Slack channel of the PR's team. As an
+// events.Sink it announces assignments and reassignments, and Remind lists
+// the reviews still open. Teams without a webhook are skipped.
+type Notifier struct {
+	client       *Client
+	webhooks     map[string]string
+	users        repository.Users
+	pullRequests repository.PullRequests
+	jobRuns      repository.JobRuns
+	log          *zap.Logger
+}
+
+func NewNotifier(
+	client *Client,
+	webhooks map[string]string,
+	users repository.Users,
+	pullRequests repository.PullRequests,
+	jobRuns repository.JobRuns,
+	log *zap.Logger,
+) *Notifier {
+	return &Notifier{
+		client:       client,
+		webhooks:     webhooks,
+		users:        users,
+		pullRequests: pullRequests,
+		jobRuns:      jobRuns,
+		log:          log,
+	}
+}
+
+func (n *Notifier) Name() string {
+	return "slack"
+}
+
+func (n *Notifier) Deliver(ctx context.Context, event events.Event) error {
+	url, ok := n.webhooks[event.TeamName]
+	if !ok {
+		return nil
+	}
+
+	pr := &models.PullRequests{ID: event.PullRequestID, Name: event.PullRequestName}
+
+	var msg Message
+	switch event.Type {
+	case events.TypeReviewerAssigned:
+		author, err := n.user(ctx, event.AuthorID)
+		if err != nil {
+			return err
+		}
+		reviewer, err := n.user(ctx, event.ReviewerID)
+		if err != nil {
+			return err
+		}
+		msg = Assignment(pr, event.TeamName, author, reviewer)
+	case events.TypeReviewerReassigned:
+		oldReviewer, err := n.user(ctx, event.OldReviewerID)
+		if err != nil {
+			return err
+		}
+		newReviewer, err := n.user(ctx, event.ReviewerID)
+		if err != nil {
+			return err
+		}
+		msg = Reassignment(pr, event.TeamName, oldReviewer, newReviewer)
+	default:
+		return nil
+	}
+
+	return n.send(ctx, event.TeamName, url, msg)
+}
+
+// Remind posts to every team with a webhook the open reviews of its active
+// members. Nothing is posted to a team where no one has a review waiting.
+func (n *Notifier) Remind(ctx context.Context) error {
+	teams := make([]string, 0, len(n.webhooks))
+	for team := range n.webhooks {
+		teams = append(teams, team)
+	}
+	slices.Sort(teams)
+
+	var errs []error
+	for _, team := range teams {
+		pending, err := n.pending(ctx, team)
+		if err == nil && len(pending) > 0 {
+			err = n.send(ctx, team, n.webhooks[team], Reminder(team, pending))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("remind team %s: %w", team, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RemindAt sends the reminders of the run scheduled at slot, unless another
+// replica has claimed that run already.
+func (n *Notifier) RemindAt(ctx context.Context, slot time.Time) error {
+	claimed, err := n.jobRuns.Claim(ctx, reminderJob, slot, time.Now())
+	if err != nil {
+		return fmt.Errorf("claim reminder run: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	return n.Remind(ctx)
+}
+
+// RunReminders calls RemindAt at every multiple of interval until ctx is
+// done. Every replica keeps the same schedule, so each run is sent by one.
+func (n *Notifier) RunReminders(ctx context.Context, interval time.Duration) {
+	for {
+		slot := time.Now().Truncate(interval).Add(interval)
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := n.RemindAt(ctx, slot); err != nil && ctx.Err() == nil {
+			n.log.Error("Error send review reminders", zap.Error(err))
+		}
+	}
+}
+
+func (n *Notifier) pending(ctx context.Context, team string) ([]Pending, error) {
+	members, err := n.users.GetActiveByTeam(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("get active members: %w", err)
+	}
+	slices.SortFunc(members, func(a, b models.Users) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	var pending []Pending
+	for _, member := range members {
+		prs, err := n.pullRequests.GetByReviewer(ctx, member.ID, models.ReviewFilter{
+			Status: custom.StatusOpen,
+			Limit:  pagination.MaxLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get open reviews of %s: %w", member.ID, err)
+		}
+		if len(prs) > 0 {
+			pending = append(pending, Pending{Reviewer: member, PullRequests: prs})
+		}
+	}
+
+	return pending, nil
+}
+
+// user loads the profile to mention. Someone missing from storage is still
+// named by ID rather than holding up the message.
+func (n *Notifier) user(ctx context.Context, id string) (models.Users, error) {
+	user, err := n.users.GetByID(ctx, id)
+	if errors.Is(err, custom.ErrNotFound) {
+		return models.Users{ID: id}, nil
+	}
+	if err != nil {
+		return models.Users{}, fmt.Errorf("get user %s: %w", id, err)
+	}
+
+	return *user, nil
+}
+
+// send drops messages Slack rejected for good: redelivery would not fix a
+// revoked webhook and would hold up the other sinks behind it.
+func (n *Notifier) send(ctx context.Context, team, url string, msg Message) error {
+	err := n.client.Post(ctx, url, msg)
+	if errors.Is(err, ErrRejected) {
+		n.log.Error("Slack rejected notification", zap.String("team", team), zap.Error(err))
+		return nil
+	}
+
+	return err
+}
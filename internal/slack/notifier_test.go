@@ -0,0 +1,200 @@
+package slack_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/internal/events"
+	"mPR/internal/slack"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/memory"
+)
+
+// stub is a local stand-in for Slack that records the messages posted to it.
+type stub struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages map[string][]slack.Message
+}
+
+func newStub(t *testing.T) *stub {
+	s := &stub{messages: make(map[string][]slack.Message)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slack.Message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid_payload"))
+			return
+		}
+
+		s.mu.Lock()
+		s.messages[r.URL.Path] = append(s.messages[r.URL.Path], msg)
+		s.mu.Unlock()
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *stub) posted(path string) []slack.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.messages[path]
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func seed(t *testing.T) *repository.All {
+	t.Helper()
+
+	ctx := context.Background()
+	repos := memory.New(0)
+	require.NoError(t, repos.Teams.Create(ctx, &models.Teams{Name: "backend"}))
+	require.NoError(t, repos.Teams.Create(ctx, &models.Teams{Name: "frontend"}))
+	require.NoError(t, repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{
+		{ID: "u1", Username: "Alice", IsActive: true, SlackID: ptr("U0ALICE")},
+		{ID: "u2", Username: "Bob", IsActive: true, SlackID: ptr("U0BOB")},
+		{ID: "u3", Username: "<Carol>", IsActive: true},
+	}))
+
+	return repos
+}
+
+func newNotifier(repos *repository.All, server *stub) *slack.Notifier {
+	client := slack.NewClient(server.Client(), 1, time.Millisecond, time.Minute)
+	webhooks := map[string]string{"backend": server.URL + "/backend"}
+
+	return slack.NewNotifier(client, webhooks, repos.Users, repos.PullRequests, repos.JobRuns, zap.NewNop())
+}
+
+func TestNotifier_AssignmentMentionsReviewer(t *testing.T) {
+	server := newStub(t)
+	notifier := newNotifier(seed(t), server)
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(context.Background(), events.ReviewerAssigned(pr, "backend", "u2")))
+
+	messages := server.posted("/backend")
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Bob, please review Add search", messages[0].Text)
+	assert.Equal(t, "section", messages[0].Blocks[0].Type)
+	assert.Equal(t, "<@U0BOB>, please review *Add search* (`pr-1`) by <@U0ALICE>.", messages[0].Blocks[0].Text.Text)
+	assert.Equal(t, "context", messages[0].Blocks[1].Type)
+}
+
+func TestNotifier_ReassignmentFallsBackToEscapedName(t *testing.T) {
+	server := newStub(t)
+	notifier := newNotifier(seed(t), server)
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Fix <script> & co", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(context.Background(), events.ReviewerReassigned(pr, "backend", "u2", "u3")))
+
+	messages := server.posted("/backend")
+	require.Len(t, messages, 1)
+	assert.Equal(t,
+		"&lt;Carol&gt;, please review *Fix &lt;script&gt; &amp; co* (`pr-1`): it moved to you from <@U0BOB>.",
+		messages[0].Blocks[0].Text.Text,
+	)
+}
+
+func TestNotifier_SkipsOtherEventsAndTeams(t *testing.T) {
+	server := newStub(t)
+	notifier := newNotifier(seed(t), server)
+	ctx := context.Background()
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	require.NoError(t, notifier.Deliver(ctx, events.PRMerged(pr, "backend")))
+	require.NoError(t, notifier.Deliver(ctx, events.ReviewerAssigned(pr, "frontend", "u2")))
+
+	assert.Empty(t, server.posted("/backend"))
+	assert.Empty(t, server.posted("/frontend"))
+}
+
+func TestNotifier_DropsRejectedMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte("channel_is_archived"))
+	}))
+	defer server.Close()
+
+	client := slack.NewClient(server.Client(), 3, time.Millisecond, time.Minute)
+	repos := seed(t)
+	notifier := slack.NewNotifier(client, map[string]string{"backend": server.URL}, repos.Users, repos.PullRequests, repos.JobRuns, zap.NewNop())
+
+	pr := &models.PullRequests{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	assert.NoError(t, notifier.Deliver(context.Background(), events.ReviewerAssigned(pr, "backend", "u2")))
+}
+
+func TestNotifier_RemindListsOpenReviews(t *testing.T) {
+	server := newStub(t)
+	repos := seed(t)
+	notifier := newNotifier(repos, server)
+	ctx := context.Background()
+
+	created := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, pr := range []models.PullRequests{
+		{ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: "OPEN", CreatedAt: created},
+		{ID: "pr-2", Name: "Fix login", AuthorID: "u1", Status: "OPEN", CreatedAt: created},
+	} {
+		require.NoError(t, repos.PullRequests.Create(ctx, &pr))
+		require.NoError(t, repos.Reviewers.Add(ctx, []models.Reviewers{{PRID: pr.ID, ReviewerID: "u2"}}))
+	}
+
+	require.NoError(t, notifier.Remind(ctx))
+
+	messages := server.posted("/backend")
+	require.Len(t, messages, 1)
+	assert.Equal(t, "2 reviews waiting in team backend", messages[0].Text)
+	require.Len(t, messages[0].Blocks, 3)
+	assert.Equal(t, "header", messages[0].Blocks[0].Type)
+	text := messages[0].Blocks[1].Text.Text
+	assert.Contains(t, text, "<@U0BOB>, 2 reviews waiting for you:")
+	assert.Contains(t, text, "*Add search* (`pr-1`), opened <!date^1746093600^{date_short_pretty}|2025-05-01>")
+	assert.Contains(t, text, "*Fix login* (`pr-2`)")
+}
+
+func TestNotifier_RemindAtSendsEachRunOnce(t *testing.T) {
+	server := newStub(t)
+	repos := seed(t)
+	ctx := context.Background()
+
+	created := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, repos.PullRequests.Create(ctx, &models.PullRequests{
+		ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: "OPEN", CreatedAt: created,
+	}))
+	require.NoError(t, repos.Reviewers.Add(ctx, []models.Reviewers{{PRID: "pr-1", ReviewerID: "u2"}}))
+
+	// Two replicas wake up for the same run.
+	slot := time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, newNotifier(repos, server).RemindAt(ctx, slot))
+	require.NoError(t, newNotifier(repos, server).RemindAt(ctx, slot))
+	assert.Len(t, server.posted("/backend"), 1)
+
+	require.NoError(t, newNotifier(repos, server).RemindAt(ctx, slot.Add(time.Hour)))
+	assert.Len(t, server.posted("/backend"), 2)
+}
+
+func TestNotifier_RemindSkipsTeamsWithNothingWaiting(t *testing.T) {
+	server := newStub(t)
+	notifier := newNotifier(seed(t), server)
+
+	require.NoError(t, notifier.Remind(context.Background()))
+
+	assert.Empty(t, server.posted("/backend"))
+}
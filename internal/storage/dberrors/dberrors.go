@@ -0,0 +1,85 @@
+// Package dberrors turns ORM and driver errors into the storage errors from
+// custom, so services can tell a missing row from a duplicate key without
+// knowing which database is behind the repositories.
+package dberrors
+
+import (
+	"errors"
+	"fmt"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"mPR/internal/custom"
+)
+
+const name = "dberrors:translate"
+
+// Translate wraps err in the matching custom error and keeps the original in
+// the chain for logs. Errors it does not recognise are returned unchanged.
+func Translate(err error) error {
+	var target error
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, custom.ErrNotFound), errors.Is(err, custom.ErrDuplicateKey),
+		errors.Is(err, custom.ErrForeignKey), errors.Is(err, custom.ErrSerialization):
+		return err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		target = custom.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		target = custom.ErrDuplicateKey
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		target = custom.ErrForeignKey
+	case isSerializationFailure(err):
+		target = custom.ErrSerialization
+	default:
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", target, err)
+}
+
+// A Postgres serialization failure or deadlock, or a SQLite database that
+// stayed locked past busy_timeout, means the statement may succeed on retry.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+
+	var liteErr *gosqlite.Error
+	if errors.As(err, &liteErr) {
+		code := liteErr.Code() & 0xff
+		return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+	}
+
+	return false
+}
+
+// Plugin applies Translate to the error of every statement run through the
+// gorm.DB it is registered on.
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return name
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	return errors.Join(
+		callbacks.Create().After("*").Register(name, translate),
+		callbacks.Query().After("*").Register(name, translate),
+		callbacks.Update().After("*").Register(name, translate),
+		callbacks.Delete().After("*").Register(name, translate),
+		callbacks.Row().After("*").Register(name, translate),
+		callbacks.Raw().After("*").Register(name, translate),
+	)
+}
+
+func translate(db *gorm.DB) {
+	db.Error = Translate(db.Error)
+}
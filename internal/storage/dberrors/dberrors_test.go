@@ -0,0 +1,67 @@
+package dberrors_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"mPR/internal/config"
+	"mPR/internal/custom"
+	"mPR/internal/storage/dberrors"
+	"mPR/internal/storage/sqlite"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found", gorm.ErrRecordNotFound, custom.ErrNotFound},
+		{"duplicate key", gorm.ErrDuplicatedKey, custom.ErrDuplicateKey},
+		{"foreign key", gorm.ErrForeignKeyViolated, custom.ErrForeignKey},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, custom.ErrSerialization},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, custom.ErrSerialization},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dberrors.Translate(tt.err)
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.err, "original error stays in the chain")
+			assert.Equal(t, err, dberrors.Translate(err), "translating twice changes nothing")
+		})
+	}
+}
+
+func TestTranslate_PassesOtherErrorsThrough(t *testing.T) {
+	other := errors.New("connection refused")
+	pgErr := &pgconn.PgError{Code: "42P01"}
+
+	assert.NoError(t, dberrors.Translate(nil))
+	assert.Same(t, other, dberrors.Translate(other))
+	assert.Same(t, pgErr, dberrors.Translate(pgErr))
+}
+
+func TestPlugin_TranslatesStatementErrors(t *testing.T) {
+	db := sqlite.New(config.SQLite{Path: filepath.Join(t.TempDir(), "test.db")}, zap.NewNop())
+	require.NoError(t, db.Exec("CREATE TABLE items (id TEXT PRIMARY KEY)").Error)
+
+	type item struct {
+		ID string `gorm:"column:id;primaryKey"`
+	}
+
+	err := db.Table("items").First(&item{}, "id = ?", "missing").Error
+	assert.ErrorIs(t, err, custom.ErrNotFound)
+
+	require.NoError(t, db.Table("items").Create(&item{ID: "a"}).Error)
+	err = db.Table("items").Create(&item{ID: "a"}).Error
+	assert.ErrorIs(t, err, custom.ErrDuplicateKey)
+}
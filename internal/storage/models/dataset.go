@@ -0,0 +1,11 @@
+package models
+
+// Dataset is the full content of the domain tables, used for snapshots.
+type Dataset struct {
+	Teams         []Teams
+	Users         []Users
+	PullRequests  []PullRequests
+	Reviewers     []Reviewers
+	Reassignments []Reassignments
+	Reviews       []Reviews
+}
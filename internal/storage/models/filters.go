@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	SortCreatedAt = "created_at"
+	SortName      = "name"
+	SortID        = "id"
+)
+
+// PullRequestFilter selects a page of pull requests. Rows are ordered by Sort
+// with the PR ID as a tie-breaker, and After is the last row of the previous page.
+type PullRequestFilter struct {
+	Status      string
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Search      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	Sort        string
+	Desc        bool
+	After       *PullRequests
+	Limit       int
+}
+
+// ReviewFilter selects a page of the PRs a user reviews, newest first. After
+// is the last row of the previous page.
+type ReviewFilter struct {
+	Status string
+	After  *PullRequests
+	Limit  int
+}
+
+// TeamFilter selects a page of teams ordered by name.
+type TeamFilter struct {
+	After string
+	Limit int
+}
+
+// UserFilter selects a page of users ordered by ID.
+type UserFilter struct {
+	TeamName       string
+	IsActive       *bool
+	UsernamePrefix string
+	After          string
+	Limit          int
+}
+
+// EventFilter selects sequenced outbox events with Seq above After, in Seq
+// order.
+type EventFilter struct {
+	After    int64
+	TeamName string
+	Limit    int
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type IdempotencyKeys struct {
+	Key         string    `gorm:"column:idempotency_key;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	StatusCode  int       `gorm:"column:status_code"`
+	Body        []byte    `gorm:"column:response_body"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
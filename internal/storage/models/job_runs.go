@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// JobRuns marks one run of a scheduled job as taken by a replica. Slot is
+// the scheduled time, the same on every replica.
+type JobRuns struct {
+	Job       string    `gorm:"column:job;primaryKey"`
+	Slot      time.Time `gorm:"column:slot;primaryKey"`
+	ClaimedAt time.Time `gorm:"column:claimed_at"`
+}
+
+func (JobRuns) TableName() string {
+	return "job_runs"
+}
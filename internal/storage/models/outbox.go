@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Outbox is a recorded domain event. IDs are handed out on insert, so they do
+// not follow commit order; Seq does, and is nil until the event is sequenced
+// after its transaction commits. DispatchedAt stays nil until every sink has
+// taken the event. A dispatcher owns an undelivered event until ClaimedUntil
+// passes.
+type Outbox struct {
+	ID           int64      `gorm:"column:id;primaryKey"`
+	Seq          *int64     `gorm:"column:seq"`
+	Type         string     `gorm:"column:event_type"`
+	TeamName     *string    `gorm:"column:team_name"`
+	Payload      string     `gorm:"column:payload"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	DispatchedAt *time.Time `gorm:"column:dispatched_at"`
+}
+
+func (Outbox) TableName() string {
+	return "outbox"
+}
+
+// OutboxDelivery records that one sink took an event its dispatcher could not
+// deliver everywhere, so a retry skips that sink.
+type OutboxDelivery struct {
+	EventID     int64     `gorm:"column:event_id;primaryKey"`
+	Sink        string    `gorm:"column:sink;primaryKey"`
+	DeliveredAt time.Time `gorm:"column:delivered_at"`
+}
+
+func (OutboxDelivery) TableName() string {
+	return "outbox_deliveries"
+}
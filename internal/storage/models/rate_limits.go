@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type RateLimits struct {
+	Key       string    `gorm:"column:bucket_key;primaryKey"`
+	Tokens    float64   `gorm:"column:tokens"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime:false"`
+	FullAt    time.Time `gorm:"column:full_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Reassignments struct {
+	ID            int64     `gorm:"column:id;primaryKey"`
+	PRID          string    `gorm:"column:pr_id"`
+	OldReviewerID string    `gorm:"column:old_reviewer_id"`
+	NewReviewerID string    `gorm:"column:new_reviewer_id"`
+	OldAssignedAt time.Time `gorm:"column:old_assigned_at"`
+	ReassignedAt  time.Time `gorm:"column:reassigned_at;autoCreateTime"`
+}
+
+func (Reassignments) TableName() string {
+	return "reviewer_reassignments"
+}
@@ -1,6 +1,9 @@
 package models
 
+import "time"
+
 type Reviewers struct {
-	PRID       string `gorm:"column:pr_id;primaryKey" json:"pr_id"`
-	ReviewerID string `gorm:"column:reviewer_id;primaryKey" json:"reviewer_id"`
+	PRID       string    `gorm:"column:pr_id;primaryKey" json:"pr_id"`
+	ReviewerID string    `gorm:"column:reviewer_id;primaryKey" json:"reviewer_id"`
+	AssignedAt time.Time `gorm:"column:assigned_at;autoCreateTime" json:"assigned_at"`
 }
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Reviews struct {
+	ID          int64     `gorm:"column:id;primaryKey" json:"-"`
+	PRID        string    `gorm:"column:pr_id" json:"pull_request_id"`
+	ReviewerID  string    `gorm:"column:reviewer_id" json:"reviewer_id"`
+	Decision    string    `gorm:"column:decision" json:"decision"`
+	SubmittedAt time.Time `gorm:"column:submitted_at;autoCreateTime" json:"submitted_at"`
+}
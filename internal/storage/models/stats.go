@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// StatsWindow limits statistics to assignments made in [From, To).
+type StatsWindow struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+}
+
+// ReviewerStats is the review workload of one user. Assignments and decision
+// times cover the window, OpenReviews is the current load. MergedUnreviewed
+// counts assignments whose PR was merged before the reviewer decided.
+type ReviewerStats struct {
+	UserID             string   `gorm:"column:user_id" json:"user_id"`
+	Username           string   `gorm:"column:username" json:"username"`
+	TeamName           *string  `gorm:"column:team_name" json:"team_name,omitempty"`
+	IsActive           bool     `gorm:"column:is_active" json:"is_active"`
+	Assignments        int64    `gorm:"column:assignments" json:"assignments"`
+	Decided            int64    `gorm:"column:decided" json:"decided"`
+	AvgDecisionSeconds *float64 `gorm:"column:avg_decision_seconds" json:"avg_decision_seconds"`
+	MergedUnreviewed   int64    `gorm:"column:merged_unreviewed" json:"merged_unreviewed"`
+	OpenReviews        int64    `gorm:"column:open_reviews" json:"open_reviews"`
+	ReassignedFrom     int64    `gorm:"column:reassigned_from" json:"reassigned_from"`
+	ReassignedTo       int64    `gorm:"column:reassigned_to" json:"reassigned_to"`
+}
+
+// CycleTimeStats holds cycle-time percentiles in seconds for the PRs a team
+// opened in one week, or in the whole window when Week is nil.
+type CycleTimeStats struct {
+	TeamName         string     `gorm:"column:team_name"`
+	Week             *time.Time `gorm:"column:week"`
+	PullRequests     int64      `gorm:"column:pull_requests"`
+	FirstReviewCount int64      `gorm:"column:first_review_count"`
+	FirstReviewP50   *float64   `gorm:"column:first_review_p50"`
+	FirstReviewP75   *float64   `gorm:"column:first_review_p75"`
+	FirstReviewP90   *float64   `gorm:"column:first_review_p90"`
+	ApprovalCount    int64      `gorm:"column:approval_count"`
+	ApprovalP50      *float64   `gorm:"column:approval_p50"`
+	ApprovalP75      *float64   `gorm:"column:approval_p75"`
+	ApprovalP90      *float64   `gorm:"column:approval_p90"`
+	MergeCount       int64      `gorm:"column:merged_count"`
+	MergeP50         *float64   `gorm:"column:merged_p50"`
+	MergeP75         *float64   `gorm:"column:merged_p75"`
+	MergeP90         *float64   `gorm:"column:merged_p90"`
+}
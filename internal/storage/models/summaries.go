@@ -0,0 +1,14 @@
+package models
+
+// TeamSummary is a team with its member counts.
+type TeamSummary struct {
+	Name          string `gorm:"column:team_name" json:"team_name"`
+	Members       int64  `gorm:"column:members" json:"members"`
+	ActiveMembers int64  `gorm:"column:active_members" json:"active_members"`
+}
+
+// UserSummary is a user with the number of open PRs they are reviewing.
+type UserSummary struct {
+	Users
+	OpenReviews int64 `gorm:"column:open_reviews" json:"open_reviews"`
+}
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"mPR/internal/config"
+	"mPR/internal/storage/dberrors"
 )
 
 func New(cfg config.Database, log *zap.Logger) *gorm.DB {
@@ -16,12 +17,17 @@ func New(cfg config.Database, log *zap.Logger) *gorm.DB {
 		cfg.Host, cfg.Username, cfg.Password, cfg.Name, cfg.Port, cfg.Mode,
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Panic("Error connect database", zap.Error(err))
 		return nil
 	}
 
+	if err := db.Use(dberrors.Plugin{}); err != nil {
+		log.Panic("Error register error translation", zap.Error(err))
+		return nil
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		log.Panic("Error receiving sqlDB", zap.Error(err))
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"mPR/internal/storage/repository/transaction"
+)
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+func (d *Database) Stats() sql.DBStats {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return sql.DBStats{}
+	}
+
+	return sqlDB.Stats()
+}
+
+func (d *Database) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var row struct {
+		Version int64
+		Dirty   bool
+	}
+
+	err := transaction.DB(ctx, d.db).
+		Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").
+		Scan(&row).Error
+	if err != nil {
+		return 0, false, err
+	}
+
+	if row.Version < 0 {
+		return 0, false, errors.New("invalid schema version")
+	}
+
+	return uint(row.Version), row.Dirty, nil
+}
@@ -0,0 +1,104 @@
+package idempotency_keys
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mPR/internal/idempotency"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+func (d *Database) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*idempotency.Record, bool, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		now := time.Now()
+
+		// An expired row the sweeper has not reached yet is taken over in place.
+		res := transaction.DB(ctx, d.db).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "idempotency_key"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"request_hash":  requestHash,
+					"status_code":   0,
+					"response_body": nil,
+					"created_at":    now,
+					"expires_at":    now.Add(ttl),
+				}),
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: now},
+				}},
+			}).
+			Create(&models.IdempotencyKeys{
+				Key:         key,
+				RequestHash: requestHash,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			})
+		if res.Error != nil {
+			return nil, false, res.Error
+		}
+
+		if res.RowsAffected == 1 {
+			return nil, true, nil
+		}
+
+		var row models.IdempotencyKeys
+		err := transaction.DB(ctx, d.db).
+			First(&row, "idempotency_key = ?", key).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The holder released the key between the insert and the read,
+			// so it is free again: try to claim it.
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		return &idempotency.Record{
+			Key:         row.Key,
+			RequestHash: row.RequestHash,
+			StatusCode:  row.StatusCode,
+			Body:        row.Body,
+			ExpiresAt:   row.ExpiresAt,
+		}, false, nil
+	}
+}
+
+func (d *Database) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	return transaction.DB(ctx, d.db).
+		Model(&models.IdempotencyKeys{}).
+		Where("idempotency_key = ?", key).
+		Updates(map[string]any{
+			"status_code":   statusCode,
+			"response_body": body,
+		}).Error
+}
+
+func (d *Database) Release(ctx context.Context, key string) error {
+	return transaction.DB(ctx, d.db).
+		Where("idempotency_key = ?", key).
+		Delete(&models.IdempotencyKeys{}).Error
+}
+
+func (d *Database) Sweep(ctx context.Context, now time.Time) error {
+	return transaction.DB(ctx, d.db).
+		Where("expires_at < ?", now).
+		Delete(&models.IdempotencyKeys{}).Error
+}
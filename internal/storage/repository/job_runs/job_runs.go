@@ -0,0 +1,51 @@
+package job_runs
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+// keep is how long claimed runs are remembered. Only a replica whose clock
+// is this far behind could claim a run again.
+const keep = 7 * 24 * time.Hour
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+// Claim takes the run of job at slot and reports whether this caller got it.
+// Runs of the job older than keep are forgotten on the way.
+func (d *Database) Claim(ctx context.Context, job string, slot, now time.Time) (bool, error) {
+	var claimed bool
+	err := transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.JobRuns{Job: job, Slot: slot.UTC(), ClaimedAt: now})
+		if result.Error != nil {
+			return result.Error
+		}
+		claimed = result.RowsAffected == 1
+		if !claimed {
+			return nil
+		}
+
+		return tx.Where("job = ? AND slot < ?", job, slot.Add(-keep).UTC()).
+			Delete(&models.JobRuns{}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Health reports the in-memory store as always reachable, with no connection
+// pool and the schema version it was created with.
+type Health struct {
+	version uint
+}
+
+func (h *Health) Ping(context.Context) error {
+	return nil
+}
+
+func (h *Health) Stats() sql.DBStats {
+	return sql.DBStats{}
+}
+
+func (h *Health) SchemaVersion(context.Context) (uint, bool, error) {
+	return h.version, false, nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// keepJobRuns matches how long the GORM backend remembers claimed runs.
+const keepJobRuns = 7 * 24 * time.Hour
+
+type JobRuns struct {
+	s *store
+}
+
+func (j *JobRuns) Claim(ctx context.Context, job string, slot, now time.Time) (bool, error) {
+	defer j.s.lock(ctx)()
+
+	key := jobRunKey{job: job, slot: slot.UnixNano()}
+	if _, ok := j.s.jobRuns[key]; ok {
+		return false, nil
+	}
+	j.s.jobRuns[key] = now
+
+	for k := range j.s.jobRuns {
+		if k.job == job && k.slot < slot.Add(-keepJobRuns).UnixNano() {
+			delete(j.s.jobRuns, k)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,228 @@
+// Package memory keeps every repository in process memory. It follows the
+// Postgres schema: missing rows return custom.ErrNotFound, primary keys
+// return custom.ErrDuplicateKey and foreign keys custom.ErrForeignKey,
+// matching the translated errors of the GORM backend. Nothing is persisted.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/idempotency"
+	"mPR/internal/ratelimit"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+type deliveryKey struct {
+	eventID int64
+	sink    string
+}
+
+type jobRunKey struct {
+	job  string
+	slot int64
+}
+
+type reviewerKey struct {
+	prID       string
+	reviewerID string
+}
+
+// store is the shared state of all repositories. One lock guards every table
+// so multi-table reads, like snapshots and statistics, see a consistent view.
+// A transaction holds the lock for its whole run, and calls made with its
+// context skip locking.
+type store struct {
+	mu sync.RWMutex
+
+	teams         map[string]struct{}
+	users         map[string]models.Users
+	pullRequests  map[string]models.PullRequests
+	reviewers     map[reviewerKey]models.Reviewers
+	reassignments []models.Reassignments
+	reviews       []models.Reviews
+	outbox        []models.Outbox
+	deliveries    map[deliveryKey]models.OutboxDelivery
+	jobRuns       map[jobRunKey]time.Time
+
+	lastReassignmentID int64
+	lastReviewID       int64
+	lastOutboxID       int64
+
+	now func() time.Time
+}
+
+// New returns repositories backed by one fresh in-memory store. The store
+// always has the schema the binary ships, so it reports schemaVersion.
+func New(schemaVersion uint) *repository.All {
+	s := &store{
+		teams:        make(map[string]struct{}),
+		users:        make(map[string]models.Users),
+		pullRequests: make(map[string]models.PullRequests),
+		reviewers:    make(map[reviewerKey]models.Reviewers),
+		deliveries:   make(map[deliveryKey]models.OutboxDelivery),
+		jobRuns:      make(map[jobRunKey]time.Time),
+		now:          time.Now,
+	}
+
+	return &repository.All{
+		Tx:           s,
+		Teams:        &Teams{s: s},
+		Users:        &Users{s: s},
+		PullRequests: &PullRequests{s: s},
+		Reviewers:    &Reviewers{s: s},
+		RateLimits:   ratelimit.NewMemory(),
+
+		IdempotencyKeys: idempotency.NewMemory(),
+		Health:          &Health{version: schemaVersion},
+		Snapshots:       &Snapshots{s: s},
+		Stats:           &Stats{s: s},
+		Outbox:          &Outbox{s: s},
+		JobRuns:         &JobRuns{s: s},
+	}
+}
+
+type txKey struct{}
+
+// InTx runs fn with the store locked and puts the tables back as they were
+// when fn fails. fn must pass on the context it receives: a call with any
+// other context would wait for the lock the transaction holds.
+func (s *store) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) == s {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := s.clone()
+	if err := fn(context.WithValue(ctx, txKey{}, s)); err != nil {
+		s.restore(saved)
+		return err
+	}
+
+	return nil
+}
+
+func (s *store) lock(ctx context.Context) (unlock func()) {
+	if ctx.Value(txKey{}) == s {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *store) rlock(ctx context.Context) (unlock func()) {
+	if ctx.Value(txKey{}) == s {
+		return func() {}
+	}
+
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// clone copies the tables but not the lock. Stored rows are replaced rather
+// than modified in place, so copying the maps and slices is enough.
+func (s *store) clone() *store {
+	return &store{
+		teams:         maps.Clone(s.teams),
+		users:         maps.Clone(s.users),
+		pullRequests:  maps.Clone(s.pullRequests),
+		reviewers:     maps.Clone(s.reviewers),
+		reassignments: slices.Clone(s.reassignments),
+		reviews:       slices.Clone(s.reviews),
+		outbox:        slices.Clone(s.outbox),
+		deliveries:    maps.Clone(s.deliveries),
+		jobRuns:       maps.Clone(s.jobRuns),
+
+		lastReassignmentID: s.lastReassignmentID,
+		lastReviewID:       s.lastReviewID,
+		lastOutboxID:       s.lastOutboxID,
+	}
+}
+
+func (s *store) restore(saved *store) {
+	s.teams = saved.teams
+	s.users = saved.users
+	s.pullRequests = saved.pullRequests
+	s.reviewers = saved.reviewers
+	s.reassignments = saved.reassignments
+	s.reviews = saved.reviews
+	s.outbox = saved.outbox
+	s.deliveries = saved.deliveries
+	s.jobRuns = saved.jobRuns
+	s.lastReassignmentID = saved.lastReassignmentID
+	s.lastReviewID = saved.lastReviewID
+	s.lastOutboxID = saved.lastOutboxID
+}
+
+// reviewersOf returns the reviewers of a PR ordered by reviewer ID.
+func (s *store) reviewersOf(prID string) []models.Reviewers {
+	list := []models.Reviewers{}
+	for key, r := range s.reviewers {
+		if key.prID == prID {
+			list = append(list, r)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ReviewerID < list[j].ReviewerID })
+	return list
+}
+
+// withReviewers copies a stored PR and attaches its reviewers, like a
+// Preload("Reviewers") does.
+func (s *store) withReviewers(pr models.PullRequests) models.PullRequests {
+	pr.MergedAt = copyTime(pr.MergedAt)
+	pr.Reviewers = s.reviewersOf(pr.ID)
+	return pr
+}
+
+func (s *store) isOpen(prID string) bool {
+	pr, ok := s.pullRequests[prID]
+	return ok && pr.Status == custom.StatusOpen
+}
+
+// stamp fills a zero timestamp the way autoCreateTime does.
+func (s *store) stamp(t *time.Time) {
+	if t.IsZero() {
+		*t = s.now()
+	}
+}
+
+func copyUser(u models.Users) models.Users {
+	u.TeamName = copyString(u.TeamName)
+	u.SlackID = copyString(u.SlackID)
+	u.Email = copyString(u.Email)
+	u.Team = nil
+	return u
+}
+
+func copyString(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyTime(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func limit[T any](rows []T, n int) []T {
+	if n > 0 && len(rows) > n {
+		return rows[:n]
+	}
+	return rows
+}
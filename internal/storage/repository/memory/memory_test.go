@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/memory"
+	"mPR/internal/storage/repository/repositorytest"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, func(*testing.T) *repository.All {
+		return memory.New(1)
+	})
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type Outbox struct {
+	s *store
+}
+
+func (o *Outbox) Add(ctx context.Context, events []models.Outbox) error {
+	defer o.s.lock(ctx)()
+
+	for i := range events {
+		o.s.lastOutboxID++
+		events[i].ID = o.s.lastOutboxID
+		// A transaction holds the store until it commits, so IDs already
+		// follow commit order.
+		seq := o.s.lastOutboxID
+		events[i].Seq = &seq
+		if events[i].CreatedAt.IsZero() {
+			events[i].CreatedAt = o.s.now()
+		}
+		o.s.outbox = append(o.s.outbox, events[i])
+	}
+
+	return nil
+}
+
+// Sequence has nothing to do: Add numbers every event.
+func (o *Outbox) Sequence(ctx context.Context) error {
+	return nil
+}
+
+func (o *Outbox) Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]models.Outbox, error) {
+	defer o.s.lock(ctx)()
+
+	until := now.Add(lease)
+	events := make([]models.Outbox, 0, limit)
+	for i, event := range o.s.outbox {
+		if len(events) == limit {
+			break
+		}
+		if event.DispatchedAt != nil || (event.ClaimedUntil != nil && !event.ClaimedUntil.Before(now)) {
+			continue
+		}
+
+		o.s.outbox[i].ClaimedUntil = &until
+		events = append(events, o.s.outbox[i])
+	}
+
+	return events, nil
+}
+
+func (o *Outbox) MarkDispatched(ctx context.Context, ids []int64, at time.Time) error {
+	defer o.s.lock(ctx)()
+
+	marked := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		marked[id] = struct{}{}
+	}
+
+	for i, event := range o.s.outbox {
+		if _, ok := marked[event.ID]; ok {
+			o.s.outbox[i].DispatchedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func (o *Outbox) MarkDelivered(ctx context.Context, deliveries []models.OutboxDelivery) error {
+	defer o.s.lock(ctx)()
+
+	for _, d := range deliveries {
+		if !slices.ContainsFunc(o.s.outbox, func(e models.Outbox) bool { return e.ID == d.EventID }) {
+			return custom.ErrForeignKey
+		}
+
+		key := deliveryKey{eventID: d.EventID, sink: d.Sink}
+		if _, ok := o.s.deliveries[key]; !ok {
+			o.s.deliveries[key] = d
+		}
+	}
+
+	return nil
+}
+
+func (o *Outbox) Deliveries(ctx context.Context, ids []int64) (map[int64][]string, error) {
+	defer o.s.rlock(ctx)()
+
+	delivered := make(map[int64][]string)
+	for _, id := range ids {
+		for key := range o.s.deliveries {
+			if key.eventID == id {
+				delivered[id] = append(delivered[id], key.sink)
+			}
+		}
+		slices.Sort(delivered[id])
+	}
+
+	return delivered, nil
+}
+
+func (o *Outbox) List(ctx context.Context, f models.EventFilter) ([]models.Outbox, error) {
+	defer o.s.rlock(ctx)()
+
+	events := make([]models.Outbox, 0)
+	for _, event := range o.s.outbox {
+		if len(events) == f.Limit {
+			break
+		}
+		if *event.Seq <= f.After {
+			continue
+		}
+		if f.TeamName != "" && (event.TeamName == nil || *event.TeamName != f.TeamName) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (o *Outbox) LastSeq(ctx context.Context) (int64, error) {
+	defer o.s.rlock(ctx)()
+
+	return o.s.lastOutboxID, nil
+}
@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type PullRequests struct {
+	s *store
+}
+
+func (p *PullRequests) Create(ctx context.Context, pr *models.PullRequests) error {
+	defer p.s.lock(ctx)()
+
+	if _, ok := p.s.pullRequests[pr.ID]; ok {
+		return custom.ErrDuplicateKey
+	}
+	if _, ok := p.s.users[pr.AuthorID]; !ok {
+		return custom.ErrForeignKey
+	}
+
+	p.s.stamp(&pr.CreatedAt)
+	p.s.savePR(pr)
+
+	return nil
+}
+
+func (p *PullRequests) GetByID(ctx context.Context, id string) (*models.PullRequests, error) {
+	defer p.s.rlock(ctx)()
+
+	stored, ok := p.s.pullRequests[id]
+	if !ok {
+		return nil, custom.ErrNotFound
+	}
+
+	pr := p.s.withReviewers(stored)
+	pr.Author = copyUser(p.s.users[pr.AuthorID])
+
+	return &pr, nil
+}
+
+// GetByIDForUpdate needs no lock of its own: a transaction holds the whole
+// store.
+func (p *PullRequests) GetByIDForUpdate(ctx context.Context, id string) (*models.PullRequests, error) {
+	return p.GetByID(ctx, id)
+}
+
+// Update saves every column of the PR, inserting it when it is missing, as
+// GORM's Save does.
+func (p *PullRequests) Update(ctx context.Context, pr *models.PullRequests) error {
+	defer p.s.lock(ctx)()
+
+	if _, ok := p.s.users[pr.AuthorID]; !ok {
+		return custom.ErrForeignKey
+	}
+
+	p.s.savePR(pr)
+	return nil
+}
+
+// savePR stores the PR columns without associations. The caller holds the
+// write lock.
+func (s *store) savePR(pr *models.PullRequests) {
+	stored := *pr
+	stored.MergedAt = copyTime(pr.MergedAt)
+	stored.Author = models.Users{}
+	stored.Reviewers = nil
+
+	s.pullRequests[pr.ID] = stored
+}
+
+func (p *PullRequests) AddReviewers(ctx context.Context, reviewers []models.Reviewers) error {
+	defer p.s.lock(ctx)()
+
+	return p.s.addReviewers(reviewers)
+}
+
+func (p *PullRequests) GetReviewers(ctx context.Context, prID string) ([]models.Reviewers, error) {
+	defer p.s.rlock(ctx)()
+
+	return p.s.reviewersOf(prID), nil
+}
+
+func (p *PullRequests) ReplaceReviewer(ctx context.Context, prID string, oldID, newID string) error {
+	defer p.s.lock(ctx)()
+
+	delete(p.s.reviewers, reviewerKey{prID: prID, reviewerID: oldID})
+	return p.s.addReviewers([]models.Reviewers{{PRID: prID, ReviewerID: newID}})
+}
+
+func (p *PullRequests) GetByReviewer(ctx context.Context, reviewerID string, f models.ReviewFilter) ([]models.PullRequests, error) {
+	defer p.s.rlock(ctx)()
+
+	prs := []models.PullRequests{}
+	for key := range p.s.reviewers {
+		if key.reviewerID != reviewerID {
+			continue
+		}
+
+		pr := p.s.pullRequests[key.prID]
+		if f.Status != "" && pr.Status != f.Status {
+			continue
+		}
+		if f.After != nil && !before(pr, *f.After) {
+			continue
+		}
+
+		prs = append(prs, pr)
+	}
+
+	sort.Slice(prs, func(i, j int) bool { return before(prs[j], prs[i]) })
+
+	return p.s.attachReviewers(limit(prs, f.Limit)), nil
+}
+
+// before orders PRs by creation time, then by ID.
+func before(a, b models.PullRequests) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+func (p *PullRequests) List(ctx context.Context, f models.PullRequestFilter) ([]models.PullRequests, error) {
+	defer p.s.rlock(ctx)()
+
+	less := sortOrder(f.Sort)
+	search := strings.ToLower(f.Search)
+
+	prs := []models.PullRequests{}
+	for _, pr := range p.s.pullRequests {
+		if f.Status != "" && pr.Status != f.Status {
+			continue
+		}
+		if f.AuthorID != "" && pr.AuthorID != f.AuthorID {
+			continue
+		}
+		if f.ReviewerID != "" {
+			if _, ok := p.s.reviewers[reviewerKey{prID: pr.ID, reviewerID: f.ReviewerID}]; !ok {
+				continue
+			}
+		}
+		if f.TeamName != "" {
+			author := p.s.users[pr.AuthorID]
+			if author.TeamName == nil || *author.TeamName != f.TeamName {
+				continue
+			}
+		}
+		if !strings.Contains(strings.ToLower(pr.Name), search) {
+			continue
+		}
+		if f.CreatedFrom != nil && pr.CreatedAt.Before(*f.CreatedFrom) {
+			continue
+		}
+		if f.CreatedTo != nil && !pr.CreatedAt.Before(*f.CreatedTo) {
+			continue
+		}
+		if f.MergedFrom != nil && (pr.MergedAt == nil || pr.MergedAt.Before(*f.MergedFrom)) {
+			continue
+		}
+		if f.MergedTo != nil && (pr.MergedAt == nil || !pr.MergedAt.Before(*f.MergedTo)) {
+			continue
+		}
+		if f.After != nil {
+			if f.Desc && !less(pr, *f.After) || !f.Desc && !less(*f.After, pr) {
+				continue
+			}
+		}
+
+		prs = append(prs, pr)
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		if f.Desc {
+			return less(prs[j], prs[i])
+		}
+		return less(prs[i], prs[j])
+	})
+
+	return p.s.attachReviewers(limit(prs, f.Limit)), nil
+}
+
+// sortOrder returns the ordering of a List sort key; every key falls back to
+// the PR ID so pages never overlap.
+func sortOrder(key string) func(a, b models.PullRequests) bool {
+	switch key {
+	case models.SortID:
+		return func(a, b models.PullRequests) bool { return a.ID < b.ID }
+	case models.SortName:
+		return func(a, b models.PullRequests) bool {
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID < b.ID
+		}
+	default:
+		return before
+	}
+}
+
+func (s *store) attachReviewers(prs []models.PullRequests) []models.PullRequests {
+	for i := range prs {
+		prs[i] = s.withReviewers(prs[i])
+	}
+	return prs
+}
+
+func (p *PullRequests) CountOpenByTeam(ctx context.Context) (map[string]int64, error) {
+	defer p.s.rlock(ctx)()
+
+	counts := make(map[string]int64)
+	for _, pr := range p.s.pullRequests {
+		if pr.Status != custom.StatusOpen {
+			continue
+		}
+		if author := p.s.users[pr.AuthorID]; author.TeamName != nil {
+			counts[*author.TeamName]++
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type Reviewers struct {
+	s *store
+}
+
+func (r *Reviewers) Add(ctx context.Context, list []models.Reviewers) error {
+	defer r.s.lock(ctx)()
+
+	return r.s.addReviewers(list)
+}
+
+// addReviewers inserts all rows or none, like a single INSERT statement. The
+// caller holds the write lock.
+func (s *store) addReviewers(list []models.Reviewers) error {
+	seen := make(map[reviewerKey]struct{}, len(list))
+	for _, reviewer := range list {
+		key := reviewerKey{prID: reviewer.PRID, reviewerID: reviewer.ReviewerID}
+		if _, ok := s.reviewers[key]; ok {
+			return custom.ErrDuplicateKey
+		}
+		if _, ok := seen[key]; ok {
+			return custom.ErrDuplicateKey
+		}
+		if _, ok := s.pullRequests[reviewer.PRID]; !ok {
+			return custom.ErrForeignKey
+		}
+		if _, ok := s.users[reviewer.ReviewerID]; !ok {
+			return custom.ErrForeignKey
+		}
+		seen[key] = struct{}{}
+	}
+
+	for i := range list {
+		s.stamp(&list[i].AssignedAt)
+		s.reviewers[reviewerKey{prID: list[i].PRID, reviewerID: list[i].ReviewerID}] = list[i]
+	}
+
+	return nil
+}
+
+func (r *Reviewers) GetByPR(ctx context.Context, prID string) ([]models.Reviewers, error) {
+	defer r.s.rlock(ctx)()
+
+	return r.s.reviewersOf(prID), nil
+}
+
+func (r *Reviewers) Delete(ctx context.Context, prID string, reviewerID string) error {
+	defer r.s.lock(ctx)()
+
+	delete(r.s.reviewers, reviewerKey{prID: prID, reviewerID: reviewerID})
+	return nil
+}
+
+func (r *Reviewers) AddOne(ctx context.Context, prID string, reviewerID string) error {
+	defer r.s.lock(ctx)()
+
+	return r.s.addReviewers([]models.Reviewers{{PRID: prID, ReviewerID: reviewerID}})
+}
+
+func (r *Reviewers) LogReassignment(ctx context.Context, prID, oldID, newID string, oldAssignedAt time.Time) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.pullRequests[prID]; !ok {
+		return custom.ErrForeignKey
+	}
+	if !r.s.usersExist(oldID, newID) {
+		return custom.ErrForeignKey
+	}
+
+	r.s.lastReassignmentID++
+	r.s.reassignments = append(r.s.reassignments, models.Reassignments{
+		ID:            r.s.lastReassignmentID,
+		PRID:          prID,
+		OldReviewerID: oldID,
+		NewReviewerID: newID,
+		OldAssignedAt: oldAssignedAt,
+		ReassignedAt:  r.s.now(),
+	})
+
+	return nil
+}
+
+func (r *Reviewers) AddReview(ctx context.Context, review *models.Reviews) error {
+	defer r.s.lock(ctx)()
+
+	if _, ok := r.s.pullRequests[review.PRID]; !ok {
+		return custom.ErrForeignKey
+	}
+	if !r.s.usersExist(review.ReviewerID) {
+		return custom.ErrForeignKey
+	}
+
+	r.s.lastReviewID++
+	review.ID = r.s.lastReviewID
+	r.s.stamp(&review.SubmittedAt)
+	r.s.reviews = append(r.s.reviews, *review)
+
+	return nil
+}
+
+func (r *Reviewers) CountOpenByReviewer(ctx context.Context) (map[string]int64, error) {
+	defer r.s.rlock(ctx)()
+
+	counts := make(map[string]int64)
+	for key := range r.s.reviewers {
+		if r.s.isOpen(key.prID) {
+			counts[key.reviewerID]++
+		}
+	}
+
+	return counts, nil
+}
+
+func (s *store) usersExist(ids ...string) bool {
+	for _, id := range ids {
+		if _, ok := s.users[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
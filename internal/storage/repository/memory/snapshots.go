@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type Snapshots struct {
+	s *store
+}
+
+func (sn *Snapshots) Load(ctx context.Context) (*models.Dataset, error) {
+	defer sn.s.rlock(ctx)()
+
+	data := &models.Dataset{
+		Teams:         make([]models.Teams, 0, len(sn.s.teams)),
+		Users:         make([]models.Users, 0, len(sn.s.users)),
+		PullRequests:  make([]models.PullRequests, 0, len(sn.s.pullRequests)),
+		Reviewers:     make([]models.Reviewers, 0, len(sn.s.reviewers)),
+		Reassignments: append([]models.Reassignments{}, sn.s.reassignments...),
+		Reviews:       append([]models.Reviews{}, sn.s.reviews...),
+	}
+
+	for name := range sn.s.teams {
+		data.Teams = append(data.Teams, models.Teams{Name: name})
+	}
+	for _, u := range sn.s.users {
+		data.Users = append(data.Users, copyUser(u))
+	}
+	for _, pr := range sn.s.pullRequests {
+		pr.MergedAt = copyTime(pr.MergedAt)
+		data.PullRequests = append(data.PullRequests, pr)
+	}
+	for _, r := range sn.s.reviewers {
+		data.Reviewers = append(data.Reviewers, r)
+	}
+
+	sort.Slice(data.Teams, func(i, j int) bool { return data.Teams[i].Name < data.Teams[j].Name })
+	sortUsers(data.Users)
+	sort.Slice(data.PullRequests, func(i, j int) bool { return data.PullRequests[i].ID < data.PullRequests[j].ID })
+	sort.Slice(data.Reviewers, func(i, j int) bool {
+		a, b := data.Reviewers[i], data.Reviewers[j]
+		if a.PRID != b.PRID {
+			return a.PRID < b.PRID
+		}
+		return a.ReviewerID < b.ReviewerID
+	})
+
+	return data, nil
+}
+
+// Restore loads the dataset into an empty store. It checks every key first,
+// so a failed restore leaves the store untouched. Reassignments and reviews get
+// fresh IDs, as the serial columns give them in Postgres.
+func (sn *Snapshots) Restore(ctx context.Context, data *models.Dataset) error {
+	defer sn.s.lock(ctx)()
+
+	if len(sn.s.teams) > 0 || len(sn.s.users) > 0 || len(sn.s.pullRequests) > 0 {
+		return custom.ErrDatabaseNotEmpty
+	}
+
+	if err := checkDataset(data); err != nil {
+		return err
+	}
+
+	for _, t := range data.Teams {
+		sn.s.teams[t.Name] = struct{}{}
+	}
+	for i := range data.Users {
+		sn.s.stamp(&data.Users[i].CreatedAt)
+		sn.s.users[data.Users[i].ID] = copyUser(data.Users[i])
+	}
+	for i := range data.PullRequests {
+		sn.s.stamp(&data.PullRequests[i].CreatedAt)
+		sn.s.savePR(&data.PullRequests[i])
+	}
+	for i := range data.Reviewers {
+		sn.s.stamp(&data.Reviewers[i].AssignedAt)
+		r := data.Reviewers[i]
+		sn.s.reviewers[reviewerKey{prID: r.PRID, reviewerID: r.ReviewerID}] = r
+	}
+
+	for _, r := range data.Reassignments {
+		sn.s.lastReassignmentID++
+		r.ID = sn.s.lastReassignmentID
+		sn.s.stamp(&r.ReassignedAt)
+		sn.s.reassignments = append(sn.s.reassignments, r)
+	}
+	for _, r := range data.Reviews {
+		sn.s.lastReviewID++
+		r.ID = sn.s.lastReviewID
+		sn.s.stamp(&r.SubmittedAt)
+		sn.s.reviews = append(sn.s.reviews, r)
+	}
+
+	return nil
+}
+
+// checkDataset enforces the primary and foreign keys of the schema on a
+// dataset before any of it is stored.
+func checkDataset(data *models.Dataset) error {
+	teams := make(map[string]struct{}, len(data.Teams))
+	for _, t := range data.Teams {
+		if _, ok := teams[t.Name]; ok {
+			return custom.ErrDuplicateKey
+		}
+		teams[t.Name] = struct{}{}
+	}
+
+	users := make(map[string]struct{}, len(data.Users))
+	for _, u := range data.Users {
+		if _, ok := users[u.ID]; ok {
+			return custom.ErrDuplicateKey
+		}
+		if u.TeamName != nil {
+			if _, ok := teams[*u.TeamName]; !ok {
+				return custom.ErrForeignKey
+			}
+		}
+		users[u.ID] = struct{}{}
+	}
+
+	prs := make(map[string]struct{}, len(data.PullRequests))
+	for _, pr := range data.PullRequests {
+		if _, ok := prs[pr.ID]; ok {
+			return custom.ErrDuplicateKey
+		}
+		if _, ok := users[pr.AuthorID]; !ok {
+			return custom.ErrForeignKey
+		}
+		prs[pr.ID] = struct{}{}
+	}
+
+	refs := func(prID string, userIDs ...string) bool {
+		if _, ok := prs[prID]; !ok {
+			return false
+		}
+		for _, id := range userIDs {
+			if _, ok := users[id]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	reviewers := make(map[reviewerKey]struct{}, len(data.Reviewers))
+	for _, r := range data.Reviewers {
+		key := reviewerKey{prID: r.PRID, reviewerID: r.ReviewerID}
+		if _, ok := reviewers[key]; ok {
+			return custom.ErrDuplicateKey
+		}
+		if !refs(r.PRID, r.ReviewerID) {
+			return custom.ErrForeignKey
+		}
+		reviewers[key] = struct{}{}
+	}
+
+	for _, r := range data.Reassignments {
+		if !refs(r.PRID, r.OldReviewerID, r.NewReviewerID) {
+			return custom.ErrForeignKey
+		}
+	}
+	for _, r := range data.Reviews {
+		if !refs(r.PRID, r.ReviewerID) {
+			return custom.ErrForeignKey
+		}
+	}
+
+	return nil
+}
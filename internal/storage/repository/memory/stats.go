@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/stats"
+)
+
+// Stats computes in Go what the GORM backend computes in SQL, with the same
+// definitions of decisions, weeks and percentiles.
+type Stats struct {
+	s *store
+}
+
+func (st *Stats) Reviewers(ctx context.Context, window models.StatsWindow) ([]models.ReviewerStats, error) {
+	defer st.s.rlock(ctx)()
+
+	firstReviews := make(map[reviewerKey]time.Time)
+	for _, r := range st.s.reviews {
+		key := reviewerKey{prID: r.PRID, reviewerID: r.ReviewerID}
+		if first, ok := firstReviews[key]; !ok || r.SubmittedAt.Before(first) {
+			firstReviews[key] = r.SubmittedAt
+		}
+	}
+
+	rows := make(map[string]*models.ReviewerStats, len(st.s.users))
+	decisionSeconds := make(map[string]float64)
+	result := make([]models.ReviewerStats, 0, len(st.s.users))
+	for _, u := range st.s.users {
+		if window.TeamName != "" && (u.TeamName == nil || *u.TeamName != window.TeamName) {
+			continue
+		}
+		rows[u.ID] = &models.ReviewerStats{
+			UserID:   u.ID,
+			Username: u.Username,
+			TeamName: copyString(u.TeamName),
+			IsActive: u.IsActive,
+		}
+	}
+
+	for key, r := range st.s.reviewers {
+		row, ok := rows[key.reviewerID]
+		if !ok {
+			continue
+		}
+
+		pr := st.s.pullRequests[key.prID]
+		if pr.Status == custom.StatusOpen {
+			row.OpenReviews++
+		}
+		if !inWindow(r.AssignedAt, window) {
+			continue
+		}
+
+		row.Assignments++
+		if decidedAt, ok := firstReviews[key]; ok {
+			row.Decided++
+			decisionSeconds[key.reviewerID] += decidedAt.Sub(r.AssignedAt).Seconds()
+		} else if pr.MergedAt != nil {
+			row.MergedUnreviewed++
+		}
+	}
+
+	for _, r := range st.s.reassignments {
+		if row, ok := rows[r.OldReviewerID]; ok && inWindow(r.OldAssignedAt, window) {
+			row.Assignments++
+			key := reviewerKey{prID: r.PRID, reviewerID: r.OldReviewerID}
+			if decidedAt, ok := firstReviews[key]; ok {
+				row.Decided++
+				decisionSeconds[r.OldReviewerID] += decidedAt.Sub(r.OldAssignedAt).Seconds()
+			}
+		}
+
+		if !inWindow(r.ReassignedAt, window) {
+			continue
+		}
+		if row, ok := rows[r.OldReviewerID]; ok {
+			row.ReassignedFrom++
+		}
+		if row, ok := rows[r.NewReviewerID]; ok {
+			row.ReassignedTo++
+		}
+	}
+
+	for id, row := range rows {
+		if row.Decided > 0 {
+			avg := decisionSeconds[id] / float64(row.Decided)
+			row.AvgDecisionSeconds = &avg
+		}
+		result = append(result, *row)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].TeamName, result[j].TeamName
+		switch {
+		case a == nil && b != nil:
+			return false
+		case a != nil && b == nil:
+			return true
+		case a != nil && *a != *b:
+			return *a < *b
+		}
+		return result[i].UserID < result[j].UserID
+	})
+
+	return result, nil
+}
+
+func (st *Stats) CycleTimes(ctx context.Context, window models.StatsWindow) ([]models.CycleTimeStats, error) {
+	defer st.s.rlock(ctx)()
+
+	firstReview := make(map[string]time.Time)
+	firstApproval := make(map[string]time.Time)
+	for _, r := range st.s.reviews {
+		if first, ok := firstReview[r.PRID]; !ok || r.SubmittedAt.Before(first) {
+			firstReview[r.PRID] = r.SubmittedAt
+		}
+		if r.Decision != custom.DecisionApproved {
+			continue
+		}
+		if first, ok := firstApproval[r.PRID]; !ok || r.SubmittedAt.Before(first) {
+			firstApproval[r.PRID] = r.SubmittedAt
+		}
+	}
+
+	var samples []stats.CycleSample
+	for _, pr := range st.s.pullRequests {
+		author := st.s.users[pr.AuthorID]
+		if author.TeamName == nil || !inWindow(pr.CreatedAt, window) {
+			continue
+		}
+		if window.TeamName != "" && *author.TeamName != window.TeamName {
+			continue
+		}
+
+		sample := stats.CycleSample{TeamName: *author.TeamName, CreatedAt: pr.CreatedAt}
+		if at, ok := firstReview[pr.ID]; ok {
+			sample.FirstReview = secondsSince(pr.CreatedAt, at)
+		}
+		if at, ok := firstApproval[pr.ID]; ok {
+			sample.Approval = secondsSince(pr.CreatedAt, at)
+		}
+		if pr.MergedAt != nil {
+			sample.Merged = secondsSince(pr.CreatedAt, *pr.MergedAt)
+		}
+		samples = append(samples, sample)
+	}
+
+	return stats.AggregateCycleTimes(samples), nil
+}
+
+func inWindow(t time.Time, window models.StatsWindow) bool {
+	return !t.Before(window.From) && t.Before(window.To)
+}
+
+func secondsSince(from, to time.Time) *float64 {
+	seconds := to.Sub(from).Seconds()
+	return &seconds
+}
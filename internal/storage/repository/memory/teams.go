@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type Teams struct {
+	s *store
+}
+
+func (t *Teams) Create(ctx context.Context, team *models.Teams) error {
+	defer t.s.lock(ctx)()
+
+	if _, ok := t.s.teams[team.Name]; ok {
+		return custom.ErrDuplicateKey
+	}
+	t.s.teams[team.Name] = struct{}{}
+
+	for i := range team.Users {
+		team.Users[i].TeamName = &team.Name
+		t.s.upsertUser(&team.Users[i])
+	}
+
+	return nil
+}
+
+func (t *Teams) GetByName(ctx context.Context, name string) (*models.Teams, error) {
+	defer t.s.rlock(ctx)()
+
+	if _, ok := t.s.teams[name]; !ok {
+		return nil, custom.ErrNotFound
+	}
+
+	team := &models.Teams{Name: name, Users: []models.Users{}}
+	for _, u := range t.s.users {
+		if u.TeamName != nil && *u.TeamName == name {
+			team.Users = append(team.Users, copyUser(u))
+		}
+	}
+	sort.Slice(team.Users, func(i, j int) bool { return team.Users[i].ID < team.Users[j].ID })
+
+	return team, nil
+}
+
+func (t *Teams) GetAll(ctx context.Context) ([]models.Teams, error) {
+	defer t.s.rlock(ctx)()
+
+	teams := make([]models.Teams, 0, len(t.s.teams))
+	for name := range t.s.teams {
+		teams = append(teams, models.Teams{Name: name})
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+
+	return teams, nil
+}
+
+func (t *Teams) List(ctx context.Context, f models.TeamFilter) ([]models.TeamSummary, error) {
+	defer t.s.rlock(ctx)()
+
+	byName := make(map[string]*models.TeamSummary, len(t.s.teams))
+	teams := make([]*models.TeamSummary, 0, len(t.s.teams))
+	for name := range t.s.teams {
+		if name > f.After {
+			summary := &models.TeamSummary{Name: name}
+			byName[name] = summary
+			teams = append(teams, summary)
+		}
+	}
+
+	for _, u := range t.s.users {
+		if u.TeamName == nil {
+			continue
+		}
+		if summary, ok := byName[*u.TeamName]; ok {
+			summary.Members++
+			if u.IsActive {
+				summary.ActiveMembers++
+			}
+		}
+	}
+
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+
+	result := make([]models.TeamSummary, 0, len(teams))
+	for _, summary := range limit(teams, f.Limit) {
+		result = append(result, *summary)
+	}
+
+	return result, nil
+}
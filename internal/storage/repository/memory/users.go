@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+type Users struct {
+	s *store
+}
+
+func (u *Users) GetByID(ctx context.Context, id string) (*models.Users, error) {
+	defer u.s.rlock(ctx)()
+
+	user, ok := u.s.users[id]
+	if !ok {
+		return nil, custom.ErrNotFound
+	}
+
+	user = copyUser(user)
+	return &user, nil
+}
+
+func (u *Users) GetActiveByTeam(ctx context.Context, team string) ([]models.Users, error) {
+	defer u.s.rlock(ctx)()
+
+	users := []models.Users{}
+	for _, user := range u.s.users {
+		if user.IsActive && user.TeamName != nil && *user.TeamName == team {
+			users = append(users, copyUser(user))
+		}
+	}
+	sortUsers(users)
+
+	return users, nil
+}
+
+func (u *Users) UpdateIsActive(ctx context.Context, id string, active bool) error {
+	defer u.s.lock(ctx)()
+
+	if user, ok := u.s.users[id]; ok {
+		user.IsActive = active
+		u.s.users[id] = user
+	}
+
+	return nil
+}
+
+func (u *Users) UpdateSlackID(ctx context.Context, id string, slackID *string) error {
+	defer u.s.lock(ctx)()
+
+	if user, ok := u.s.users[id]; ok {
+		user.SlackID = copyString(slackID)
+		u.s.users[id] = user
+	}
+
+	return nil
+}
+
+func (u *Users) UpdateEmail(ctx context.Context, id string, email *string, optOut bool) error {
+	defer u.s.lock(ctx)()
+
+	if user, ok := u.s.users[id]; ok {
+		user.Email = copyString(email)
+		user.EmailOptOut = optOut
+		u.s.users[id] = user
+	}
+
+	return nil
+}
+
+func (u *Users) CreateOrUpdate(ctx context.Context, teamName string, members []models.Users) error {
+	defer u.s.lock(ctx)()
+
+	if len(members) == 0 {
+		return nil
+	}
+	if _, ok := u.s.teams[teamName]; !ok {
+		return custom.ErrForeignKey
+	}
+
+	for i := range members {
+		members[i].TeamName = &teamName
+		u.s.upsertUser(&members[i])
+	}
+
+	return nil
+}
+
+// upsertUser inserts the user or updates username, team and activity of an
+// existing one, keeping its creation time, email opt-out and, unless new ones
+// are given, its Slack ID and email. The caller holds the write lock.
+func (s *store) upsertUser(user *models.Users) {
+	if existing, ok := s.users[user.ID]; ok {
+		user.CreatedAt = existing.CreatedAt
+		user.EmailOptOut = existing.EmailOptOut
+		if user.SlackID == nil {
+			user.SlackID = copyString(existing.SlackID)
+		}
+		if user.Email == nil {
+			user.Email = copyString(existing.Email)
+		}
+	} else {
+		s.stamp(&user.CreatedAt)
+	}
+
+	s.users[user.ID] = copyUser(*user)
+}
+
+func (u *Users) GetAll(ctx context.Context) ([]models.Users, error) {
+	defer u.s.rlock(ctx)()
+
+	users := make([]models.Users, 0, len(u.s.users))
+	for _, user := range u.s.users {
+		users = append(users, copyUser(user))
+	}
+	sortUsers(users)
+
+	return users, nil
+}
+
+func (u *Users) List(ctx context.Context, f models.UserFilter) ([]models.UserSummary, error) {
+	defer u.s.rlock(ctx)()
+
+	prefix := strings.ToLower(f.UsernamePrefix)
+
+	users := []models.UserSummary{}
+	for _, user := range u.s.users {
+		if f.TeamName != "" && (user.TeamName == nil || *user.TeamName != f.TeamName) {
+			continue
+		}
+		if f.IsActive != nil && user.IsActive != *f.IsActive {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(user.Username), prefix) {
+			continue
+		}
+		if user.ID <= f.After {
+			continue
+		}
+
+		users = append(users, models.UserSummary{Users: copyUser(user)})
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	users = limit(users, f.Limit)
+
+	for i := range users {
+		for key := range u.s.reviewers {
+			if key.reviewerID == users[i].ID && u.s.isOpen(key.prID) {
+				users[i].OpenReviews++
+			}
+		}
+	}
+
+	return users, nil
+}
+
+func sortUsers(users []models.Users) {
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+}
@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+// sequenceLock is the Postgres advisory lock key that serializes Sequence.
+const sequenceLock = 0x6f7574626f78
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+func (d *Database) Add(ctx context.Context, events []models.Outbox) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return transaction.DB(ctx, d.db).Create(&events).Error
+}
+
+// Sequence numbers the committed events that have no Seq yet, after every
+// event already numbered and in ID order among themselves. Rows of
+// transactions still open are invisible here and get numbered by a later
+// call, so Seq follows commit order even where IDs do not. On Postgres an
+// advisory lock keeps concurrent calls from handing out the same numbers;
+// SQLite runs one writer at a time anyway.
+func (d *Database) Sequence(ctx context.Context) error {
+	return transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		if d.db.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", sequenceLock).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec(`
+			UPDATE outbox SET seq = numbered.seq
+			FROM (
+				SELECT id, (SELECT COALESCE(MAX(seq), 0) FROM outbox) + ROW_NUMBER() OVER (ORDER BY id) AS seq
+				FROM outbox
+				WHERE seq IS NULL
+			) AS numbered
+			WHERE outbox.id = numbered.id`).Error
+	})
+}
+
+func (d *Database) Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]models.Outbox, error) {
+	var events []models.Outbox
+	err := transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		query := tx.
+			Where("seq IS NOT NULL AND dispatched_at IS NULL AND (claimed_until IS NULL OR claimed_until < ?)", now).
+			Order("seq").
+			Limit(limit)
+		if d.db.Name() == "postgres" {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+
+		if err := query.Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		until := now.Add(lease)
+		ids := make([]int64, 0, len(events))
+		for i := range events {
+			events[i].ClaimedUntil = &until
+			ids = append(ids, events[i].ID)
+		}
+
+		return tx.Model(&models.Outbox{}).
+			Where("id IN ?", ids).
+			Update("claimed_until", until).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (d *Database) MarkDispatched(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return transaction.DB(ctx, d.db).
+		Model(&models.Outbox{}).
+		Where("id IN ?", ids).
+		Update("dispatched_at", at).Error
+}
+
+func (d *Database) MarkDelivered(ctx context.Context, deliveries []models.OutboxDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return transaction.DB(ctx, d.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+}
+
+// Deliveries returns the sinks recorded as done for each of the events.
+func (d *Database) Deliveries(ctx context.Context, ids []int64) (map[int64][]string, error) {
+	delivered := make(map[int64][]string)
+	if len(ids) == 0 {
+		return delivered, nil
+	}
+
+	var rows []models.OutboxDelivery
+	err := transaction.DB(ctx, d.db).
+		Where("event_id IN ?", ids).
+		Order("event_id, sink").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		delivered[row.EventID] = append(delivered[row.EventID], row.Sink)
+	}
+
+	return delivered, nil
+}
+
+func (d *Database) List(ctx context.Context, filter models.EventFilter) ([]models.Outbox, error) {
+	query := transaction.DB(ctx, d.db).
+		Where("seq > ?", filter.After).
+		Order("seq").
+		Limit(filter.Limit)
+	if filter.TeamName != "" {
+		query = query.Where("team_name = ?", filter.TeamName)
+	}
+
+	var events []models.Outbox
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// LastSeq returns the newest Seq, or 0 when nothing is sequenced yet.
+func (d *Database) LastSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := transaction.DB(ctx, d.db).
+		Model(&models.Outbox{}).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error
+
+	return seq, err
+}
@@ -2,10 +2,14 @@ package pull_requests
 
 import (
 	"context"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"mPR/internal/custom"
 	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
 )
 
 type Database struct {
@@ -19,12 +23,33 @@ func New(db *gorm.DB) *Database {
 }
 
 func (d *Database) Create(ctx context.Context, pr *models.PullRequests) error {
-	return d.db.WithContext(ctx).Create(pr).Error
+	return transaction.DB(ctx, d.db).Create(pr).Error
 }
 
 func (d *Database) GetByID(ctx context.Context, id string) (*models.PullRequests, error) {
 	var pr models.PullRequests
-	err := d.db.WithContext(ctx).
+	err := transaction.DB(ctx, d.db).
+		Preload("Author").
+		Preload("Reviewers").
+		First(&pr, "pr_id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// GetByIDForUpdate is GetByID that also locks the PR row until the
+// transaction in ctx ends, so read-check-write sequences on one PR run one
+// at a time. SQLite has a single connection and needs no row lock.
+func (d *Database) GetByIDForUpdate(ctx context.Context, id string) (*models.PullRequests, error) {
+	query := transaction.DB(ctx, d.db)
+	if d.db.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var pr models.PullRequests
+	err := query.
 		Preload("Author").
 		Preload("Reviewers").
 		First(&pr, "pr_id = ?", id).Error
@@ -36,16 +61,20 @@ func (d *Database) GetByID(ctx context.Context, id string) (*models.PullRequests
 }
 
 func (d *Database) Update(ctx context.Context, pr *models.PullRequests) error {
-	return d.db.WithContext(ctx).Save(pr).Error
+	return transaction.DB(ctx, d.db).Save(pr).Error
 }
 
 func (d *Database) AddReviewers(ctx context.Context, reviewers []models.Reviewers) error {
-	return d.db.WithContext(ctx).Create(&reviewers).Error
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	return transaction.DB(ctx, d.db).Create(&reviewers).Error
 }
 
 func (d *Database) GetReviewers(ctx context.Context, prID string) ([]models.Reviewers, error) {
 	var list []models.Reviewers
-	err := d.db.WithContext(ctx).
+	err := transaction.DB(ctx, d.db).
 		Where("pr_id = ?", prID).
 		Find(&list).Error
 
@@ -53,26 +82,148 @@ func (d *Database) GetReviewers(ctx context.Context, prID string) ([]models.Revi
 }
 
 func (d *Database) ReplaceReviewer(ctx context.Context, prID string, oldID, newID string) error {
-	err := d.db.WithContext(ctx).
+	err := transaction.DB(ctx, d.db).
 		Where("pr_id = ? AND reviewer_id = ?", prID, oldID).
 		Delete(&models.Reviewers{}).Error
 	if err != nil {
 		return err
 	}
 
-	return d.db.WithContext(ctx).
+	return transaction.DB(ctx, d.db).
 		Create(&models.Reviewers{
 			PRID:       prID,
 			ReviewerID: newID,
 		}).Error
 }
 
-func (d *Database) GetByReviewer(ctx context.Context, reviewerID string) ([]models.PullRequests, error) {
+// GetByReviewer loads a page of the reviewer's PRs with one joined query plus
+// one preload for the assigned reviewers, however many PRs the page holds.
+func (d *Database) GetByReviewer(ctx context.Context, reviewerID string, f models.ReviewFilter) ([]models.PullRequests, error) {
+	query := transaction.DB(ctx, d.db).
+		Preload("Reviewers").
+		Joins("JOIN reviewers r ON r.pr_id = pull_requests.pr_id").
+		Where("r.reviewer_id = ?", reviewerID)
+
+	if f.Status != "" {
+		query = query.Where("pull_requests.status = ?", f.Status)
+	}
+	if f.After != nil {
+		query = query.Where("(pull_requests.created_at, pull_requests.pr_id) < (?, ?)", f.After.CreatedAt, f.After.ID)
+	}
+
+	var prs []models.PullRequests
+	err := query.
+		Order("pull_requests.created_at DESC").
+		Order("pull_requests.pr_id DESC").
+		Limit(f.Limit).
+		Find(&prs).Error
+
+	return prs, err
+}
+
+var sortColumns = map[string]string{
+	models.SortCreatedAt: "pull_requests.created_at",
+	models.SortName:      "pull_requests.pr_name",
+	models.SortID:        "pull_requests.pr_id",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (d *Database) List(ctx context.Context, f models.PullRequestFilter) ([]models.PullRequests, error) {
+	query := transaction.DB(ctx, d.db).Model(&models.PullRequests{}).Preload("Reviewers")
+
+	if f.Status != "" {
+		query = query.Where("pull_requests.status = ?", f.Status)
+	}
+	if f.AuthorID != "" {
+		query = query.Where("pull_requests.author_id = ?", f.AuthorID)
+	}
+	if f.ReviewerID != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM reviewers r WHERE r.pr_id = pull_requests.pr_id AND r.reviewer_id = ?)",
+			f.ReviewerID,
+		)
+	}
+	if f.TeamName != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM users u WHERE u.user_id = pull_requests.author_id AND u.team_name = ?)",
+			f.TeamName,
+		)
+	}
+	if f.Search != "" {
+		query = query.Where(
+			`LOWER(pull_requests.pr_name) LIKE LOWER(?) ESCAPE '\'`,
+			"%"+likeEscaper.Replace(f.Search)+"%",
+		)
+	}
+	if f.CreatedFrom != nil {
+		query = query.Where("pull_requests.created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("pull_requests.created_at < ?", *f.CreatedTo)
+	}
+	if f.MergedFrom != nil {
+		query = query.Where("pull_requests.merged_at >= ?", *f.MergedFrom)
+	}
+	if f.MergedTo != nil {
+		query = query.Where("pull_requests.merged_at < ?", *f.MergedTo)
+	}
+
+	column, ok := sortColumns[f.Sort]
+	if !ok {
+		column = sortColumns[models.SortCreatedAt]
+	}
+
+	direction, cmp := "ASC", ">"
+	if f.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if f.After != nil {
+		switch column {
+		case sortColumns[models.SortID]:
+			query = query.Where("pull_requests.pr_id "+cmp+" ?", f.After.ID)
+		case sortColumns[models.SortName]:
+			query = query.Where("(pull_requests.pr_name, pull_requests.pr_id) "+cmp+" (?, ?)", f.After.Name, f.After.ID)
+		default:
+			query = query.Where("(pull_requests.created_at, pull_requests.pr_id) "+cmp+" (?, ?)", f.After.CreatedAt, f.After.ID)
+		}
+	}
+
+	if column != sortColumns[models.SortID] {
+		query = query.Order(column + " " + direction)
+	}
+
 	var prs []models.PullRequests
-	err := d.db.WithContext(ctx).
-		Joins("JOIN pr_reviewers r ON r.pr_id = pull_requests.pr_id").
-		Where("r.reviewer_id = ?", reviewerID).
+	err := query.
+		Order("pull_requests.pr_id " + direction).
+		Limit(f.Limit).
 		Find(&prs).Error
 
 	return prs, err
 }
+
+func (d *Database) CountOpenByTeam(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		TeamName string
+		Count    int64
+	}
+
+	err := transaction.DB(ctx, d.db).
+		Model(&models.PullRequests{}).
+		Select("u.team_name AS team_name, COUNT(*) AS count").
+		Joins("JOIN users u ON u.user_id = pull_requests.author_id").
+		Where("pull_requests.status = ? AND u.team_name IS NOT NULL", custom.StatusOpen).
+		Group("u.team_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		counts[r.TeamName] = r.Count
+	}
+
+	return counts, nil
+}
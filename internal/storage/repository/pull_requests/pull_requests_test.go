@@ -0,0 +1,114 @@
+package pull_requests_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/pull_requests"
+)
+
+// countingDB wires the repository to sqlmock and counts every SELECT gorm
+// issues, preloads included.
+func countingDB(tb testing.TB) (*pull_requests.Database, sqlmock.Sqlmock, *int) {
+	tb.Helper()
+
+	conn, mock, err := sqlmock.New()
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	require.NoError(tb, err)
+
+	queries := new(int)
+	err = db.Callback().Query().Before("gorm:query").Register("count_queries", func(*gorm.DB) { *queries++ })
+	require.NoError(tb, err)
+
+	return pull_requests.New(db), mock, queries
+}
+
+// expectReviews queues the result sets for a reviewer assigned to n PRs,
+// each with two reviewers.
+func expectReviews(mock sqlmock.Sqlmock, n int) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	prs := sqlmock.NewRows([]string{"pr_id", "pr_name", "author_id", "status", "created_at", "merged_at"})
+	reviewers := sqlmock.NewRows([]string{"pr_id", "reviewer_id"})
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("pr-%d", i)
+		prs.AddRow(id, "PR "+id, "u1", "OPEN", created.Add(-time.Duration(i)*time.Minute), nil)
+		reviewers.AddRow(id, "u2").AddRow(id, "u3")
+	}
+
+	mock.ExpectQuery(`SELECT "pull_requests"."pr_id",.* FROM "pull_requests" JOIN reviewers r ON r.pr_id = pull_requests.pr_id WHERE r.reviewer_id = \$1 AND pull_requests.status = \$2 ORDER BY pull_requests.created_at DESC,pull_requests.pr_id DESC LIMIT \$3`).WillReturnRows(prs)
+	if n > 0 {
+		mock.ExpectQuery(`SELECT \* FROM "reviewers" WHERE "reviewers"."pr_id" (IN|=)`).WillReturnRows(reviewers)
+	}
+}
+
+func TestGetByReviewer_ConstantQueryCount(t *testing.T) {
+	for _, n := range []int{1, 10, 200} {
+		t.Run(fmt.Sprintf("reviews=%d", n), func(t *testing.T) {
+			repo, mock, queries := countingDB(t)
+			expectReviews(mock, n)
+
+			prs, err := repo.GetByReviewer(context.Background(), "u2", models.ReviewFilter{
+				Status: "OPEN",
+				Limit:  n + 1,
+			})
+
+			require.NoError(t, err)
+			require.Len(t, prs, n)
+			require.Len(t, prs[n-1].Reviewers, 2)
+			require.Equal(t, 2, *queries)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetByReviewer_PropagatesErrors(t *testing.T) {
+	repo, mock, _ := countingDB(t)
+	mock.ExpectQuery(`FROM "pull_requests"`).WillReturnError(fmt.Errorf("connection reset"))
+
+	prs, err := repo.GetByReviewer(context.Background(), "u2", models.ReviewFilter{Limit: 10})
+
+	require.ErrorContains(t, err, "connection reset")
+	require.Empty(t, prs)
+}
+
+// BenchmarkGetByReviewer reports queries/op, which stays at 2 however many
+// PRs the reviewer is assigned to.
+func BenchmarkGetByReviewer(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("reviews=%d", n), func(b *testing.B) {
+			repo, mock, queries := countingDB(b)
+			ctx := context.Background()
+			filter := models.ReviewFilter{Status: "OPEN", Limit: n + 1}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				expectReviews(mock, n)
+				b.StartTimer()
+
+				if _, err := repo.GetByReviewer(ctx, "u2", filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+
+			b.ReportMetric(float64(*queries)/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package rate_limits
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"mPR/internal/ratelimit"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+func (d *Database) Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	var (
+		allowed    bool
+		retryAfter time.Duration
+	)
+
+	err := transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.RateLimits{
+				Key:       key,
+				Tokens:    float64(burst),
+				UpdatedAt: now,
+				FullAt:    now,
+			}).Error; err != nil {
+			return err
+		}
+
+		var row models.RateLimits
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&row, "bucket_key = ?", key).Error; err != nil {
+			return err
+		}
+
+		bucket := ratelimit.Bucket{Tokens: row.Tokens, UpdatedAt: row.UpdatedAt}
+		allowed, retryAfter = bucket.Take(now, rate, burst)
+
+		return tx.Model(&models.RateLimits{}).
+			Where("bucket_key = ?", key).
+			Updates(map[string]any{
+				"tokens":     bucket.Tokens,
+				"updated_at": bucket.UpdatedAt,
+				"full_at":    bucket.FullAt(rate, burst),
+			}).Error
+	})
+	if err != nil {
+		return false, 0, err
+	}
+
+	return allowed, retryAfter, nil
+}
+
+func (d *Database) Sweep(ctx context.Context, now time.Time) error {
+	return transaction.DB(ctx, d.db).
+		Where("full_at < ?", now).
+		Delete(&models.RateLimits{}).Error
+}
@@ -2,52 +2,97 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"gorm.io/gorm"
 
+	"mPR/internal/idempotency"
+	"mPR/internal/ratelimit"
 	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/health"
+	"mPR/internal/storage/repository/idempotency_keys"
+	"mPR/internal/storage/repository/job_runs"
+	"mPR/internal/storage/repository/outbox"
 	"mPR/internal/storage/repository/pull_requests"
+	"mPR/internal/storage/repository/rate_limits"
 	"mPR/internal/storage/repository/reviewers"
+	"mPR/internal/storage/repository/snapshots"
+	"mPR/internal/storage/repository/stats"
 	"mPR/internal/storage/repository/teams"
+	"mPR/internal/storage/repository/transaction"
 	"mPR/internal/storage/repository/users"
 )
 
 type All struct {
+	Tx           Transactor
 	Teams        Teams
 	Users        Users
 	PullRequests PullRequests
 	Reviewers    Reviewers
+	RateLimits   ratelimit.Store
+
+	IdempotencyKeys idempotency.Store
+	Health          Health
+	Snapshots       Snapshots
+	Stats           Stats
+	Outbox          Outbox
+	JobRuns         JobRuns
 }
 
 func New(db *gorm.DB) *All {
 	return &All{
+		Tx:           transaction.New(db),
 		Teams:        teams.New(db),
 		Users:        users.New(db),
 		PullRequests: pull_requests.New(db),
 		Reviewers:    reviewers.New(db),
+		RateLimits:   rate_limits.New(db),
+
+		IdempotencyKeys: idempotency_keys.New(db),
+		Health:          health.New(db),
+		Snapshots:       snapshots.New(db),
+		Stats:           stats.New(db),
+		Outbox:          outbox.New(db),
+		JobRuns:         job_runs.New(db),
 	}
 }
 
+// Transactor runs fn in one storage transaction: repository calls made with
+// the context fn receives commit together, or not at all when fn fails.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Teams interface {
 	Create(ctx context.Context, team *models.Teams) error
 	GetByName(ctx context.Context, name string) (*models.Teams, error)
+	GetAll(ctx context.Context) ([]models.Teams, error)
+	List(ctx context.Context, filter models.TeamFilter) ([]models.TeamSummary, error)
 }
 
 type Users interface {
 	GetByID(ctx context.Context, id string) (*models.Users, error)
 	GetActiveByTeam(ctx context.Context, team string) ([]models.Users, error)
 	UpdateIsActive(ctx context.Context, id string, active bool) error
+	UpdateSlackID(ctx context.Context, id string, slackID *string) error
+	UpdateEmail(ctx context.Context, id string, email *string, optOut bool) error
 	CreateOrUpdate(ctx context.Context, teamName string, members []models.Users) error
+	GetAll(ctx context.Context) ([]models.Users, error)
+	List(ctx context.Context, filter models.UserFilter) ([]models.UserSummary, error)
 }
 
 type PullRequests interface {
 	Create(ctx context.Context, pr *models.PullRequests) error
 	GetByID(ctx context.Context, id string) (*models.PullRequests, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.PullRequests, error)
 	Update(ctx context.Context, pr *models.PullRequests) error
 	AddReviewers(ctx context.Context, reviewers []models.Reviewers) error
 	GetReviewers(ctx context.Context, prID string) ([]models.Reviewers, error)
 	ReplaceReviewer(ctx context.Context, prID string, oldID, newID string) error
-	GetByReviewer(ctx context.Context, reviewerID string) ([]models.PullRequests, error)
+	GetByReviewer(ctx context.Context, reviewerID string, filter models.ReviewFilter) ([]models.PullRequests, error)
+	CountOpenByTeam(ctx context.Context) (map[string]int64, error)
+	List(ctx context.Context, filter models.PullRequestFilter) ([]models.PullRequests, error)
 }
 
 type Reviewers interface {
@@ -55,5 +100,48 @@ type Reviewers interface {
 	GetByPR(ctx context.Context, prID string) ([]models.Reviewers, error)
 	Delete(ctx context.Context, prID string, reviewerID string) error
 	AddOne(ctx context.Context, prID string, reviewerID string) error
-	GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error)
+	LogReassignment(ctx context.Context, prID, oldID, newID string, oldAssignedAt time.Time) error
+	AddReview(ctx context.Context, review *models.Reviews) error
+	CountOpenByReviewer(ctx context.Context) (map[string]int64, error)
+}
+
+type Health interface {
+	Ping(ctx context.Context) error
+	Stats() sql.DBStats
+	SchemaVersion(ctx context.Context) (uint, bool, error)
+}
+
+type Snapshots interface {
+	Load(ctx context.Context) (*models.Dataset, error)
+	Restore(ctx context.Context, data *models.Dataset) error
+}
+
+type Stats interface {
+	Reviewers(ctx context.Context, window models.StatsWindow) ([]models.ReviewerStats, error)
+	CycleTimes(ctx context.Context, window models.StatsWindow) ([]models.CycleTimeStats, error)
+}
+
+// Outbox stores domain events written in the same transaction as the change
+// they describe, until a dispatcher has delivered them. Sequence numbers
+// committed events in commit order; only sequenced events are claimed or
+// listed. Claim hands out the oldest undelivered events not claimed by
+// another dispatcher and reserves them for lease, so replicas can share the
+// outbox. MarkDelivered records the sinks that took an event still waiting
+// for others, and Deliveries reads them back. List reads events whether delivered or not, for consumers that
+// follow the sequence themselves.
+type Outbox interface {
+	Add(ctx context.Context, events []models.Outbox) error
+	Sequence(ctx context.Context) error
+	Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]models.Outbox, error)
+	MarkDispatched(ctx context.Context, ids []int64, at time.Time) error
+	MarkDelivered(ctx context.Context, deliveries []models.OutboxDelivery) error
+	Deliveries(ctx context.Context, ids []int64) (map[int64][]string, error)
+	List(ctx context.Context, filter models.EventFilter) ([]models.Outbox, error)
+	LastSeq(ctx context.Context) (int64, error)
+}
+
+// JobRuns lets replicas agree on who runs a scheduled job. Every replica
+// calls Claim for the same job and slot, and exactly one gets true.
+type JobRuns interface {
+	Claim(ctx context.Context, job string, slot, now time.Time) (bool, error)
 }
@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mPR/db/migrations"
+	"mPR/internal/config"
+	"mPR/internal/storage/postgres"
+	"mPR/internal/storage/repository"
+	"mPR/internal/storage/repository/repositorytest"
+	"mPR/internal/storage/sqlite"
+)
+
+// TestContract runs the repository contract against a real Postgres. The
+// database named by TEST_DB_* is migrated and truncated, so never point it at
+// data you want to keep.
+func TestContract(t *testing.T) {
+	if os.Getenv("TEST_DB_HOST") == "" {
+		t.Skip("TEST_DB_HOST is not set")
+	}
+
+	cfg := config.Database{
+		Host:     os.Getenv("TEST_DB_HOST"),
+		Port:     envOr("TEST_DB_PORT", "5432"),
+		Username: envOr("TEST_DB_USERNAME", "postgres"),
+		Password: os.Getenv("TEST_DB_PASSWORD"),
+		Name:     envOr("TEST_DB_NAME", "managerPR_test"),
+		Mode:     envOr("TEST_DB_MODE", "disable"),
+	}
+	log := zap.NewNop()
+
+	migrator, err := migrations.New(cfg, log)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up())
+	require.NoError(t, migrator.Close())
+
+	db := postgres.New(cfg, log)
+
+	open := func(t *testing.T) *repository.All {
+		require.NoError(t, db.Exec(
+			"TRUNCATE teams, users, pull_requests, reviewers, reviewer_reassignments, reviews, outbox, job_runs, idempotency_keys, rate_limits RESTART IDENTITY CASCADE",
+		).Error)
+		return repository.New(db)
+	}
+	repositorytest.Run(t, open)
+	repositorytest.RunConcurrent(t, open)
+}
+
+// TestContractSQLite runs the same contract against a fresh, fully migrated
+// SQLite file per case.
+func TestContractSQLite(t *testing.T) {
+	log := zap.NewNop()
+
+	repositorytest.Run(t, func(t *testing.T) *repository.All {
+		cfg := config.SQLite{Path: filepath.Join(t.TempDir(), "contract.db")}
+
+		migrator, err := migrations.NewSQLite(cfg, log)
+		require.NoError(t, err)
+		require.NoError(t, migrator.Up())
+		require.NoError(t, migrator.Close())
+
+		db := sqlite.New(cfg, log)
+		t.Cleanup(func() {
+			sqlDB, err := db.DB()
+			require.NoError(t, err)
+			require.NoError(t, sqlDB.Close())
+		})
+
+		return repository.New(db)
+	})
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,117 @@
+package repositorytest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/idempotency"
+)
+
+func testIdempotencyKeys(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("ReserveReturnsStoredRecord", func(t *testing.T) {
+		repos := open(t)
+
+		_, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash-1", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		require.NoError(t, repos.IdempotencyKeys.Complete(ctx, "k1", 201, []byte(`{"ok":true}`)))
+
+		record, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash-2", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		require.NotNil(t, record)
+		assert.Equal(t, "hash-1", record.RequestHash)
+		assert.Equal(t, 201, record.StatusCode)
+		assert.JSONEq(t, `{"ok":true}`, string(record.Body))
+	})
+
+	t.Run("ReserveTakesOverExpiredRecord", func(t *testing.T) {
+		repos := open(t)
+
+		_, _, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash-1", -time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, repos.IdempotencyKeys.Complete(ctx, "k1", 201, []byte(`{}`)))
+
+		_, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash-2", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+
+		record, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash-3", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, "hash-2", record.RequestHash)
+		assert.False(t, record.Completed())
+	})
+
+	t.Run("SweepDeletesExpiredRecords", func(t *testing.T) {
+		repos := open(t)
+
+		_, _, err := repos.IdempotencyKeys.Reserve(ctx, "short", "hash", time.Minute)
+		require.NoError(t, err)
+		_, _, err = repos.IdempotencyKeys.Reserve(ctx, "long", "hash", 2*time.Hour)
+		require.NoError(t, err)
+
+		require.NoError(t, repos.IdempotencyKeys.Sweep(ctx, time.Now().Add(time.Hour)))
+
+		// Neither key has expired by the wall clock yet, so only a deleted
+		// record can be claimed again.
+		_, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "short", "hash", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+
+		_, reserved, err = repos.IdempotencyKeys.Reserve(ctx, "long", "hash", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+	})
+
+	t.Run("LongestScopedKeyFits", func(t *testing.T) {
+		repos := open(t)
+
+		// The longest key the API accepts, from a bearer-token client on the
+		// longest idempotent route.
+		client := "token:" + strings.Repeat("f", 64)
+		key := idempotency.ScopedKey(client, "/pullRequest/reassign", strings.Repeat("k", 255))
+
+		_, reserved, err := repos.IdempotencyKeys.Reserve(ctx, key, "hash", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		require.NoError(t, repos.IdempotencyKeys.Complete(ctx, key, 200, []byte(`{}`)))
+
+		record, reserved, err := repos.IdempotencyKeys.Reserve(ctx, key, "hash", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, 200, record.StatusCode)
+	})
+
+	t.Run("ReserveRacesRelease", func(t *testing.T) {
+		repos := open(t)
+
+		// One client keeps failing and releasing the key while others retry
+		// it: each of them must either claim the key or see the record.
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 50 {
+					_, reserved, err := repos.IdempotencyKeys.Reserve(ctx, "k1", "hash", time.Hour)
+					if !assert.NoError(t, err) {
+						return
+					}
+					if reserved {
+						assert.NoError(t, repos.IdempotencyKeys.Release(ctx, "k1"))
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,57 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJobRuns(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("ClaimOncePerSlot", func(t *testing.T) {
+		repos := open(t)
+		slot := base.Truncate(time.Hour)
+
+		claimed, err := repos.JobRuns.Claim(ctx, "reminders", slot, base)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		// Another replica, with its clock in another zone.
+		claimed, err = repos.JobRuns.Claim(ctx, "reminders", slot.In(time.FixedZone("UTC+3", 3*60*60)), base)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+
+		claimed, err = repos.JobRuns.Claim(ctx, "reminders", slot.Add(time.Hour), base)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		claimed, err = repos.JobRuns.Claim(ctx, "digests", slot, base)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+	})
+
+	t.Run("ConcurrentClaims", func(t *testing.T) {
+		repos := open(t)
+
+		results := make(chan bool, 5)
+		for range 5 {
+			go func() {
+				claimed, err := repos.JobRuns.Claim(ctx, "digests", base, base)
+				assert.NoError(t, err)
+				results <- claimed
+			}()
+		}
+
+		won := 0
+		for range 5 {
+			if <-results {
+				won++
+			}
+		}
+		assert.Equal(t, 1, won)
+	})
+}
@@ -0,0 +1,203 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/storage/models"
+)
+
+func testOutbox(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("AddAssignsIncreasingIDs", func(t *testing.T) {
+		repos := open(t)
+		team := "backend"
+
+		events := []models.Outbox{
+			{Type: "TEAM_CREATED", TeamName: &team, Payload: `{"team_name":"backend"}`},
+			{Type: "USER_ACTIVITY_CHANGED", Payload: `{"user_id":"u1"}`},
+		}
+		require.NoError(t, repos.Outbox.Add(ctx, events))
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+
+		assert.Positive(t, events[0].ID)
+		assert.Greater(t, events[1].ID, events[0].ID)
+
+		pending, err := repos.Outbox.Claim(ctx, 10, base, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, events[0].ID, pending[0].ID)
+		assert.Equal(t, "TEAM_CREATED", pending[0].Type)
+		assert.Equal(t, &team, pending[0].TeamName)
+		assert.JSONEq(t, `{"team_name":"backend"}`, pending[0].Payload)
+		assert.False(t, pending[0].CreatedAt.IsZero())
+		assert.Nil(t, pending[1].TeamName)
+	})
+
+	t.Run("ClaimSkipsClaimedAndDispatched", func(t *testing.T) {
+		repos := open(t)
+
+		events := []models.Outbox{
+			{Type: "PR_MERGED", Payload: `{}`},
+			{Type: "PR_MERGED", Payload: `{}`},
+			{Type: "PR_MERGED", Payload: `{}`},
+		}
+		require.NoError(t, repos.Outbox.Add(ctx, events))
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+
+		first, err := repos.Outbox.Claim(ctx, 2, base, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Equal(t, events[0].ID, first[0].ID)
+
+		second, err := repos.Outbox.Claim(ctx, 2, base, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.Equal(t, events[2].ID, second[0].ID)
+
+		require.NoError(t, repos.Outbox.MarkDispatched(ctx, []int64{first[0].ID}, base))
+
+		// The lease on the second event ran out without it being delivered.
+		expired, err := repos.Outbox.Claim(ctx, 10, base.Add(2*time.Minute), time.Minute)
+		require.NoError(t, err)
+		require.Len(t, expired, 2)
+		assert.Equal(t, []int64{events[1].ID, events[2].ID}, []int64{expired[0].ID, expired[1].ID})
+	})
+
+	t.Run("RolledBackWithTransaction", func(t *testing.T) {
+		repos := open(t)
+		failure := errors.New("boom")
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			if err := repos.Outbox.Add(ctx, []models.Outbox{{Type: "TEAM_CREATED", Payload: `{}`}}); err != nil {
+				return err
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+
+		pending, err := repos.Outbox.Claim(ctx, 10, base, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+	t.Run("ListFollowsSequence", func(t *testing.T) {
+		repos := open(t)
+		backend, frontend := "backend", "frontend"
+
+		last, err := repos.Outbox.LastSeq(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, last)
+
+		events := []models.Outbox{
+			{Type: "TEAM_CREATED", TeamName: &backend, Payload: `{}`},
+			{Type: "TEAM_CREATED", TeamName: &frontend, Payload: `{}`},
+			{Type: "PR_MERGED", TeamName: &backend, Payload: `{}`},
+			{Type: "USER_ACTIVITY_CHANGED", Payload: `{}`},
+		}
+		require.NoError(t, repos.Outbox.Add(ctx, events))
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+
+		last, err = repos.Outbox.LastSeq(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), last)
+
+		all, err := repos.Outbox.List(ctx, models.EventFilter{After: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, []int64{events[1].ID, events[2].ID}, []int64{all[0].ID, all[1].ID})
+
+		team, err := repos.Outbox.List(ctx, models.EventFilter{TeamName: "backend", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, team, 2)
+		assert.Equal(t, []int64{events[0].ID, events[2].ID}, []int64{team[0].ID, team[1].ID})
+	})
+
+	t.Run("DeliveriesPerSink", func(t *testing.T) {
+		repos := open(t)
+
+		events := []models.Outbox{{Type: "PR_MERGED", Payload: `{}`}, {Type: "PR_MERGED", Payload: `{}`}}
+		require.NoError(t, repos.Outbox.Add(ctx, events))
+
+		require.NoError(t, repos.Outbox.MarkDelivered(ctx, []models.OutboxDelivery{
+			{EventID: events[0].ID, Sink: "webhook", DeliveredAt: base},
+			{EventID: events[0].ID, Sink: "log", DeliveredAt: base},
+		}))
+		// Recording a sink again is harmless.
+		require.NoError(t, repos.Outbox.MarkDelivered(ctx, []models.OutboxDelivery{
+			{EventID: events[0].ID, Sink: "log", DeliveredAt: base.Add(time.Minute)},
+		}))
+
+		delivered, err := repos.Outbox.Deliveries(ctx, []int64{events[0].ID, events[1].ID})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64][]string{events[0].ID: {"log", "webhook"}}, delivered)
+	})
+
+	t.Run("SequenceNumbersEachEventOnce", func(t *testing.T) {
+		repos := open(t)
+
+		require.NoError(t, repos.Outbox.Add(ctx, []models.Outbox{{Type: "PR_MERGED", Payload: `{}`}}))
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			return repos.Outbox.Add(ctx, []models.Outbox{{Type: "PR_MERGED", Payload: `{}`}})
+		})
+		require.NoError(t, err)
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+		require.NoError(t, repos.Outbox.Sequence(ctx))
+
+		all, err := repos.Outbox.List(ctx, models.EventFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, []int64{1, 2}, []int64{*all[0].Seq, *all[1].Seq})
+	})
+}
+
+// testOutboxCommitOrder needs a backend where two writers run side by side.
+// The first event to be inserted commits last, and a reader following the
+// sequence must still get it.
+func testOutboxCommitOrder(t *testing.T, open Open) {
+	ctx := context.Background()
+	repos := open(t)
+
+	inserted := make(chan struct{})
+	release := make(chan struct{})
+	slow := make(chan error, 1)
+	go func() {
+		slow <- repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			if err := repos.Outbox.Add(ctx, []models.Outbox{{Type: "PR_CREATED", Payload: `{}`}}); err != nil {
+				return err
+			}
+			close(inserted)
+			<-release
+			return nil
+		})
+	}()
+	<-inserted
+
+	fast := []models.Outbox{{Type: "PR_MERGED", Payload: `{}`}}
+	require.NoError(t, repos.Outbox.Add(ctx, fast))
+	require.NoError(t, repos.Outbox.Sequence(ctx))
+
+	seen, err := repos.Outbox.List(ctx, models.EventFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, seen, 1)
+	assert.Equal(t, fast[0].ID, seen[0].ID)
+	after := *seen[0].Seq
+
+	close(release)
+	require.NoError(t, <-slow)
+	require.NoError(t, repos.Outbox.Sequence(ctx))
+
+	rest, err := repos.Outbox.List(ctx, models.EventFilter{After: after, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "PR_CREATED", rest[0].Type)
+	assert.Less(t, rest[0].ID, fast[0].ID)
+	assert.Greater(t, *rest[0].Seq, after)
+}
@@ -0,0 +1,240 @@
+package repositorytest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testPullRequests(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("GetByIDLoadsAuthorAndReviewers", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2", "u3")
+
+		pr, err := repos.PullRequests.GetByID(ctx, "pr1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "PR pr1", pr.Name)
+		assert.Equal(t, custom.StatusOpen, pr.Status)
+		assert.True(t, base.Equal(pr.CreatedAt))
+		assert.Nil(t, pr.MergedAt)
+		assert.Equal(t, "Alice", pr.Author.Username)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewerIDs(pr.Reviewers))
+	})
+
+	t.Run("GetByIDMissing", func(t *testing.T) {
+		_, err := open(t).PullRequests.GetByID(ctx, "pr404")
+
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+
+	t.Run("CreateChecksKeys", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+		seedPR(t, repos, "pr1", "u1", base)
+
+		err := repos.PullRequests.Create(ctx, &models.PullRequests{ID: "pr1", Name: "again", AuthorID: "u1", Status: custom.StatusOpen})
+		assert.ErrorIs(t, err, custom.ErrDuplicateKey)
+
+		err = repos.PullRequests.Create(ctx, &models.PullRequests{ID: "pr2", Name: "orphan", AuthorID: "u404", Status: custom.StatusOpen})
+		assert.ErrorIs(t, err, custom.ErrForeignKey)
+	})
+
+	t.Run("CreateStampsCreatedAt", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+
+		pr := &models.PullRequests{ID: "pr1", Name: "now", AuthorID: "u1", Status: custom.StatusOpen}
+		require.NoError(t, repos.PullRequests.Create(ctx, pr))
+
+		assert.WithinDuration(t, time.Now(), pr.CreatedAt, time.Minute)
+	})
+
+	t.Run("UpdateSavesStatus", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+
+		pr, err := repos.PullRequests.GetByID(ctx, "pr1")
+		require.NoError(t, err)
+		merged := base.Add(time.Hour)
+		pr.Status, pr.MergedAt = custom.StatusMerged, &merged
+		require.NoError(t, repos.PullRequests.Update(ctx, pr))
+
+		got, err := repos.PullRequests.GetByID(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, custom.StatusMerged, got.Status)
+		require.NotNil(t, got.MergedAt)
+		assert.True(t, merged.Equal(*got.MergedAt))
+		assert.Equal(t, []string{"u2"}, reviewerIDs(got.Reviewers))
+	})
+
+	t.Run("ReviewerAssignments", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base)
+
+		require.NoError(t, repos.PullRequests.AddReviewers(ctx, []models.Reviewers{{PRID: "pr1", ReviewerID: "u2"}}))
+		require.NoError(t, repos.PullRequests.AddReviewers(ctx, nil))
+		require.NoError(t, repos.PullRequests.ReplaceReviewer(ctx, "pr1", "u2", "u3"))
+
+		reviewers, err := repos.PullRequests.GetReviewers(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, reviewerIDs(reviewers))
+	})
+
+	t.Run("GetByReviewer", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2", "u3")
+		seedPR(t, repos, "pr2", "u1", base.Add(time.Hour), "u2")
+		seedPR(t, repos, "pr3", "u1", base.Add(time.Hour), "u2")
+		seedPR(t, repos, "pr4", "u1", base.Add(2*time.Hour), "u3")
+		merge(t, repos, "pr2")
+
+		all, err := repos.PullRequests.GetByReviewer(ctx, "u2", models.ReviewFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr3", "pr2", "pr1"}, prIDs(all))
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewerIDs(all[2].Reviewers))
+
+		openOnly, err := repos.PullRequests.GetByReviewer(ctx, "u2", models.ReviewFilter{Status: custom.StatusOpen, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr3", "pr1"}, prIDs(openOnly))
+
+		page, err := repos.PullRequests.GetByReviewer(ctx, "u2", models.ReviewFilter{After: &all[0], Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr2"}, prIDs(page))
+	})
+
+	t.Run("ListFiltersAndSorts", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true))
+		seedTeam(t, repos, "frontend", user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base.Add(time.Hour))
+		seedPR(t, repos, "pr3", "u3", base.Add(2*time.Hour), "u2")
+		require.NoError(t, repos.PullRequests.Create(ctx, &models.PullRequests{
+			ID: "pr4", Name: "Fix 100% CPU", AuthorID: "u3", Status: custom.StatusOpen, CreatedAt: base.Add(3 * time.Hour),
+		}))
+		merge(t, repos, "pr1")
+
+		list := func(f models.PullRequestFilter) []string {
+			t.Helper()
+			if f.Limit == 0 {
+				f.Limit = 10
+			}
+			prs, err := repos.PullRequests.List(ctx, f)
+			require.NoError(t, err)
+			return prIDs(prs)
+		}
+
+		assert.Equal(t, []string{"pr2", "pr3", "pr4"}, list(models.PullRequestFilter{Status: custom.StatusOpen}))
+		assert.Equal(t, []string{"pr1", "pr2"}, list(models.PullRequestFilter{AuthorID: "u1"}))
+		assert.Equal(t, []string{"pr1", "pr3"}, list(models.PullRequestFilter{ReviewerID: "u2"}))
+		assert.Equal(t, []string{"pr3", "pr4"}, list(models.PullRequestFilter{TeamName: "frontend"}))
+		assert.Equal(t, []string{"pr4"}, list(models.PullRequestFilter{Search: "0% c"}))
+		assert.Empty(t, list(models.PullRequestFilter{Search: "pr_"}))
+
+		from, to := base.Add(time.Hour), base.Add(3*time.Hour)
+		assert.Equal(t, []string{"pr2", "pr3"}, list(models.PullRequestFilter{CreatedFrom: &from, CreatedTo: &to}))
+		assert.Equal(t, []string{"pr1"}, list(models.PullRequestFilter{MergedFrom: &base}))
+
+		assert.Equal(t, []string{"pr4", "pr3", "pr2", "pr1"}, list(models.PullRequestFilter{Desc: true}))
+		assert.Equal(t, []string{"pr4", "pr1", "pr2", "pr3"}, list(models.PullRequestFilter{Sort: models.SortName}))
+
+		after := models.PullRequests{ID: "pr2", Name: "PR pr2", CreatedAt: base.Add(time.Hour)}
+		assert.Equal(t, []string{"pr3"}, list(models.PullRequestFilter{After: &after, Limit: 1}))
+		assert.Equal(t, []string{"pr1"}, list(models.PullRequestFilter{After: &after, Desc: true}))
+		assert.Equal(t, []string{"pr3"}, list(models.PullRequestFilter{Sort: models.SortName, After: &after}))
+		assert.Equal(t, []string{"pr1"}, list(models.PullRequestFilter{Sort: models.SortID, After: &after, Desc: true}))
+	})
+
+	t.Run("CountOpenByTeam", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+		seedTeam(t, repos, "frontend", user("u2", "Bob", true))
+		seedPR(t, repos, "pr1", "u1", base)
+		seedPR(t, repos, "pr2", "u1", base)
+		seedPR(t, repos, "pr3", "u2", base)
+		merge(t, repos, "pr3")
+
+		counts, err := repos.PullRequests.CountOpenByTeam(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{"backend": 2}, counts)
+	})
+
+	t.Run("ConcurrentCreates", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true))
+
+		const workers = 16
+		var wg sync.WaitGroup
+		errs := make([]error, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id := string(rune('a'+i)) + "-pr"
+				errs[i] = repos.PullRequests.Create(ctx, &models.PullRequests{
+					ID: id, Name: id, AuthorID: "u1", Status: custom.StatusOpen,
+				})
+				if errs[i] == nil {
+					errs[i] = repos.Reviewers.AddOne(ctx, id, "u2")
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		counts, err := repos.Reviewers.CountOpenByReviewer(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, workers, counts["u2"])
+	})
+
+	t.Run("GetByIDForUpdateSerializesChecks", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+		seedPR(t, repos, "pr-1", "u1", base)
+
+		// Each worker merges the PR only if it still finds it open, as the
+		// service does; the row lock lets exactly one of them win.
+		const workers = 8
+		var wg sync.WaitGroup
+		var merged atomic.Int32
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+					pr, err := repos.PullRequests.GetByIDForUpdate(ctx, "pr-1")
+					if err != nil || pr.Status == custom.StatusMerged {
+						return err
+					}
+
+					mergedAt := base.Add(time.Hour)
+					pr.Status, pr.MergedAt = custom.StatusMerged, &mergedAt
+					merged.Add(1)
+					return repos.PullRequests.Update(ctx, pr)
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, 1, merged.Load())
+	})
+}
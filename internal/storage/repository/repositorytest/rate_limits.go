@@ -0,0 +1,35 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRateLimits(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("SweepDropsOnlyFullBuckets", func(t *testing.T) {
+		repos := open(t)
+
+		// One token per hour: the drained bucket stays empty for the test.
+		const rate = 1.0 / 3600
+		take := func() bool {
+			allowed, _, err := repos.RateLimits.Take(ctx, "ip:10.0.0.1", rate, 1)
+			require.NoError(t, err)
+			return allowed
+		}
+
+		assert.True(t, take())
+		assert.False(t, take())
+
+		require.NoError(t, repos.RateLimits.Sweep(ctx, time.Now()))
+		assert.False(t, take(), "a bucket that is still refilling survives the sweep")
+
+		require.NoError(t, repos.RateLimits.Sweep(ctx, time.Now().Add(2*time.Hour)))
+		assert.True(t, take(), "a full bucket is swept and starts over")
+	})
+}
@@ -0,0 +1,103 @@
+// Package repositorytest is the contract every repository backend must meet.
+// Backends run it from their own tests with a constructor for empty storage.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository"
+)
+
+// Open returns repositories over empty storage. It is called once per case.
+type Open func(t *testing.T) *repository.All
+
+// Run checks the backend returned by open against the contract.
+func Run(t *testing.T, open Open) {
+	t.Run("Teams", func(t *testing.T) { testTeams(t, open) })
+	t.Run("Users", func(t *testing.T) { testUsers(t, open) })
+	t.Run("PullRequests", func(t *testing.T) { testPullRequests(t, open) })
+	t.Run("Reviewers", func(t *testing.T) { testReviewers(t, open) })
+	t.Run("Snapshots", func(t *testing.T) { testSnapshots(t, open) })
+	t.Run("Stats", func(t *testing.T) { testStats(t, open) })
+	t.Run("Transactions", func(t *testing.T) { testTransactions(t, open) })
+	t.Run("Outbox", func(t *testing.T) { testOutbox(t, open) })
+	t.Run("JobRuns", func(t *testing.T) { testJobRuns(t, open) })
+	t.Run("IdempotencyKeys", func(t *testing.T) { testIdempotencyKeys(t, open) })
+	t.Run("RateLimits", func(t *testing.T) { testRateLimits(t, open) })
+}
+
+// RunConcurrent checks the cases that need transactions to run side by side.
+// Backends that run one transaction at a time skip it: their IDs already
+// follow commit order.
+func RunConcurrent(t *testing.T, open Open) {
+	t.Run("OutboxCommitOrder", func(t *testing.T) { testOutboxCommitOrder(t, open) })
+}
+
+// base is a Wednesday, so PRs created near it fall into one ISO week.
+var base = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func seedTeam(t *testing.T, repos *repository.All, name string, members ...models.Users) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, repos.Teams.Create(ctx, &models.Teams{Name: name}))
+	require.NoError(t, repos.Users.CreateOrUpdate(ctx, name, members))
+}
+
+func seedPR(t *testing.T, repos *repository.All, id, author string, createdAt time.Time, reviewers ...string) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, repos.PullRequests.Create(ctx, &models.PullRequests{
+		ID:        id,
+		Name:      "PR " + id,
+		AuthorID:  author,
+		Status:    custom.StatusOpen,
+		CreatedAt: createdAt,
+	}))
+
+	list := make([]models.Reviewers, 0, len(reviewers))
+	for _, r := range reviewers {
+		list = append(list, models.Reviewers{PRID: id, ReviewerID: r})
+	}
+	require.NoError(t, repos.Reviewers.Add(ctx, list))
+}
+
+// merge marks the PR merged half an hour after it was created.
+func merge(t *testing.T, repos *repository.All, id string) {
+	t.Helper()
+	ctx := context.Background()
+
+	pr, err := repos.PullRequests.GetByID(ctx, id)
+	require.NoError(t, err)
+
+	mergedAt := pr.CreatedAt.Add(30 * time.Minute)
+	pr.Status, pr.MergedAt = custom.StatusMerged, &mergedAt
+	require.NoError(t, repos.PullRequests.Update(ctx, pr))
+}
+
+func user(id, name string, active bool) models.Users {
+	return models.Users{ID: id, Username: name, IsActive: active}
+}
+
+func prIDs(prs []models.PullRequests) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return ids
+}
+
+func reviewerIDs(reviewers []models.Reviewers) []string {
+	ids := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		ids = append(ids, r.ReviewerID)
+	}
+	return ids
+}
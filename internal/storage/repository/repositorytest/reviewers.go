@@ -0,0 +1,91 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testReviewers(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("AddGetDelete", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+
+		require.NoError(t, repos.Reviewers.AddOne(ctx, "pr1", "u3"))
+		require.NoError(t, repos.Reviewers.Add(ctx, nil))
+
+		reviewers, err := repos.Reviewers.GetByPR(ctx, "pr1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, reviewerIDs(reviewers))
+		for _, r := range reviewers {
+			assert.WithinDuration(t, time.Now(), r.AssignedAt, time.Minute)
+		}
+
+		require.NoError(t, repos.Reviewers.Delete(ctx, "pr1", "u2"))
+		require.NoError(t, repos.Reviewers.Delete(ctx, "pr1", "u404"))
+
+		reviewers, err = repos.Reviewers.GetByPR(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, reviewerIDs(reviewers))
+	})
+
+	t.Run("AddChecksKeys", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+
+		assert.ErrorIs(t, repos.Reviewers.AddOne(ctx, "pr1", "u2"), custom.ErrDuplicateKey)
+		assert.ErrorIs(t, repos.Reviewers.AddOne(ctx, "pr404", "u2"), custom.ErrForeignKey)
+		assert.ErrorIs(t, repos.Reviewers.AddOne(ctx, "pr1", "u404"), custom.ErrForeignKey)
+
+		err := repos.Reviewers.Add(ctx, []models.Reviewers{{PRID: "pr1", ReviewerID: "u3"}, {PRID: "pr1", ReviewerID: "u2"}})
+		assert.ErrorIs(t, err, custom.ErrDuplicateKey)
+
+		reviewers, err := repos.Reviewers.GetByPR(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, reviewerIDs(reviewers), "a failed batch must not add any row")
+	})
+
+	t.Run("CountOpenByReviewer", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2", "u3")
+		seedPR(t, repos, "pr2", "u1", base, "u2")
+		seedPR(t, repos, "pr3", "u1", base, "u3")
+		merge(t, repos, "pr3")
+
+		counts, err := repos.Reviewers.CountOpenByReviewer(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{"u2": 2, "u3": 1}, counts)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+
+		require.NoError(t, repos.Reviewers.LogReassignment(ctx, "pr1", "u2", "u3", base))
+		assert.ErrorIs(t, repos.Reviewers.LogReassignment(ctx, "pr1", "u2", "u404", base), custom.ErrForeignKey)
+
+		first := &models.Reviews{PRID: "pr1", ReviewerID: "u3", Decision: custom.DecisionCommented}
+		second := &models.Reviews{PRID: "pr1", ReviewerID: "u3", Decision: custom.DecisionApproved}
+		require.NoError(t, repos.Reviewers.AddReview(ctx, first))
+		require.NoError(t, repos.Reviewers.AddReview(ctx, second))
+		assert.NotZero(t, first.ID)
+		assert.Greater(t, second.ID, first.ID)
+		assert.WithinDuration(t, time.Now(), first.SubmittedAt, time.Minute)
+
+		err := repos.Reviewers.AddReview(ctx, &models.Reviews{PRID: "pr404", ReviewerID: "u3", Decision: custom.DecisionApproved})
+		assert.ErrorIs(t, err, custom.ErrForeignKey)
+	})
+}
@@ -0,0 +1,96 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testSnapshots(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("LoadAndRestore", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", false))
+		seedTeam(t, repos, "empty")
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base, "u3")
+		merge(t, repos, "pr2")
+		require.NoError(t, repos.Reviewers.LogReassignment(ctx, "pr1", "u3", "u2", base))
+		require.NoError(t, repos.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr1", ReviewerID: "u2", Decision: custom.DecisionApproved,
+		}))
+
+		data, err := repos.Snapshots.Load(ctx)
+		require.NoError(t, err)
+		assertDataset(t, data)
+
+		restored := open(t)
+		require.NoError(t, restored.Snapshots.Restore(ctx, data))
+
+		again, err := restored.Snapshots.Load(ctx)
+		require.NoError(t, err)
+		assertDataset(t, again)
+
+		pr, err := restored.PullRequests.GetByID(ctx, "pr2")
+		require.NoError(t, err)
+		assert.Equal(t, custom.StatusMerged, pr.Status)
+		assert.NotNil(t, pr.MergedAt)
+		assert.NoError(t, restored.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr2", ReviewerID: "u3", Decision: custom.DecisionCommented,
+		}))
+	})
+
+	t.Run("RestoreRequiresEmptyStorage", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend")
+
+		err := repos.Snapshots.Restore(ctx, &models.Dataset{Teams: []models.Teams{{Name: "frontend"}}})
+
+		assert.ErrorIs(t, err, custom.ErrDatabaseNotEmpty)
+	})
+
+	t.Run("RestoreIsAtomic", func(t *testing.T) {
+		repos := open(t)
+
+		err := repos.Snapshots.Restore(ctx, &models.Dataset{
+			Teams:        []models.Teams{{Name: "backend"}},
+			Users:        []models.Users{user("u1", "Alice", true)},
+			PullRequests: []models.PullRequests{{ID: "pr1", Name: "orphan", AuthorID: "u404", Status: custom.StatusOpen}},
+		})
+		require.Error(t, err)
+
+		data, err := repos.Snapshots.Load(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, data.Teams)
+		assert.Empty(t, data.Users)
+	})
+}
+
+func assertDataset(t *testing.T, data *models.Dataset) {
+	t.Helper()
+
+	teams := make([]string, 0, len(data.Teams))
+	for _, team := range data.Teams {
+		teams = append(teams, team.Name)
+	}
+	users := make([]string, 0, len(data.Users))
+	for _, u := range data.Users {
+		users = append(users, u.ID)
+	}
+
+	assert.Equal(t, []string{"backend", "empty"}, teams)
+	assert.Equal(t, []string{"u1", "u2", "u3"}, users)
+	assert.Equal(t, []string{"pr1", "pr2"}, prIDs(data.PullRequests))
+	assert.Equal(t, []string{"u2", "u3"}, reviewerIDs(data.Reviewers))
+	require.Len(t, data.Reassignments, 1)
+	assert.Equal(t, "u3", data.Reassignments[0].OldReviewerID)
+	assert.True(t, base.Equal(data.Reassignments[0].OldAssignedAt), data.Reassignments[0].OldAssignedAt)
+	require.Len(t, data.Reviews, 1)
+	assert.Equal(t, custom.DecisionApproved, data.Reviews[0].Decision)
+}
@@ -0,0 +1,132 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testStats(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("Reviewers", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true), user("u3", "Carol", false))
+		seedTeam(t, repos, "frontend", user("u4", "Dan", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base, "u2")
+		// Carol held pr2 before it moved to Bob; the assignment still counts.
+		require.NoError(t, repos.Reviewers.LogReassignment(ctx, "pr2", "u3", "u2", time.Now()))
+		require.NoError(t, repos.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr1", ReviewerID: "u2", Decision: custom.DecisionApproved,
+		}))
+
+		now := time.Now()
+		rows, err := repos.Stats.Reviewers(ctx, models.StatsWindow{
+			From:     now.Add(-time.Hour),
+			To:       now.Add(time.Hour),
+			TeamName: "backend",
+		})
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.UserID)
+		}
+		require.Equal(t, []string{"u1", "u2", "u3"}, ids)
+
+		bob := rows[1]
+		assert.EqualValues(t, 2, bob.Assignments)
+		assert.EqualValues(t, 1, bob.Decided)
+		require.NotNil(t, bob.AvgDecisionSeconds)
+		assert.InDelta(t, 0, *bob.AvgDecisionSeconds, 60)
+		assert.EqualValues(t, 2, bob.OpenReviews)
+		assert.EqualValues(t, 1, bob.ReassignedTo)
+		carol := rows[2]
+		assert.EqualValues(t, 1, carol.Assignments)
+		assert.Zero(t, carol.Decided)
+		assert.Zero(t, carol.OpenReviews)
+		assert.EqualValues(t, 1, carol.ReassignedFrom)
+		assert.Nil(t, rows[0].AvgDecisionSeconds)
+
+		past, err := repos.Stats.Reviewers(ctx, models.StatsWindow{From: base, To: base.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, past, 4)
+		for _, row := range past {
+			assert.Zero(t, row.Assignments)
+			assert.Zero(t, row.ReassignedFrom+row.ReassignedTo)
+		}
+	})
+
+	t.Run("MergeIsNotADecision", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base, "u2")
+		merge(t, repos, "pr1")
+		merge(t, repos, "pr2")
+		require.NoError(t, repos.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr2", ReviewerID: "u2", Decision: custom.DecisionApproved,
+		}))
+
+		now := time.Now()
+		rows, err := repos.Stats.Reviewers(ctx, models.StatsWindow{From: now.Add(-time.Hour), To: now.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		bob := rows[1]
+		assert.EqualValues(t, 2, bob.Assignments)
+		assert.EqualValues(t, 1, bob.Decided)
+		assert.EqualValues(t, 1, bob.MergedUnreviewed)
+		require.NotNil(t, bob.AvgDecisionSeconds)
+		assert.InDelta(t, 0, *bob.AvgDecisionSeconds, 60)
+	})
+
+	t.Run("CycleTimes", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base.Add(7*24*time.Hour), "u2")
+		seedPR(t, repos, "pr3", "u1", base.Add(-30*24*time.Hour))
+		merge(t, repos, "pr1")
+		require.NoError(t, repos.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr1", ReviewerID: "u2", Decision: custom.DecisionCommented, SubmittedAt: base.Add(10 * time.Minute),
+		}))
+		require.NoError(t, repos.Reviewers.AddReview(ctx, &models.Reviews{
+			PRID: "pr1", ReviewerID: "u2", Decision: custom.DecisionApproved, SubmittedAt: base.Add(20 * time.Minute),
+		}))
+
+		rows, err := repos.Stats.CycleTimes(ctx, models.StatsWindow{From: base.Add(-time.Hour), To: base.Add(8 * 24 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		total := rows[0]
+		assert.Equal(t, "backend", total.TeamName)
+		assert.Nil(t, total.Week)
+		assert.EqualValues(t, 2, total.PullRequests)
+		assert.EqualValues(t, 1, total.MergeCount)
+
+		week := rows[1]
+		require.NotNil(t, week.Week)
+		assert.True(t, time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC).Equal(*week.Week), "week starts on Monday: %s", week.Week)
+		assert.EqualValues(t, 1, week.PullRequests)
+		require.NotNil(t, week.FirstReviewP50)
+		assert.InDelta(t, 600, *week.FirstReviewP50, 0.001)
+		require.NotNil(t, week.ApprovalP90)
+		assert.InDelta(t, 1200, *week.ApprovalP90, 0.001)
+		require.NotNil(t, week.MergeP75)
+		assert.InDelta(t, 1800, *week.MergeP75, 0.001)
+
+		next := rows[2]
+		require.NotNil(t, next.Week)
+		assert.True(t, next.Week.After(*week.Week))
+		assert.Zero(t, next.FirstReviewCount)
+		assert.Nil(t, next.FirstReviewP50)
+	})
+}
@@ -0,0 +1,75 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testTeams(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("GetByNameLoadsMembers", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", false))
+
+		team, err := repos.Teams.GetByName(ctx, "backend")
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", team.Name)
+		require.Len(t, team.Users, 2)
+		for _, u := range team.Users {
+			require.NotNil(t, u.TeamName)
+			assert.Equal(t, "backend", *u.TeamName)
+		}
+	})
+
+	t.Run("GetByNameMissing", func(t *testing.T) {
+		_, err := open(t).Teams.GetByName(ctx, "nope")
+
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+
+	t.Run("CreateDuplicate", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend")
+
+		err := repos.Teams.Create(ctx, &models.Teams{Name: "backend"})
+
+		assert.ErrorIs(t, err, custom.ErrDuplicateKey)
+	})
+
+	t.Run("GetAllSortedByName", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "frontend")
+		seedTeam(t, repos, "backend")
+
+		teams, err := repos.Teams.GetAll(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Teams{{Name: "backend"}, {Name: "frontend"}}, teams)
+	})
+
+	t.Run("ListCountsMembersAndPages", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", false))
+		seedTeam(t, repos, "empty")
+		seedTeam(t, repos, "frontend", user("u3", "Carol", true))
+
+		first, err := repos.Teams.List(ctx, models.TeamFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []models.TeamSummary{
+			{Name: "backend", Members: 2, ActiveMembers: 1},
+			{Name: "empty"},
+		}, first)
+
+		rest, err := repos.Teams.List(ctx, models.TeamFilter{After: "empty", Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []models.TeamSummary{{Name: "frontend", Members: 1, ActiveMembers: 1}}, rest)
+	})
+}
@@ -0,0 +1,89 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testTransactions(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("Commit", func(t *testing.T) {
+		repos := open(t)
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &models.Teams{Name: "backend"}); err != nil {
+				return err
+			}
+			return repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{user("u1", "Alice", true)})
+		})
+
+		require.NoError(t, err)
+		team, err := repos.Teams.GetByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Len(t, team.Users, 1)
+	})
+
+	t.Run("RollbackOnError", func(t *testing.T) {
+		repos := open(t)
+		failure := errors.New("boom")
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &models.Teams{Name: "backend"}); err != nil {
+				return err
+			}
+			if err := repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{user("u1", "Alice", true)}); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		_, err = repos.Teams.GetByName(ctx, "backend")
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+		_, err = repos.Users.GetByID(ctx, "u1")
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+
+	t.Run("DuplicateRollsBackEarlierWrites", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend")
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			if err := repos.Teams.Create(ctx, &models.Teams{Name: "frontend"}); err != nil {
+				return err
+			}
+			return repos.Teams.Create(ctx, &models.Teams{Name: "backend"})
+		})
+
+		assert.ErrorIs(t, err, custom.ErrDuplicateKey)
+		_, err = repos.Teams.GetByName(ctx, "frontend")
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+
+	t.Run("NestedJoinsOuter", func(t *testing.T) {
+		repos := open(t)
+		failure := errors.New("boom")
+
+		err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+			err := repos.Tx.InTx(ctx, func(ctx context.Context) error {
+				return repos.Teams.Create(ctx, &models.Teams{Name: "backend"})
+			})
+			if err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		_, err = repos.Teams.GetByName(ctx, "backend")
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+}
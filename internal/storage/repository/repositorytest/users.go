@@ -0,0 +1,180 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+)
+
+func testUsers(t *testing.T, open Open) {
+	ctx := context.Background()
+
+	t.Run("GetByIDMissing", func(t *testing.T) {
+		_, err := open(t).Users.GetByID(ctx, "u404")
+
+		assert.ErrorIs(t, err, custom.ErrNotFound)
+	})
+
+	t.Run("CreateOrUpdateUpserts", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+		seedTeam(t, repos, "frontend")
+
+		before, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, "frontend", []models.Users{user("u1", "Alice B.", false)}))
+
+		after, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "Alice B.", after.Username)
+		assert.False(t, after.IsActive)
+		require.NotNil(t, after.TeamName)
+		assert.Equal(t, "frontend", *after.TeamName)
+		assert.True(t, before.CreatedAt.Equal(after.CreatedAt), "created_at must survive an update")
+	})
+
+	t.Run("CreateOrUpdateUnknownTeam", func(t *testing.T) {
+		err := open(t).Users.CreateOrUpdate(ctx, "ghosts", []models.Users{user("u1", "Alice", true)})
+
+		assert.ErrorIs(t, err, custom.ErrForeignKey)
+	})
+
+	t.Run("CreateOrUpdateNoMembers", func(t *testing.T) {
+		assert.NoError(t, open(t).Users.CreateOrUpdate(ctx, "ghosts", nil))
+	})
+
+	t.Run("GetActiveByTeam", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "Bob", false), user("u3", "Carol", true))
+		seedTeam(t, repos, "frontend", user("u4", "Dan", true))
+
+		users, err := repos.Users.GetActiveByTeam(ctx, "backend")
+
+		require.NoError(t, err)
+		ids := make([]string, 0, len(users))
+		for _, u := range users {
+			ids = append(ids, u.ID)
+		}
+		assert.ElementsMatch(t, []string{"u1", "u3"}, ids)
+	})
+
+	t.Run("UpdateIsActive", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+
+		require.NoError(t, repos.Users.UpdateIsActive(ctx, "u1", false))
+		require.NoError(t, repos.Users.UpdateIsActive(ctx, "u404", false))
+
+		u, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.False(t, u.IsActive)
+	})
+
+	t.Run("UpdateSlackID", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+
+		slackID := "U024BE7LH"
+		require.NoError(t, repos.Users.UpdateSlackID(ctx, "u1", &slackID))
+		require.NoError(t, repos.Users.UpdateSlackID(ctx, "u404", &slackID))
+
+		u, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, u.SlackID)
+		assert.Equal(t, slackID, *u.SlackID)
+
+		// An upsert without a Slack ID keeps the stored one.
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{user("u1", "Alice B.", true)}))
+		u, err = repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, u.SlackID)
+		assert.Equal(t, slackID, *u.SlackID)
+
+		require.NoError(t, repos.Users.UpdateSlackID(ctx, "u1", nil))
+		u, err = repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Nil(t, u.SlackID)
+	})
+
+	t.Run("UpdateEmail", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true))
+
+		email := "alice@example.com"
+		require.NoError(t, repos.Users.UpdateEmail(ctx, "u1", &email, true))
+		require.NoError(t, repos.Users.UpdateEmail(ctx, "u404", &email, true))
+
+		u, err := repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, u.Email)
+		assert.Equal(t, email, *u.Email)
+		assert.True(t, u.EmailOptOut)
+
+		// An upsert keeps the stored email and opt-out.
+		require.NoError(t, repos.Users.CreateOrUpdate(ctx, "backend", []models.Users{user("u1", "Alice B.", true)}))
+		u, err = repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		require.NotNil(t, u.Email)
+		assert.Equal(t, email, *u.Email)
+		assert.True(t, u.EmailOptOut)
+
+		require.NoError(t, repos.Users.UpdateEmail(ctx, "u1", nil, false))
+		u, err = repos.Users.GetByID(ctx, "u1")
+		require.NoError(t, err)
+		assert.Nil(t, u.Email)
+		assert.False(t, u.EmailOptOut)
+	})
+
+	t.Run("GetAllSortedByID", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u2", "Bob", true), user("u1", "Alice", true))
+
+		users, err := repos.Users.GetAll(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "u1", users[0].ID)
+		assert.Equal(t, "u2", users[1].ID)
+	})
+
+	t.Run("ListFiltersAndCountsOpenReviews", func(t *testing.T) {
+		repos := open(t)
+		seedTeam(t, repos, "backend", user("u1", "Alice", true), user("u2", "alex", true), user("u3", "Bob", false))
+		seedTeam(t, repos, "frontend", user("u4", "Al_", true))
+		seedPR(t, repos, "pr1", "u1", base, "u2")
+		seedPR(t, repos, "pr2", "u1", base, "u2")
+		seedPR(t, repos, "pr3", "u3", base, "u2")
+
+		merge(t, repos, "pr3")
+
+		active := true
+		users, err := repos.Users.List(ctx, models.UserFilter{
+			TeamName:       "backend",
+			IsActive:       &active,
+			UsernamePrefix: "AL",
+			Limit:          10,
+		})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, "u1", users[0].ID)
+		assert.Zero(t, users[0].OpenReviews)
+		assert.Equal(t, "u2", users[1].ID)
+		assert.EqualValues(t, 2, users[1].OpenReviews)
+
+		escaped, err := repos.Users.List(ctx, models.UserFilter{UsernamePrefix: "al_", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, escaped, 1)
+		assert.Equal(t, "u4", escaped[0].ID)
+
+		page, err := repos.Users.List(ctx, models.UserFilter{After: "u2", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "u3", page[0].ID)
+	})
+}
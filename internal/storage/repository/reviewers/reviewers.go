@@ -2,10 +2,13 @@ package reviewers
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
+	"mPR/internal/custom"
 	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
 )
 
 type Database struct {
@@ -23,12 +26,12 @@ func (d *Database) Add(ctx context.Context, list []models.Reviewers) error {
 		return nil
 	}
 
-	return d.db.WithContext(ctx).Create(&list).Error
+	return transaction.DB(ctx, d.db).Create(&list).Error
 }
 
 func (d *Database) GetByPR(ctx context.Context, prID string) ([]models.Reviewers, error) {
 	var reviewers []models.Reviewers
-	err := d.db.WithContext(ctx).
+	err := transaction.DB(ctx, d.db).
 		Where("pr_id = ?", prID).
 		Find(&reviewers).Error
 
@@ -36,25 +39,54 @@ func (d *Database) GetByPR(ctx context.Context, prID string) ([]models.Reviewers
 }
 
 func (d *Database) Delete(ctx context.Context, prID string, reviewerID string) error {
-	return d.db.WithContext(ctx).
+	return transaction.DB(ctx, d.db).
 		Where("pr_id = ? AND reviewer_id = ?", prID, reviewerID).
 		Delete(&models.Reviewers{}).Error
 }
 
 func (d *Database) AddOne(ctx context.Context, prID string, reviewerID string) error {
-	return d.db.WithContext(ctx).
+	return transaction.DB(ctx, d.db).
 		Create(&models.Reviewers{
 			PRID:       prID,
 			ReviewerID: reviewerID,
 		}).Error
 }
 
-func (d *Database) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
-	var ids []string
-	err := d.db.WithContext(ctx).
+func (d *Database) LogReassignment(ctx context.Context, prID, oldID, newID string, oldAssignedAt time.Time) error {
+	return transaction.DB(ctx, d.db).
+		Create(&models.Reassignments{
+			PRID:          prID,
+			OldReviewerID: oldID,
+			NewReviewerID: newID,
+			OldAssignedAt: oldAssignedAt,
+		}).Error
+}
+
+func (d *Database) AddReview(ctx context.Context, review *models.Reviews) error {
+	return transaction.DB(ctx, d.db).Create(review).Error
+}
+
+func (d *Database) CountOpenByReviewer(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		ReviewerID string
+		Count      int64
+	}
+
+	err := transaction.DB(ctx, d.db).
 		Model(&models.Reviewers{}).
-		Where("reviewer_id = ?", reviewerID).
-		Pluck("pr_id", &ids).Error
+		Select("reviewers.reviewer_id AS reviewer_id, COUNT(*) AS count").
+		Joins("JOIN pull_requests pr ON pr.pr_id = reviewers.pr_id").
+		Where("pr.status = ?", custom.StatusOpen).
+		Group("reviewers.reviewer_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		counts[r.ReviewerID] = r.Count
+	}
 
-	return ids, err
+	return counts, nil
 }
@@ -0,0 +1,101 @@
+package snapshots
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+const batchSize = 500
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+// Load reads every table inside one repeatable-read transaction so the
+// snapshot is consistent even while the service keeps accepting writes.
+func (d *Database) Load(ctx context.Context) (*models.Dataset, error) {
+	var data models.Dataset
+
+	err := transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Order("team_name").Find(&data.Teams).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("user_id").Find(&data.Users).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("pr_id").Find(&data.PullRequests).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("pr_id, reviewer_id").Find(&data.Reviewers).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("id").Find(&data.Reassignments).Error; err != nil {
+			return err
+		}
+		return tx.Order("id").Find(&data.Reviews).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (d *Database) Restore(ctx context.Context, data *models.Dataset) error {
+	return transaction.DB(ctx, d.db).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []any{&models.Teams{}, &models.Users{}, &models.PullRequests{}} {
+			var count int64
+			if err := tx.Model(table).Limit(1).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return custom.ErrDatabaseNotEmpty
+			}
+		}
+
+		if len(data.Teams) > 0 {
+			if err := tx.Omit("Users").CreateInBatches(data.Teams, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Users) > 0 {
+			if err := tx.Omit("Team").CreateInBatches(data.Users, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.PullRequests) > 0 {
+			if err := tx.Omit("Author", "Reviewers").CreateInBatches(data.PullRequests, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Reviewers) > 0 {
+			if err := tx.CreateInBatches(data.Reviewers, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Reassignments) > 0 {
+			if err := tx.CreateInBatches(data.Reassignments, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Reviews) > 0 {
+			if err := tx.CreateInBatches(data.Reviews, batchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"mPR/internal/storage/models"
+)
+
+// CycleSample holds one PR's durations in seconds from creation to its first
+// review, first approval and merge; nil means it has not got there yet.
+type CycleSample struct {
+	TeamName    string    `gorm:"column:team_name"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	FirstReview *float64  `gorm:"column:first_review"`
+	Approval    *float64  `gorm:"column:approval"`
+	Merged      *float64  `gorm:"column:merged"`
+}
+
+// AggregateCycleTimes groups samples by team and week and takes the same
+// percentiles cycleTimeQuery does, for backends without percentile_cont.
+func AggregateCycleTimes(samples []CycleSample) []models.CycleTimeStats {
+	type weekKey struct {
+		team string
+		week time.Time
+	}
+	totals := make(map[string]*cycleSamples)
+	weeks := make(map[weekKey]*cycleSamples)
+
+	for _, sample := range samples {
+		key := weekKey{team: sample.TeamName, week: startOfWeek(sample.CreatedAt)}
+		if totals[key.team] == nil {
+			totals[key.team] = &cycleSamples{}
+		}
+		if weeks[key] == nil {
+			weeks[key] = &cycleSamples{}
+		}
+
+		totals[key.team].add(sample)
+		weeks[key].add(sample)
+	}
+
+	rows := make([]models.CycleTimeStats, 0, len(totals)+len(weeks))
+	for team, samples := range totals {
+		rows = append(rows, samples.row(team, nil))
+	}
+	for key, samples := range weeks {
+		week := key.week
+		rows = append(rows, samples.row(key.team, &week))
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.TeamName != b.TeamName {
+			return a.TeamName < b.TeamName
+		}
+		if a.Week == nil || b.Week == nil {
+			return a.Week == nil && b.Week != nil
+		}
+		return a.Week.Before(*b.Week)
+	})
+
+	return rows
+}
+
+type cycleSamples struct {
+	pullRequests int64
+	firstReview  []float64
+	approval     []float64
+	merged       []float64
+}
+
+func (c *cycleSamples) add(sample CycleSample) {
+	c.pullRequests++
+	if sample.FirstReview != nil {
+		c.firstReview = append(c.firstReview, *sample.FirstReview)
+	}
+	if sample.Approval != nil {
+		c.approval = append(c.approval, *sample.Approval)
+	}
+	if sample.Merged != nil {
+		c.merged = append(c.merged, *sample.Merged)
+	}
+}
+
+func (c *cycleSamples) row(team string, week *time.Time) models.CycleTimeStats {
+	row := models.CycleTimeStats{
+		TeamName:         team,
+		Week:             week,
+		PullRequests:     c.pullRequests,
+		FirstReviewCount: int64(len(c.firstReview)),
+		ApprovalCount:    int64(len(c.approval)),
+		MergeCount:       int64(len(c.merged)),
+	}
+	row.FirstReviewP50, row.FirstReviewP75, row.FirstReviewP90 = percentiles(c.firstReview)
+	row.ApprovalP50, row.ApprovalP75, row.ApprovalP90 = percentiles(c.approval)
+	row.MergeP50, row.MergeP75, row.MergeP90 = percentiles(c.merged)
+
+	return row
+}
+
+// startOfWeek truncates to Monday midnight UTC, as date_trunc('week') does.
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func percentiles(samples []float64) (p50, p75, p90 *float64) {
+	if len(samples) == 0 {
+		return nil, nil, nil
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	return percentileCont(sorted, 0.5), percentileCont(sorted, 0.75), percentileCont(sorted, 0.9)
+}
+
+// percentileCont interpolates linearly between the closest ranks, like the
+// percentile_cont aggregate.
+func percentileCont(sorted []float64, p float64) *float64 {
+	pos := p * float64(len(sorted)-1)
+	lower := math.Floor(pos)
+	value := sorted[int(lower)]
+	if frac := pos - lower; frac > 0 {
+		value += frac * (sorted[int(lower)+1] - value)
+	}
+	return &value
+}
@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"context"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+// SQLite has no interval arithmetic, percentile_cont or grouping sets, so its
+// queries measure durations with julianday and leave the percentiles to
+// AggregateCycleTimes. Definitions match the Postgres queries above.
+const sqliteReviewerStatsQuery = `
+SELECT u.user_id, u.username, u.team_name, u.is_active,
+       COALESCE(a.assignments, 0) AS assignments,
+       COALESCE(a.decided, 0) AS decided,
+       a.avg_decision_seconds,
+       COALESCE(a.merged_unreviewed, 0) AS merged_unreviewed,
+       COALESCE(o.open_reviews, 0) AS open_reviews,
+       COALESCE(rf.reassigned_from, 0) AS reassigned_from,
+       COALESCE(rt.reassigned_to, 0) AS reassigned_to
+FROM users u
+LEFT JOIN (
+    SELECT s.reviewer_id,
+           COUNT(*) AS assignments,
+           COUNT(d.decided_at) AS decided,
+           AVG((julianday(d.decided_at) - julianday(s.assigned_at)) * 86400) AS avg_decision_seconds,
+           SUM(CASE WHEN d.decided_at IS NULL AND s.merged_at IS NOT NULL THEN 1 ELSE 0 END) AS merged_unreviewed
+    FROM (
+        SELECT r.pr_id, r.reviewer_id, r.assigned_at, pr.merged_at
+        FROM reviewers r
+        JOIN pull_requests pr ON pr.pr_id = r.pr_id
+        UNION ALL
+        SELECT pr_id, old_reviewer_id, old_assigned_at, NULL
+        FROM reviewer_reassignments
+    ) s
+    LEFT JOIN (
+        SELECT pr_id, reviewer_id, MIN(submitted_at) AS decided_at
+        FROM reviews
+        GROUP BY pr_id, reviewer_id
+    ) d ON d.pr_id = s.pr_id AND d.reviewer_id = s.reviewer_id
+    WHERE s.assigned_at >= @from AND s.assigned_at < @to
+    GROUP BY s.reviewer_id
+) a ON a.reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT r.reviewer_id, COUNT(*) AS open_reviews
+    FROM reviewers r
+    JOIN pull_requests pr ON pr.pr_id = r.pr_id
+    WHERE pr.status = @open
+    GROUP BY r.reviewer_id
+) o ON o.reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT old_reviewer_id, COUNT(*) AS reassigned_from
+    FROM reviewer_reassignments
+    WHERE reassigned_at >= @from AND reassigned_at < @to
+    GROUP BY old_reviewer_id
+) rf ON rf.old_reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT new_reviewer_id, COUNT(*) AS reassigned_to
+    FROM reviewer_reassignments
+    WHERE reassigned_at >= @from AND reassigned_at < @to
+    GROUP BY new_reviewer_id
+) rt ON rt.new_reviewer_id = u.user_id
+WHERE @team = '' OR u.team_name = @team
+ORDER BY u.team_name IS NULL, u.team_name, u.user_id`
+
+const sqliteCycleSampleQuery = `
+SELECT u.team_name, pr.created_at,
+       (julianday(rv.first_review_at) - julianday(pr.created_at)) * 86400 AS first_review,
+       (julianday(rv.first_approval_at) - julianday(pr.created_at)) * 86400 AS approval,
+       (julianday(pr.merged_at) - julianday(pr.created_at)) * 86400 AS merged
+FROM pull_requests pr
+JOIN users u ON u.user_id = pr.author_id
+LEFT JOIN (
+    SELECT pr_id,
+           MIN(submitted_at) AS first_review_at,
+           MIN(CASE WHEN decision = @approved THEN submitted_at END) AS first_approval_at
+    FROM reviews
+    GROUP BY pr_id
+) rv ON rv.pr_id = pr.pr_id
+WHERE pr.created_at >= @from AND pr.created_at < @to
+  AND u.team_name IS NOT NULL
+  AND (@team = '' OR u.team_name = @team)`
+
+func (d *Database) sqliteReviewers(ctx context.Context, window models.StatsWindow) ([]models.ReviewerStats, error) {
+	var rows []models.ReviewerStats
+	err := transaction.DB(ctx, d.db).
+		Raw(sqliteReviewerStatsQuery, map[string]any{
+			"from": window.From,
+			"to":   window.To,
+			"team": window.TeamName,
+			"open": custom.StatusOpen,
+		}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (d *Database) sqliteCycleTimes(ctx context.Context, window models.StatsWindow) ([]models.CycleTimeStats, error) {
+	var samples []CycleSample
+	err := transaction.DB(ctx, d.db).
+		Raw(sqliteCycleSampleQuery, map[string]any{
+			"from":     window.From,
+			"to":       window.To,
+			"team":     window.TeamName,
+			"approved": custom.DecisionApproved,
+		}).
+		Scan(&samples).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return AggregateCycleTimes(samples), nil
+}
@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"mPR/internal/custom"
+	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
+)
+
+// Assignments are the current reviewers plus the ones reassigned away, so a
+// handed-off PR still counts for whoever held it. A reviewer's decision is
+// their first submitted review; a PR merged before its current reviewer
+// submitted one is counted apart, since the merge was someone else's call.
+const reviewerStatsQuery = `
+SELECT u.user_id, u.username, u.team_name, u.is_active,
+       COALESCE(a.assignments, 0) AS assignments,
+       COALESCE(a.decided, 0) AS decided,
+       a.avg_decision_seconds,
+       COALESCE(a.merged_unreviewed, 0) AS merged_unreviewed,
+       COALESCE(o.open_reviews, 0) AS open_reviews,
+       COALESCE(rf.reassigned_from, 0) AS reassigned_from,
+       COALESCE(rt.reassigned_to, 0) AS reassigned_to
+FROM users u
+LEFT JOIN (
+    SELECT s.reviewer_id,
+           COUNT(*) AS assignments,
+           COUNT(d.decided_at) AS decided,
+           AVG(EXTRACT(EPOCH FROM (d.decided_at - s.assigned_at)))::double precision AS avg_decision_seconds,
+           COUNT(*) FILTER (WHERE d.decided_at IS NULL AND s.merged_at IS NOT NULL) AS merged_unreviewed
+    FROM (
+        SELECT r.pr_id, r.reviewer_id, r.assigned_at, pr.merged_at::timestamptz AS merged_at
+        FROM reviewers r
+        JOIN pull_requests pr ON pr.pr_id = r.pr_id
+        UNION ALL
+        SELECT pr_id, old_reviewer_id, old_assigned_at, NULL
+        FROM reviewer_reassignments
+    ) s
+    LEFT JOIN (
+        SELECT pr_id, reviewer_id, MIN(submitted_at) AS decided_at
+        FROM reviews
+        GROUP BY pr_id, reviewer_id
+    ) d ON d.pr_id = s.pr_id AND d.reviewer_id = s.reviewer_id
+    WHERE s.assigned_at >= @from AND s.assigned_at < @to
+    GROUP BY s.reviewer_id
+) a ON a.reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT r.reviewer_id, COUNT(*) AS open_reviews
+    FROM reviewers r
+    JOIN pull_requests pr ON pr.pr_id = r.pr_id
+    WHERE pr.status = @open
+    GROUP BY r.reviewer_id
+) o ON o.reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT old_reviewer_id, COUNT(*) AS reassigned_from
+    FROM reviewer_reassignments
+    WHERE reassigned_at >= @from AND reassigned_at < @to
+    GROUP BY old_reviewer_id
+) rf ON rf.old_reviewer_id = u.user_id
+LEFT JOIN (
+    SELECT new_reviewer_id, COUNT(*) AS reassigned_to
+    FROM reviewer_reassignments
+    WHERE reassigned_at >= @from AND reassigned_at < @to
+    GROUP BY new_reviewer_id
+) rt ON rt.new_reviewer_id = u.user_id
+WHERE @team = '' OR u.team_name = @team
+ORDER BY u.team_name, u.user_id`
+
+// cycleTimeQuery measures every PR created in the window from creation to its
+// first review, first approval and merge, and takes percentiles per team and
+// week plus a per-team total row with a NULL week.
+var cycleTimeQuery = `
+WITH cycle AS (
+    SELECT u.team_name,
+           date_trunc('week', pr.created_at) AS week,
+           EXTRACT(EPOCH FROM (rv.first_review_at - pr.created_at::timestamptz))::double precision AS first_review,
+           EXTRACT(EPOCH FROM (rv.first_approval_at - pr.created_at::timestamptz))::double precision AS approval,
+           EXTRACT(EPOCH FROM (pr.merged_at - pr.created_at))::double precision AS merged
+    FROM pull_requests pr
+    JOIN users u ON u.user_id = pr.author_id
+    LEFT JOIN (
+        SELECT pr_id,
+               MIN(submitted_at) AS first_review_at,
+               MIN(submitted_at) FILTER (WHERE decision = @approved) AS first_approval_at
+        FROM reviews
+        GROUP BY pr_id
+    ) rv ON rv.pr_id = pr.pr_id
+    WHERE pr.created_at >= @from AND pr.created_at < @to
+      AND u.team_name IS NOT NULL
+      AND (@team = '' OR u.team_name = @team)
+)
+SELECT team_name, week, COUNT(*) AS pull_requests,
+       ` + percentileColumns("first_review") + `,
+       ` + percentileColumns("approval") + `,
+       ` + percentileColumns("merged") + `
+FROM cycle
+GROUP BY GROUPING SETS ((team_name, week), (team_name))
+ORDER BY team_name, week NULLS FIRST`
+
+func percentileColumns(metric string) string {
+	return fmt.Sprintf("COUNT(%[1]s) AS %[1]s_count, "+
+		"percentile_cont(0.5) WITHIN GROUP (ORDER BY %[1]s) AS %[1]s_p50, "+
+		"percentile_cont(0.75) WITHIN GROUP (ORDER BY %[1]s) AS %[1]s_p75, "+
+		"percentile_cont(0.9) WITHIN GROUP (ORDER BY %[1]s) AS %[1]s_p90", metric)
+}
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+func (d *Database) Reviewers(ctx context.Context, window models.StatsWindow) ([]models.ReviewerStats, error) {
+	if d.db.Name() == "sqlite" {
+		return d.sqliteReviewers(ctx, window)
+	}
+
+	var rows []models.ReviewerStats
+	err := transaction.DB(ctx, d.db).
+		Raw(reviewerStatsQuery, map[string]any{
+			"from": window.From,
+			"to":   window.To,
+			"team": window.TeamName,
+			"open": custom.StatusOpen,
+		}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (d *Database) CycleTimes(ctx context.Context, window models.StatsWindow) ([]models.CycleTimeStats, error) {
+	if d.db.Name() == "sqlite" {
+		return d.sqliteCycleTimes(ctx, window)
+	}
+
+	var rows []models.CycleTimeStats
+	err := transaction.DB(ctx, d.db).
+		Raw(cycleTimeQuery, map[string]any{
+			"from":     window.From,
+			"to":       window.To,
+			"team":     window.TeamName,
+			"approved": custom.DecisionApproved,
+		}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
 )
 
 type Database struct {
@@ -19,12 +20,12 @@ func New(db *gorm.DB) *Database {
 }
 
 func (d *Database) Create(ctx context.Context, team *models.Teams) error {
-	return d.db.WithContext(ctx).Create(team).Error
+	return transaction.DB(ctx, d.db).Create(team).Error
 }
 
 func (d *Database) GetByName(ctx context.Context, name string) (*models.Teams, error) {
 	var team models.Teams
-	if err := d.db.WithContext(ctx).
+	if err := transaction.DB(ctx, d.db).
 		Preload("Users").
 		First(&team, "team_name = ?", name).Error; err != nil {
 		return nil, err
@@ -32,3 +33,37 @@ func (d *Database) GetByName(ctx context.Context, name string) (*models.Teams, e
 
 	return &team, nil
 }
+
+func (d *Database) GetAll(ctx context.Context) ([]models.Teams, error) {
+	var teams []models.Teams
+	if err := transaction.DB(ctx, d.db).
+		Order("team_name").
+		Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (d *Database) List(ctx context.Context, f models.TeamFilter) ([]models.TeamSummary, error) {
+	query := transaction.DB(ctx, d.db).
+		Model(&models.Teams{}).
+		Select("teams.team_name AS team_name, " +
+			"COUNT(u.user_id) AS members, " +
+			"COALESCE(SUM(CASE WHEN u.is_active THEN 1 ELSE 0 END), 0) AS active_members").
+		Joins("LEFT JOIN users u ON u.team_name = teams.team_name").
+		Group("teams.team_name").
+		Order("teams.team_name").
+		Limit(f.Limit)
+
+	if f.After != "" {
+		query = query.Where("teams.team_name > ?", f.After)
+	}
+
+	var teams []models.TeamSummary
+	if err := query.Scan(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"mPR/internal/storage/dberrors"
+)
+
+type ctxKey struct{}
+
+type Database struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Database {
+	return &Database{
+		db: db,
+	}
+}
+
+// InTx runs fn in one database transaction. Repositories join it through the
+// context fn receives; when ctx already carries a transaction, fn runs in it.
+func (d *Database) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(ctxKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, ctxKey{}, tx))
+	})
+
+	return dberrors.Translate(err)
+}
+
+// DB returns the transaction carried by ctx, or db when there is none, bound
+// to ctx. Repositories use it instead of db.WithContext so every statement
+// joins the caller's transaction.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(ctxKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
@@ -2,11 +2,14 @@ package users
 
 import (
 	"context"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"mPR/internal/custom"
 	"mPR/internal/storage/models"
+	"mPR/internal/storage/repository/transaction"
 )
 
 type Database struct {
@@ -21,7 +24,7 @@ func New(db *gorm.DB) *Database {
 
 func (d *Database) GetByID(ctx context.Context, id string) (*models.Users, error) {
 	var user models.Users
-	if err := d.db.WithContext(ctx).
+	if err := transaction.DB(ctx, d.db).
 		First(&user, "user_id = ?", id).Error; err != nil {
 		return nil, err
 	}
@@ -31,7 +34,7 @@ func (d *Database) GetByID(ctx context.Context, id string) (*models.Users, error
 
 func (d *Database) GetActiveByTeam(ctx context.Context, team string) ([]models.Users, error) {
 	var users []models.Users
-	if err := d.db.WithContext(ctx).
+	if err := transaction.DB(ctx, d.db).
 		Where("team_name = ? AND is_active = true", team).
 		Find(&users).Error; err != nil {
 		return nil, err
@@ -41,7 +44,7 @@ func (d *Database) GetActiveByTeam(ctx context.Context, team string) ([]models.U
 }
 
 func (d *Database) UpdateIsActive(ctx context.Context, id string, active bool) error {
-	if err := d.db.WithContext(ctx).
+	if err := transaction.DB(ctx, d.db).
 		Model(&models.Users{}).
 		Where("user_id = ?", id).
 		Update("is_active", active).
@@ -52,15 +55,55 @@ func (d *Database) UpdateIsActive(ctx context.Context, id string, active bool) e
 	return nil
 }
 
+func (d *Database) UpdateSlackID(ctx context.Context, id string, slackID *string) error {
+	if err := transaction.DB(ctx, d.db).
+		Model(&models.Users{}).
+		Where("user_id = ?", id).
+		Update("slack_id", slackID).
+		Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *Database) UpdateEmail(ctx context.Context, id string, email *string, optOut bool) error {
+	if err := transaction.DB(ctx, d.db).
+		Model(&models.Users{}).
+		Where("user_id = ?", id).
+		Updates(map[string]any{"email": email, "email_opt_out": optOut}).
+		Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateOrUpdate upserts the members into the team. A member without a Slack
+// ID or email keeps the one already stored, and the email opt-out is never
+// changed here.
 func (d *Database) CreateOrUpdate(ctx context.Context, teamName string, members []models.Users) error {
+	if len(members) == 0 {
+		return nil
+	}
+
 	for i := range members {
 		members[i].TeamName = &teamName
 	}
 
-	if err := d.db.WithContext(ctx).
+	if err := transaction.DB(ctx, d.db).
 		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "user_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"username", "team_name", "is_active"}),
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: append(clause.AssignmentColumns([]string{"username", "team_name", "is_active"}),
+				clause.Assignment{
+					Column: clause.Column{Name: "slack_id"},
+					Value:  gorm.Expr("COALESCE(excluded.slack_id, users.slack_id)"),
+				},
+				clause.Assignment{
+					Column: clause.Column{Name: "email"},
+					Value:  gorm.Expr("COALESCE(excluded.email, users.email)"),
+				},
+			),
 		}).
 		Create(&members).Error; err != nil {
 		return err
@@ -68,3 +111,52 @@ func (d *Database) CreateOrUpdate(ctx context.Context, teamName string, members
 
 	return nil
 }
+
+func (d *Database) GetAll(ctx context.Context) ([]models.Users, error) {
+	var users []models.Users
+	if err := transaction.DB(ctx, d.db).
+		Order("user_id").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (d *Database) List(ctx context.Context, f models.UserFilter) ([]models.UserSummary, error) {
+	query := transaction.DB(ctx, d.db).
+		Model(&models.Users{}).
+		Select("users.*, (?) AS open_reviews",
+			d.db.Table("reviewers r").
+				Select("COUNT(*)").
+				Joins("JOIN pull_requests pr ON pr.pr_id = r.pr_id").
+				Where("r.reviewer_id = users.user_id AND pr.status = ?", custom.StatusOpen),
+		).
+		Order("users.user_id").
+		Limit(f.Limit)
+
+	if f.TeamName != "" {
+		query = query.Where("users.team_name = ?", f.TeamName)
+	}
+	if f.IsActive != nil {
+		query = query.Where("users.is_active = ?", *f.IsActive)
+	}
+	if f.UsernamePrefix != "" {
+		query = query.Where(
+			`LOWER(users.username) LIKE LOWER(?) ESCAPE '\'`,
+			likeEscaper.Replace(f.UsernamePrefix)+"%",
+		)
+	}
+	if f.After != "" {
+		query = query.Where("users.user_id > ?", f.After)
+	}
+
+	var users []models.UserSummary
+	if err := query.Scan(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"mPR/internal/config"
+	"mPR/internal/storage/dberrors"
+)
+
+func New(cfg config.SQLite, log *zap.Logger) *gorm.DB {
+	sqlDB := Open(cfg.Path)
+
+	db, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{
+		TranslateError: true,
+		NowFunc:        func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		log.Panic("Error connect database", zap.Error(err))
+		return nil
+	}
+
+	if err := db.Use(dberrors.Plugin{}); err != nil {
+		log.Panic("Error register error translation", zap.Error(err))
+		return nil
+	}
+
+	return db
+}
+
+// Open returns a handle on the database file at path with foreign keys
+// enforced, creating it when missing. SQLite allows one writer at a time, so
+// the pool holds a single connection and callers queue for it instead of
+// failing with SQLITE_BUSY.
+func Open(path string) *sql.DB {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+
+	db := sql.OpenDB(&connector{dsn: path + "?" + params.Encode()})
+	db.SetMaxOpenConns(1)
+
+	return db
+}
+
+// SQLite keeps timestamps as text, so ranges and keyset cursors compare them
+// as strings. connector stores every time in UTC to make that order match the
+// order of the instants, whatever zone the caller passed them in.
+type connector struct {
+	dsn string
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &utcConn{Conn: conn}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return &gosqlite.Driver{}
+}
+
+type utcConn struct {
+	driver.Conn
+}
+
+func (c *utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC()
+	}
+	nv.Value = value
+
+	return nil
+}
+
+func (c *utcConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *utcConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
@@ -0,0 +1,80 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"mPR/internal/tracing"
+)
+
+func setupProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	return exporter
+}
+
+func TestStart_DisabledKeepsContext(t *testing.T) {
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx := context.Background()
+	spanCtx, span := tracing.Start(ctx, "test")
+	tracing.End(span, nil)
+
+	assert.Equal(t, ctx, spanCtx)
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	exporter := setupProvider(t)
+
+	_, span := tracing.Start(context.Background(), "pull_requests.Create")
+	tracing.End(span, errors.New("boom"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "pull_requests.Create", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Len(t, spans[0].Events, 1)
+}
+
+func TestStart_PropagatesTraceparent(t *testing.T) {
+	exporter := setupProvider(t)
+
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(otelgin.Middleware("test"))
+	router.GET("/test", func(c *gin.Context) {
+		_, span := tracing.Start(c, "users.GetUserReviews")
+		tracing.End(span, nil)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, s := range spans {
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.SpanContext.TraceID().String())
+	}
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}